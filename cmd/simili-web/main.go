@@ -1,7 +1,7 @@
 // Author: Kaviru Hapuarachchi
 // GitHub: https://github.com/kavirubc
 // Created: 2026-02-10
-// Last Modified: 2026-02-10
+// Last Modified: 2026-10-18
 
 package main
 
@@ -14,6 +14,7 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/similigh/simili-bot/internal/core/config"
 	"github.com/similigh/simili-bot/internal/core/pipeline"
@@ -57,6 +58,9 @@ var (
 )
 
 func main() {
+	// Keep secrets resolved from the config out of the logs
+	log.SetOutput(config.NewRedactingWriter(os.Stderr))
+
 	// Load configuration
 	cfgPath := config.FindConfigPath("")
 	var err error
@@ -113,12 +117,20 @@ func initDependencies(cfg *config.Config) (*pipeline.Dependencies, error) {
 	deps := &pipeline.Dependencies{
 		DryRun: true, // Always dry-run for web UI
 	}
+	limits := cfg.RateLimitRegistry() // Shared by all requests
 
 	// Embedder (Gemini/OpenAI auto-selected by available keys)
 	embedder, err := ai.NewEmbedder(cfg.Embedding.APIKey, cfg.Embedding.Model)
 	if err != nil {
 		return nil, fmt.Errorf("failed to init embedder: %w", err)
 	}
+	if len(cfg.Embedding.Fallbacks) > 0 {
+		if err := embedder.AddFallbacks(providerSpecs(cfg.Embedding.Fallbacks)); err != nil {
+			return nil, fmt.Errorf("failed to configure embedding fallbacks: %w", err)
+		}
+		embedder.SetCircuitBreaker(cfg.Embedding.CircuitBreaker.FailureThreshold, breakerCooldown(cfg.Embedding.CircuitBreaker))
+	}
+	embedder.SetRateLimits(limits)
 	deps.Embedder = embedder
 
 	// Qdrant
@@ -139,7 +151,7 @@ func initDependencies(cfg *config.Config) (*pipeline.Dependencies, error) {
 
 	// GitHub (optional)
 	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
-		deps.GitHub = github.NewClient(context.Background(), token)
+		deps.GitHub = github.NewRateLimitedClient(context.Background(), token, limits.Get("github"))
 	}
 
 	// LLM Client (Gemini/OpenAI auto-selected by available keys)
@@ -155,11 +167,41 @@ func initDependencies(cfg *config.Config) (*pipeline.Dependencies, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to init LLM: %w", err)
 	}
+	if err := llm.LoadPrompts(cfg.Prompts.Dir, cfg.Prompts.Overrides); err != nil {
+		return nil, err
+	}
+	llm.SetDailyTokenBudget(cfg.LLM.MaxTokensPerDay, nil)
+	if len(cfg.LLM.Fallbacks) > 0 {
+		if err := llm.AddFallbacks(providerSpecs(cfg.LLM.Fallbacks)); err != nil {
+			return nil, fmt.Errorf("failed to configure LLM fallbacks: %w", err)
+		}
+		llm.SetCircuitBreaker(cfg.LLM.CircuitBreaker.FailureThreshold, breakerCooldown(cfg.LLM.CircuitBreaker))
+	}
+	llm.SetRateLimits(limits)
 	deps.LLMClient = llm
 
 	return deps, nil
 }
 
+// providerSpecs converts configured fallbacks into AI provider specs.
+func providerSpecs(fallbacks []config.ProviderFallback) []ai.ProviderSpec {
+	specs := make([]ai.ProviderSpec, 0, len(fallbacks))
+	for _, fb := range fallbacks {
+		specs = append(specs, ai.ProviderSpec{
+			Provider:   fb.Provider,
+			APIKey:     fb.APIKey,
+			Model:      fb.Model,
+			Dimensions: fb.Dimensions,
+		})
+	}
+	return specs
+}
+
+// breakerCooldown returns the configured circuit breaker cooldown.
+func breakerCooldown(cb config.CircuitBreakerConfig) time.Duration {
+	return time.Duration(cb.CooldownSeconds) * time.Second
+}
+
 func handleHealth(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(map[string]string{"status": "ok"}); err != nil {
@@ -257,7 +299,7 @@ func handleAnalyze(w http.ResponseWriter, r *http.Request) {
 
 func runPipeline(issue *pipeline.Issue) (*pipeline.Result, error) {
 	ctx := context.Background()
-	pCtx := pipeline.NewContext(ctx, issue, cfg)
+	pCtx := pipeline.NewContext(ctx, issue, cfg.ForRepository(issue.Org, issue.Repo))
 
 	registry := pipeline.NewRegistry()
 	steps.RegisterAll(registry)
@@ -1,6 +1,7 @@
 // Author: Kaviru Hapuarachchi
 // GitHub: https://github.com/kavirubc
 // Created: 2026-02-25
+// Last Modified: 2026-10-18
 
 package commands
 
@@ -108,6 +109,9 @@ func runAutoClose(cmd *cobra.Command, args []string) {
 		cfg = &config.Config{}
 	}
 
+	// Apply the repository's auto_close overrides before the CLI flags.
+	cfg = cfg.ForRepository(org, repoOnly)
+
 	// Apply grace-period-minutes override when the flag was explicitly provided.
 	// auto_closer.go only acts on GracePeriodMinutesOverride when it is > 0,
 	// so passing 0 on the CLI (meaning "expire instantly for tests") is stored
@@ -127,6 +131,7 @@ func runAutoClose(cmd *cobra.Command, args []string) {
 	// Run auto-closer
 	ghClient := github.NewClient(context.Background(), token)
 	closer := steps.NewAutoCloser(ghClient, cfg, autoCloseDryRun, verbose)
+	closer.WithFeedback(feedbackStore(cfg, token, org, repoOnly))
 
 	result, err := closer.Run(context.Background(), org, repoOnly)
 	if err != nil {
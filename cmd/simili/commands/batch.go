@@ -1,7 +1,7 @@
 // Author: Kaviru Hapuarachchi
 // GitHub: https://github.com/kavirubc
 // Created: 2026-02-10
-// Last Modified: 2026-02-10
+// Last Modified: 2026-10-18
 
 package commands
 
@@ -58,6 +58,7 @@ type JSONOutput struct {
 	TotalIssues int           `json:"total_issues"`
 	Successful  int           `json:"successful"`
 	Failed      int           `json:"failed"`
+	Usage       ai.Usage      `json:"usage"` // Totals across all results
 	Results     []ResultEntry `json:"results"`
 }
 
@@ -120,12 +121,67 @@ func runBatch(cmd *cobra.Command, args []string) {
 	}
 
 	// 2. Load Configuration
+	cfg := loadPipelineConfig()
+
+	// 3. Apply configuration overrides from flags
+	applyConfigOverrides(cfg)
+
+	// 4. Determine steps (exclude indexer — batch should never write to VDB)
+	stepNames := withoutIndexer(pipeline.ResolveSteps(cfg.Steps, batchWorkflow))
+	if verbose {
+		fmt.Printf("Pipeline steps: %v\n", stepNames)
+	}
+
+	// 5. Initialize dependencies with DryRun=true
+	deps, err := initializeDependencies(cfg)
+	if err != nil {
+		fmt.Printf("❌ Error initializing dependencies: %v\n", err)
+		os.Exit(1)
+	}
+	defer deps.Close()
+
+	// CRITICAL: Force dry-run mode to prevent any GitHub writes
+	deps.DryRun = true
+	if verbose {
+		fmt.Println("✓ Dry-run mode enabled (no GitHub writes will be performed)")
+	}
+
+	// 6. Process batch
+	fmt.Printf("Processing %d issues with %d workers...\n", len(issues), batchWorkers)
+	results := processBatch(ctx, issues, cfg, deps, stepNames, batchWorkers)
+
+	// 6.5. Resolve duplicate chains across batch results (post-processing)
+	resolveDuplicateChains(results)
+
+	// 7. Output results
+	if err := outputResults(results); err != nil {
+		fmt.Printf("❌ Error outputting results: %v\n", err)
+		os.Exit(1)
+	}
+
+	// 8. Print summary
+	successful := 0
+	failed := 0
+	for _, r := range results {
+		if r.Error == nil {
+			successful++
+		} else {
+			failed++
+		}
+	}
+	fmt.Printf("\n✓ Batch processing completed: %d successful, %d failed\n", successful, failed)
+}
+
+// loadPipelineConfig loads simili.yaml (with inheritance) from --config or the
+// default locations, falling back to defaults when none is found.
+func loadPipelineConfig() *config.Config {
 	cfgPath := cfgFile
 	if cfgPath == "" {
 		cfgPath = config.FindConfigPath("")
 	}
 
 	var cfg *config.Config
+	var err error
 	if cfgPath != "" {
 		// Prepare fetcher for inheritance
 		var configToken string
@@ -161,11 +217,11 @@ func runBatch(cmd *cobra.Command, args []string) {
 		cfg.ApplyDefaults()
 	}
 
-	// 3. Apply configuration overrides from flags
-	applyConfigOverrides(cfg)
+	return cfg
+}
 
-	// 4. Determine steps (exclude indexer — batch should never write to VDB)
-	stepNames := pipeline.ResolveSteps(cfg.Steps, batchWorkflow)
+// withoutIndexer removes the indexer step so dry runs never write to the VDB.
+func withoutIndexer(stepNames []string) []string {
 	filtered := make([]string, 0, len(stepNames))
 	for _, name := range stepNames {
 		if name == "indexer" {
@@ -176,49 +232,7 @@ func runBatch(cmd *cobra.Command, args []string) {
 		}
 		filtered = append(filtered, name)
 	}
-	stepNames = filtered
-	if verbose {
-		fmt.Printf("Pipeline steps: %v\n", stepNames)
-	}
-
-	// 5. Initialize dependencies with DryRun=true
-	deps, err := initializeDependencies(cfg)
-	if err != nil {
-		fmt.Printf("❌ Error initializing dependencies: %v\n", err)
-		os.Exit(1)
-	}
-	defer deps.Close()
-
-	// CRITICAL: Force dry-run mode to prevent any GitHub writes
-	deps.DryRun = true
-	if verbose {
-		fmt.Println("✓ Dry-run mode enabled (no GitHub writes will be performed)")
-	}
-
-	// 6. Process batch
-	fmt.Printf("Processing %d issues with %d workers...\n", len(issues), batchWorkers)
-	results := processBatch(ctx, issues, cfg, deps, stepNames)
-
-	// 6.5. Resolve duplicate chains across batch results (post-processing)
-	resolveDuplicateChains(results)
-
-	// 7. Output results
-	if err := outputResults(results); err != nil {
-		fmt.Printf("❌ Error outputting results: %v\n", err)
-		os.Exit(1)
-	}
-
-	// 8. Print summary
-	successful := 0
-	failed := 0
-	for _, r := range results {
-		if r.Error == nil {
-			successful++
-		} else {
-			failed++
-		}
-	}
-	fmt.Printf("\n✓ Batch processing completed: %d successful, %d failed\n", successful, failed)
+	return filtered
 }
 
 // loadIssues reads and parses a JSON file containing an array of issues
@@ -281,12 +295,17 @@ func applyConfigOverrides(cfg *config.Config) {
 // initializeDependencies initializes all required dependencies for pipeline execution
 func initializeDependencies(cfg *config.Config) (*pipeline.Dependencies, error) {
 	deps := &pipeline.Dependencies{}
+	limits := cfg.RateLimitRegistry() // Shared by all workers
 
 	// Initialize Embedder (Gemini/OpenAI auto-selected by available keys)
 	embedder, err := ai.NewEmbedder(cfg.Embedding.APIKey, cfg.Embedding.Model)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize embedder: %w", err)
 	}
+	if err := applyEmbeddingFallbacks(embedder, cfg); err != nil {
+		return nil, fmt.Errorf("failed to configure embedding fallbacks: %w", err)
+	}
+	embedder.SetRateLimits(limits)
 	deps.Embedder = embedder
 	if verbose {
 		fmt.Printf("✓ Initialized Embedder (%s) with model: %s\n", embedder.Provider(), embedder.Model())
@@ -326,7 +345,7 @@ func initializeDependencies(cfg *config.Config) (*pipeline.Dependencies, error)
 		token = os.Getenv("GITHUB_TOKEN")
 	}
 	if token != "" {
-		ghClient := github.NewClient(context.Background(), token)
+		ghClient := github.NewRateLimitedClient(context.Background(), token, limits.Get("github"))
 		deps.GitHub = ghClient
 		if verbose {
 			fmt.Println("✓ Initialized GitHub client")
@@ -348,6 +367,10 @@ func initializeDependencies(cfg *config.Config) (*pipeline.Dependencies, error)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize LLM client: %w", err)
 	}
+	if err := configureLLM(llm, cfg, nil); err != nil {
+		return nil, err
+	}
+	llm.SetRateLimits(limits)
 	deps.LLMClient = llm
 	if verbose {
 		fmt.Printf("✓ Initialized LLM client (%s) with model: %s\n", llm.Provider(), llm.Model())
@@ -357,13 +380,16 @@ func initializeDependencies(cfg *config.Config) (*pipeline.Dependencies, error)
 }
 
 // processBatch processes all issues using a worker pool pattern
-func processBatch(ctx context.Context, issues []pipeline.Issue, cfg *config.Config, deps *pipeline.Dependencies, stepNames []string) []BatchResult {
-	jobs := make(chan BatchJob, batchWorkers)
-	results := make(chan BatchResult, batchWorkers)
+func processBatch(ctx context.Context, issues []pipeline.Issue, cfg *config.Config, deps *pipeline.Dependencies, stepNames []string, workers int) []BatchResult {
+	if workers < 1 {
+		workers = 1
+	}
+	jobs := make(chan BatchJob, workers)
+	results := make(chan BatchResult, workers)
 	var wg sync.WaitGroup
 
 	// Start workers
-	for i := 0; i < batchWorkers; i++ {
+	for i := 0; i < workers; i++ {
 		wg.Add(1)
 		go func(workerID int) {
 			defer wg.Done()
@@ -472,6 +498,7 @@ func outputResults(results []BatchResult) error {
 func formatJSON(results []BatchResult) ([]byte, error) {
 	successful := 0
 	failed := 0
+	var usage ai.Usage
 	entries := make([]ResultEntry, len(results))
 
 	for i, r := range results {
@@ -485,6 +512,9 @@ func formatJSON(results []BatchResult) ([]byte, error) {
 		} else {
 			successful++
 		}
+		if r.Result != nil {
+			addUsage(&usage, r.Result.Usage)
+		}
 		entries[i] = entry
 	}
 
@@ -493,6 +523,7 @@ func formatJSON(results []BatchResult) ([]byte, error) {
 		TotalIssues: len(results),
 		Successful:  successful,
 		Failed:      failed,
+		Usage:       usage,
 		Results:     entries,
 	}
 
@@ -526,8 +557,15 @@ func formatCSV(results []BatchResult) ([]byte, error) {
 		"transfer_reason",
 		"quality_score",
 		"suggested_labels",
+		"llm_calls",
+		"prompt_tokens",
+		"completion_tokens",
+		"embedding_tokens",
+		"cost_usd",
+		"latency_ms",
 		"error",
 	}
+	errorCol := len(header) - 1
 	if err := writer.Write(header); err != nil {
 		return nil, err
 	}
@@ -543,7 +581,7 @@ func formatCSV(results []BatchResult) ([]byte, error) {
 		row[5] = r.Issue.State
 
 		if r.Error != nil {
-			row[20] = r.Error.Error()
+			row[errorCol] = r.Error.Error()
 		} else if r.Result != nil {
 			row[6] = strconv.FormatBool(r.Result.Skipped)
 			row[7] = r.Result.SkipReason
@@ -566,6 +604,12 @@ func formatCSV(results []BatchResult) ([]byte, error) {
 			row[17] = r.Result.TransferReason
 			row[18] = fmt.Sprintf("%.4f", r.Result.QualityScore)
 			row[19] = strings.Join(r.Result.SuggestedLabels, ";")
+			row[20] = strconv.Itoa(r.Result.Usage.LLMCalls)
+			row[21] = strconv.Itoa(r.Result.Usage.PromptTokens)
+			row[22] = strconv.Itoa(r.Result.Usage.CompletionTokens)
+			row[23] = strconv.Itoa(r.Result.Usage.EmbeddingTokens)
+			row[24] = fmt.Sprintf("%.6f", r.Result.Usage.CostUSD)
+			row[25] = strconv.FormatInt(r.Result.Usage.LatencyMs, 10)
 		}
 
 		if err := writer.Write(row); err != nil {
@@ -581,6 +625,18 @@ func formatCSV(results []BatchResult) ([]byte, error) {
 	return []byte(buf.String()), nil
 }
 
+// addUsage adds the usage of one run to a running total.
+func addUsage(total *ai.Usage, u ai.Usage) {
+	total.LLMCalls += u.LLMCalls
+	total.PromptTokens += u.PromptTokens
+	total.CompletionTokens += u.CompletionTokens
+	total.EmbeddingCalls += u.EmbeddingCalls
+	total.EmbeddingTokens += u.EmbeddingTokens
+	total.CostUSD += u.CostUSD
+	total.LatencyMs += u.LatencyMs
+	total.BudgetExceeded = total.BudgetExceeded || u.BudgetExceeded
+}
+
 // resolveDuplicateChains resolves transitive duplicate relationships.
 // If issue A is duplicate of B, and B is duplicate of C, this updates A to point to C.
 func resolveDuplicateChains(results []BatchResult) {
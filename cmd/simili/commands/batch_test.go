@@ -1,7 +1,7 @@
 // Author: Kaviru Hapuarachchi
 // GitHub: https://github.com/kavirubc
 // Created: 2026-02-10
-// Last Modified: 2026-02-10
+// Last Modified: 2026-10-18
 
 package commands
 
@@ -15,6 +15,7 @@ import (
 
 	"github.com/similigh/simili-bot/internal/core/config"
 	"github.com/similigh/simili-bot/internal/core/pipeline"
+	"github.com/similigh/simili-bot/internal/integrations/ai"
 )
 
 func TestLoadIssues(t *testing.T) {
@@ -449,6 +450,40 @@ func TestFormatCSV_FieldEscaping(t *testing.T) {
 	}
 }
 
+func TestFormatOutput_Usage(t *testing.T) {
+	results := []BatchResult{
+		{Issue: pipeline.Issue{Number: 1}, Result: &pipeline.Result{IssueNumber: 1, Usage: ai.Usage{LLMCalls: 2, PromptTokens: 900, CompletionTokens: 100, CostUSD: 0.0012, LatencyMs: 850}}},
+		{Issue: pipeline.Issue{Number: 2}, Result: &pipeline.Result{IssueNumber: 2, Usage: ai.Usage{LLMCalls: 1, PromptTokens: 100, EmbeddingTokens: 30, BudgetExceeded: true}}},
+	}
+
+	data, err := formatJSON(results)
+	if err != nil {
+		t.Fatalf("formatJSON() error = %v", err)
+	}
+	var output JSONOutput
+	if err := json.Unmarshal(data, &output); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if output.Usage.LLMCalls != 3 || output.Usage.PromptTokens != 1000 || output.Usage.EmbeddingTokens != 30 {
+		t.Errorf("unexpected usage totals: %+v", output.Usage)
+	}
+	if !output.Usage.BudgetExceeded {
+		t.Error("expected BudgetExceeded in totals")
+	}
+
+	data, err = formatCSV(results)
+	if err != nil {
+		t.Fatalf("formatCSV() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if !strings.Contains(lines[0], "prompt_tokens,completion_tokens,embedding_tokens,cost_usd,latency_ms,error") {
+		t.Errorf("CSV header missing usage columns: %s", lines[0])
+	}
+	if !strings.HasSuffix(lines[1], ",2,900,100,0,0.001200,850,") {
+		t.Errorf("CSV row missing usage values: %s", lines[1])
+	}
+}
+
 // testError is a simple error type for testing
 type testError struct {
 	msg string
@@ -0,0 +1,127 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/similigh/simili-bot/internal/core/config"
+	"github.com/similigh/simili-bot/internal/integrations/github"
+	"github.com/similigh/simili-bot/internal/steps"
+)
+
+var (
+	closeStaleRepo      string
+	closeStaleDryRun    bool
+	closeStaleStaleDays int
+)
+
+var closeStaleCmd = &cobra.Command{
+	Use:   "close-stale",
+	Short: "Close needs-info issues whose author never replied",
+	Long: `Scan all open issues labelled with needs_info.label ("needs-info" by default).
+
+Close those where the label was applied more than needs_info.stale_days ago
+(14 by default) and the issue author has not commented since, posting
+needs_info.close_message first.
+
+Run it on a schedule, next to auto-close.`,
+	Run: runCloseStale,
+}
+
+func init() {
+	rootCmd.AddCommand(closeStaleCmd)
+
+	closeStaleCmd.Flags().StringVar(&closeStaleRepo, "repo", "", "Repository to scan (owner/name); falls back to GITHUB_REPOSITORY env var")
+	closeStaleCmd.Flags().BoolVar(&closeStaleDryRun, "dry-run", false, "Print what would be closed without making any changes")
+	closeStaleCmd.Flags().IntVar(&closeStaleStaleDays, "stale-days", 0, "Override needs_info.stale_days")
+}
+
+func runCloseStale(cmd *cobra.Command, args []string) {
+	// Resolve org/repo
+	repo := closeStaleRepo
+	if repo == "" {
+		repo = os.Getenv("GITHUB_REPOSITORY")
+	}
+	if repo == "" {
+		fmt.Fprintln(os.Stderr, "Error: --repo or GITHUB_REPOSITORY is required")
+		os.Exit(1)
+	}
+	org, repoOnly, ok := strings.Cut(repo, "/")
+	if !ok || org == "" || repoOnly == "" {
+		fmt.Fprintf(os.Stderr, "Error: invalid repository format %q (expected owner/name)\n", repo)
+		os.Exit(1)
+	}
+
+	token := os.Getenv("GITHUB_TOKEN")
+	if token == "" {
+		fmt.Fprintln(os.Stderr, "Error: GITHUB_TOKEN is required")
+		os.Exit(1)
+	}
+
+	// Load config
+	actualCfgPath := cfgFile
+	if actualCfgPath == "" {
+		actualCfgPath = config.FindConfigPath("")
+	}
+
+	var cfg *config.Config
+	var err error
+	if actualCfgPath != "" {
+		fetcher := func(ref string) ([]byte, error) {
+			o, r, branch, path, ferr := config.ParseExtendsRef(ref)
+			if ferr != nil {
+				return nil, ferr
+			}
+			ghc := github.NewClient(context.Background(), token)
+			return ghc.GetFileContent(context.Background(), o, r, path, branch)
+		}
+		cfg, err = config.LoadWithInheritance(actualCfgPath, fetcher)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to load config from %s: %v — using defaults\n", actualCfgPath, err)
+			cfg = &config.Config{}
+			cfg.ApplyDefaults()
+		} else if verbose {
+			fmt.Printf("Loaded config from %s\n", actualCfgPath)
+		}
+	} else {
+		if verbose {
+			fmt.Fprintln(os.Stderr, "No config file found, using defaults")
+		}
+		cfg = &config.Config{}
+		cfg.ApplyDefaults()
+	}
+	cfg = cfg.ForRepository(org, repoOnly)
+
+	if closeStaleStaleDays > 0 {
+		cfg.NeedsInfo.StaleDays = closeStaleStaleDays
+	}
+
+	// Run stale closer
+	ghClient := github.NewClient(context.Background(), token)
+	closer := steps.NewStaleCloser(ghClient, cfg, closeStaleDryRun, verbose)
+	closer.WithFeedback(feedbackStore(cfg, token, org, repoOnly))
+
+	result, err := closer.Run(context.Background(), org, repoOnly)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Print result as JSON to stdout
+	out, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error marshaling result: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println(string(out))
+}
@@ -0,0 +1,226 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/similigh/simili-bot/internal/core/config"
+	"github.com/similigh/simili-bot/internal/core/pipeline"
+	"github.com/similigh/simili-bot/internal/integrations/github"
+	"github.com/similigh/simili-bot/internal/steps"
+)
+
+var (
+	configShowResolved bool
+	configFormat       string
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the simili configuration",
+}
+
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Print the configuration",
+	Long: `Print the local configuration file as parsed. With --resolved, follow the
+extends chain (fetching remote configs with GITHUB_TOKEN), merge every level
+and apply defaults, printing the configuration the pipeline actually uses.
+
+API keys and values resolved from ${file:...} or ${cmd:...} references are
+masked in the output.`,
+	Run: runConfigShow,
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Strictly validate the configuration file",
+	Long: `Check the configuration file for unknown keys (typos), values of the wrong
+type, out-of-range thresholds, unknown enum values, unknown steps or
+workflows, and transfer rule targets missing from repositories. Problems
+are reported with their line and column, and the command exits non-zero
+if any are found.
+
+When the file extends another config, the resolved repositories list is
+used for the transfer target check (fetching with GITHUB_TOKEN).`,
+	Run: runConfigValidate,
+}
+
+var configSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON Schema for simili.yaml",
+	Run:   runConfigSchema,
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configShowCmd)
+	configCmd.AddCommand(configValidateCmd)
+	configCmd.AddCommand(configSchemaCmd)
+
+	configShowCmd.Flags().BoolVar(&configShowResolved, "resolved", false, "Resolve extends and apply defaults")
+	configValidateCmd.Flags().StringVar(&configFormat, "format", "text", "Output format: text or json")
+}
+
+// remoteConfigFetcher fetches configs referenced by extends from GitHub.
+func remoteConfigFetcher(token string) func(ref string) ([]byte, error) {
+	return func(ref string) ([]byte, error) {
+		org, repo, branch, path, err := config.ParseExtendsRef(ref)
+		if err != nil {
+			return nil, err
+		}
+		if token == "" {
+			return nil, fmt.Errorf("GITHUB_TOKEN required to fetch remote config %s", ref)
+		}
+		ghClient := github.NewClient(context.Background(), token)
+		return ghClient.GetFileContent(context.Background(), org, repo, path, branch)
+	}
+}
+
+func runConfigShow(cmd *cobra.Command, args []string) {
+	cfgPath := config.FindConfigPath(cfgFile)
+	if cfgPath == "" {
+		fmt.Fprintln(os.Stderr, "Error: config file not found")
+		os.Exit(1)
+	}
+
+	var cfg *config.Config
+	var err error
+	if configShowResolved {
+		cfg, err = config.Resolve(cfgPath, remoteConfigFetcher(os.Getenv("GITHUB_TOKEN")))
+	} else {
+		var data []byte
+		if data, err = os.ReadFile(cfgPath); err == nil {
+			cfg, err = config.Parse(data)
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to load config from %s: %v\n", cfgPath, err)
+		os.Exit(1)
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(cfg.Redacted()); err != nil {
+		fmt.Fprintf(os.Stderr, "Error marshaling config: %v\n", err)
+		os.Exit(1)
+	}
+	enc.Close()
+	fmt.Printf("# %s\n%s", cfgPath, config.RedactSecrets(buf.String()))
+}
+
+func runConfigValidate(cmd *cobra.Command, args []string) {
+	cfgPath := config.FindConfigPath(cfgFile)
+	if cfgPath == "" {
+		fmt.Fprintln(os.Stderr, "Error: config file not found")
+		os.Exit(1)
+	}
+	data, err := os.ReadFile(cfgPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to read config: %v\n", err)
+		os.Exit(1)
+	}
+
+	report := config.ValidateStrict(data)
+	if report.Config != nil {
+		repos, checkTargets := report.Config.Repositories, true
+		if report.Config.Extends != "" {
+			resolved, err := config.Resolve(cfgPath, remoteConfigFetcher(os.Getenv("GITHUB_TOKEN")))
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: could not resolve extends (%v); skipping transfer target check\n", err)
+				checkTargets = false
+			} else {
+				repos = resolved.Repositories
+			}
+		}
+		if checkTargets {
+			report.CheckTransferTargets(repos)
+		}
+		checkStepNames(report)
+	}
+	sort.SliceStable(report.Issues, func(i, j int) bool {
+		return report.Issues[i].Line < report.Issues[j].Line
+	})
+
+	switch strings.ToLower(configFormat) {
+	case "json":
+		issues := report.Issues
+		if issues == nil {
+			issues = []config.ValidationIssue{}
+		}
+		out, err := json.MarshalIndent(issues, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error marshaling issues: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(out))
+	case "text":
+		writeValidationIssues(os.Stdout, cfgPath, report.Issues)
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unsupported format: %s (use 'text' or 'json')\n", configFormat)
+		os.Exit(1)
+	}
+	if len(report.Issues) > 0 {
+		os.Exit(1)
+	}
+}
+
+// checkStepNames reports unknown workflow presets and step names, top-level
+// and per repository.
+func checkStepNames(report *config.ValidationReport) {
+	registry := pipeline.NewRegistry()
+	steps.RegisterAll(registry)
+
+	check := func(prefix, workflow string, names []string) {
+		if workflow != "" {
+			if _, ok := pipeline.GetPreset(workflow); !ok {
+				report.Addf(prefix+"workflow", "unknown workflow %q", workflow)
+			}
+		}
+		for i, name := range names {
+			if !registry.Has(name) {
+				report.Addf(fmt.Sprintf("%ssteps[%d]", prefix, i), "unknown step %q", name)
+			}
+		}
+	}
+	check("", report.Config.Workflow, report.Config.Steps)
+	for i, repo := range report.Config.Repositories {
+		check(fmt.Sprintf("repositories[%d].", i), repo.Workflow, repo.Steps)
+	}
+}
+
+// writeValidationIssues prints issues as file:line:col: path: message.
+func writeValidationIssues(w io.Writer, path string, issues []config.ValidationIssue) {
+	if len(issues) == 0 {
+		fmt.Fprintf(w, "%s: OK\n", path)
+		return
+	}
+	for _, issue := range issues {
+		fmt.Fprintf(w, "%s:%s\n", path, issue)
+	}
+	fmt.Fprintf(w, "%d problem(s) found\n", len(issues))
+}
+
+func runConfigSchema(cmd *cobra.Command, args []string) {
+	schema, err := config.JSONSchema()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error generating schema: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println(string(schema))
+}
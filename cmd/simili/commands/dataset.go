@@ -0,0 +1,341 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package commands
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/spf13/cobra"
+
+	similiConfig "github.com/similigh/simili-bot/internal/core/config"
+	"github.com/similigh/simili-bot/internal/core/pipeline"
+	similiGithub "github.com/similigh/simili-bot/internal/integrations/github"
+)
+
+var (
+	datasetRepo           string
+	datasetOutFile        string
+	datasetToken          string
+	datasetState          string
+	datasetSince          string
+	datasetLimit          int
+	datasetDuplicateLabel string
+	datasetTransferSource string
+)
+
+// duplicateOfPattern matches GitHub's "Duplicate of #N" marker, also written
+// with a full issue URL. It must stand on its own line, so prose such as the
+// bot's "might be a duplicate of #N" does not count.
+var duplicateOfPattern = regexp.MustCompile(`(?im)^[ \t]*duplicate of[ \t]+(?:#|https://github\.com/([\w.-]+)/([\w.-]+)/issues/)(\d+)[ \t]*$`)
+
+// botCommentMarker starts the hidden markers simili writes into its own
+// comments (report, state block, auto-close and stale-close notices).
+const botCommentMarker = "<!-- simili-bot-"
+
+// datasetCmd groups commands that work with evaluation datasets.
+var datasetCmd = &cobra.Command{
+	Use:   "dataset",
+	Short: "Build evaluation datasets for simili eval",
+}
+
+var datasetBuildCmd = &cobra.Command{
+	Use:   "build",
+	Short: "Harvest a labeled dataset from repository history",
+	Long: `Build a JSONL evaluation dataset from the history of a GitHub repository.
+
+Ground truth is taken from what maintainers already did:
+  - Duplicates: closed issues labelled as duplicates or closed as duplicate,
+    with the original found in a "Duplicate of #N" comment.
+  - Routing: issues transferred into the repository. Use --transfer-source to
+    present them as filed in the repository they were transferred from.
+  - Labels: the final labels of each issue.
+
+Each issue is written as it looked when opened (open, no labels), so the
+dataset can be fed straight into simili eval.
+
+Examples:
+  simili dataset build --repo my-org/backend --out-file labeled.jsonl
+  simili dataset build --repo my-org/backend --transfer-source my-org/intake --limit 500`,
+	Run: runDatasetBuild,
+}
+
+func init() {
+	rootCmd.AddCommand(datasetCmd)
+	datasetCmd.AddCommand(datasetBuildCmd)
+
+	datasetBuildCmd.Flags().StringVar(&datasetRepo, "repo", "", "Repository to harvest (owner/name)")
+	datasetBuildCmd.Flags().StringVar(&datasetOutFile, "out-file", "", "Output file path (stdout if not specified)")
+	datasetBuildCmd.Flags().StringVar(&datasetToken, "token", "", "GitHub token (optional, defaults to GITHUB_TOKEN env var)")
+	datasetBuildCmd.Flags().StringVar(&datasetState, "state", "closed", "Issue state to harvest: closed or all")
+	datasetBuildCmd.Flags().StringVar(&datasetSince, "since", "", "Only harvest issues updated after this timestamp (ISO8601)")
+	datasetBuildCmd.Flags().IntVar(&datasetLimit, "limit", 0, "Maximum number of issues to harvest (0 = no limit)")
+	datasetBuildCmd.Flags().StringVar(&datasetDuplicateLabel, "duplicate-label", "duplicate", "Label maintainers use to mark duplicates")
+	datasetBuildCmd.Flags().StringVar(&datasetTransferSource, "transfer-source", "", "Repository transferred issues were originally filed in (owner/name)")
+
+	if err := datasetBuildCmd.MarkFlagRequired("repo"); err != nil {
+		log.Fatalf("Failed to mark repo flag as required: %v", err)
+	}
+}
+
+// harvestOptions controls how an issue's history is turned into ground truth.
+type harvestOptions struct {
+	Org            string
+	Repo           string
+	DuplicateLabel string
+	TransferSource string   // owner/name; empty keeps transferred issues in place
+	BotUsers       []string // Authors whose comments are never ground truth
+}
+
+func runDatasetBuild(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+
+	org, repo, ok := strings.Cut(datasetRepo, "/")
+	if !ok || org == "" || repo == "" {
+		log.Fatalf("Invalid repo format: %s (expected owner/name)", datasetRepo)
+	}
+	if datasetTransferSource != "" {
+		if parts := strings.Split(datasetTransferSource, "/"); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			log.Fatalf("Invalid transfer source: %s (expected owner/name)", datasetTransferSource)
+		}
+	}
+	if datasetState != "closed" && datasetState != "all" {
+		log.Fatalf("Invalid state: %s (use 'closed' or 'all')", datasetState)
+	}
+
+	token := datasetToken
+	if token == "" {
+		token = os.Getenv("GITHUB_TOKEN")
+	}
+	if token == "" {
+		log.Fatal("GitHub token is required (use --token or GITHUB_TOKEN env var)")
+	}
+
+	// The config is optional here; it only supplies GitHub rate limits and
+	// the bot accounts whose comments must not count as ground truth.
+	var ghClient *similiGithub.Client
+	var botUsers []string
+	if cfgPath := similiConfig.FindConfigPath(cfgFile); cfgPath != "" {
+		cfg, err := similiConfig.Load(cfgPath)
+		if err != nil {
+			log.Fatalf("Failed to load config: %v", err)
+		}
+		ghClient = similiGithub.NewRateLimitedClient(ctx, token, cfg.RateLimitRegistry().Get("github"))
+		botUsers = cfg.BotUsers
+	} else {
+		ghClient = similiGithub.NewClient(ctx, token)
+	}
+
+	opts := &github.IssueListByRepoOptions{
+		State:       datasetState,
+		Sort:        "created",
+		Direction:   "asc",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	if datasetSince != "" {
+		t, err := time.Parse(time.RFC3339, datasetSince)
+		if err != nil {
+			log.Fatalf("Invalid --since timestamp: %v", err)
+		}
+		opts.Since = t
+	}
+
+	var out io.Writer = os.Stdout
+	if datasetOutFile != "" {
+		f, err := os.Create(datasetOutFile)
+		if err != nil {
+			log.Fatalf("Failed to create output file: %v", err)
+		}
+		defer f.Close()
+		out = f
+	}
+	w := bufio.NewWriter(out)
+	defer w.Flush()
+	enc := json.NewEncoder(w)
+
+	harvest := harvestOptions{
+		Org:            org,
+		Repo:           repo,
+		DuplicateLabel: datasetDuplicateLabel,
+		TransferSource: datasetTransferSource,
+		BotUsers:       botUsers,
+	}
+
+	var written, duplicates, transfers int
+	for {
+		issues, resp, err := ghClient.ListIssues(ctx, org, repo, opts)
+		if err != nil {
+			log.Fatalf("Error listing issues page %d: %v", opts.Page, err)
+		}
+
+		for _, issue := range issues {
+			if issue.IsPullRequest() {
+				continue
+			}
+			if datasetLimit > 0 && written >= datasetLimit {
+				break
+			}
+
+			number := issue.GetNumber()
+			events, err := ghClient.ListIssueEvents(ctx, org, repo, number)
+			if err != nil {
+				log.Printf("Skipping #%d: %v", number, err)
+				continue
+			}
+			var comments []*github.IssueComment
+			if issue.GetState() == "closed" {
+				if comments, err = listAllComments(ctx, ghClient, org, repo, number); err != nil {
+					log.Printf("Skipping #%d: %v", number, err)
+					continue
+				}
+			}
+
+			c := harvestCase(issue, comments, events, harvest)
+			if err := enc.Encode(c); err != nil {
+				log.Fatalf("Failed to write case for #%d: %v", number, err)
+			}
+			written++
+			if c.DuplicateOf != nil && *c.DuplicateOf > 0 {
+				duplicates++
+			}
+			if !strings.EqualFold(c.Repo, c.Issue.Org+"/"+c.Issue.Repo) {
+				transfers++
+			}
+		}
+
+		if (datasetLimit > 0 && written >= datasetLimit) || resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	log.Printf("Harvested %d issues from %s/%s (%d duplicates, %d transfers)", written, org, repo, duplicates, transfers)
+}
+
+// listAllComments fetches every comment on an issue.
+func listAllComments(ctx context.Context, gh *similiGithub.Client, org, repo string, number int) ([]*github.IssueComment, error) {
+	var all []*github.IssueComment
+	opts := &github.IssueListCommentsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		comments, resp, err := gh.ListComments(ctx, org, repo, number, opts)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, comments...)
+		if resp == nil || resp.NextPage == 0 {
+			return all, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// harvestCase turns an issue and its history into an evaluation case.
+func harvestCase(issue *github.Issue, comments []*github.IssueComment, events []*github.IssueEvent, opts harvestOptions) EvalCase {
+	c := EvalCase{
+		Issue: pipeline.Issue{
+			Org:       opts.Org,
+			Repo:      opts.Repo,
+			Number:    issue.GetNumber(),
+			Title:     issue.GetTitle(),
+			Body:      issue.GetBody(),
+			State:     "open",
+			Author:    issue.GetUser().GetLogin(),
+			URL:       issue.GetHTMLURL(),
+			CreatedAt: issue.GetCreatedAt().Time,
+		},
+		Repo:   opts.Org + "/" + opts.Repo,
+		Labels: []string{},
+	}
+
+	// Routing: an issue transferred in belonged here, not where it was filed.
+	if opts.TransferSource != "" && wasTransferred(events) {
+		c.Issue.Org, c.Issue.Repo, _ = strings.Cut(opts.TransferSource, "/")
+	}
+
+	// Duplicates: only closed issues have a verdict, and only a maintainer's
+	// duplicate label or close reason makes one a duplicate. Without a
+	// "Duplicate of" reference naming the original it is left unscored.
+	if issue.GetState() == "closed" {
+		markedDuplicate := strings.EqualFold(issue.GetStateReason(), "duplicate")
+		for _, l := range issue.Labels {
+			if strings.EqualFold(l.GetName(), opts.DuplicateLabel) {
+				markedDuplicate = true
+			}
+		}
+		if !markedDuplicate {
+			c.DuplicateOf = new(int)
+		} else if target := duplicateTarget(comments, opts.Org, opts.Repo, opts.BotUsers); target > 0 {
+			c.DuplicateOf = &target
+		}
+	}
+
+	// Labels: the final labels, minus those describing duplicate status.
+	for _, l := range issue.Labels {
+		name := l.GetName()
+		if name == "" || strings.EqualFold(name, opts.DuplicateLabel) || strings.EqualFold(name, potentialDuplicateLabel) {
+			continue
+		}
+		c.Labels = append(c.Labels, name)
+	}
+
+	return c
+}
+
+// wasTransferred reports whether the issue was transferred into its repository.
+func wasTransferred(events []*github.IssueEvent) bool {
+	for _, e := range events {
+		if e.GetEvent() == "transferred" {
+			return true
+		}
+	}
+	return false
+}
+
+// duplicateTarget returns the issue named by the most recent "Duplicate of"
+// comment, or 0 if there is none. References to other repositories and
+// comments written by the bot are ignored, so eval never scores the bot
+// against its own verdicts.
+func duplicateTarget(comments []*github.IssueComment, org, repo string, botUsers []string) int {
+	for i := len(comments) - 1; i >= 0; i-- {
+		body := comments[i].GetBody()
+		if isDatasetBot(comments[i].GetUser().GetLogin(), botUsers) || strings.Contains(body, botCommentMarker) {
+			continue
+		}
+		for _, m := range duplicateOfPattern.FindAllStringSubmatch(body, -1) {
+			if m[1] != "" && (!strings.EqualFold(m[1], org) || !strings.EqualFold(m[2], repo)) {
+				continue
+			}
+			if n, err := strconv.Atoi(m[3]); err == nil && n > 0 {
+				return n
+			}
+		}
+	}
+	return 0
+}
+
+// isDatasetBot reports whether login is a bot account or one of botUsers.
+func isDatasetBot(login string, botUsers []string) bool {
+	if strings.HasSuffix(login, "[bot]") ||
+		strings.HasPrefix(login, "gh-simili") ||
+		strings.EqualFold(login, "simili-bot") {
+		return true
+	}
+	for _, u := range botUsers {
+		if strings.EqualFold(login, u) {
+			return true
+		}
+	}
+	return false
+}
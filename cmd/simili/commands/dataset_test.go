@@ -0,0 +1,172 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package commands
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/v60/github"
+)
+
+func comment(body string) *github.IssueComment {
+	return &github.IssueComment{Body: github.String(body)}
+}
+
+func botComment(login, body string) *github.IssueComment {
+	return &github.IssueComment{Body: github.String(body), User: &github.User{Login: github.String(login)}}
+}
+
+func TestDuplicateTarget(t *testing.T) {
+	tests := []struct {
+		name     string
+		comments []*github.IssueComment
+		want     int
+	}{
+		{"no comments", nil, 0},
+		{"github marker", []*github.IssueComment{comment("Duplicate of #12")}, 12},
+		{"own line among text", []*github.IssueComment{comment("Thanks for the report.\n\nduplicate of #7\n")}, 7},
+		{"lowercase in sentence", []*github.IssueComment{comment("Closing, this is a duplicate of #7.")}, 0},
+		{"bot report", []*github.IssueComment{comment("<!-- simili-bot-report -->\nThis issue might be a duplicate of #7.\nDuplicate of #7")}, 0},
+		{"bot user", []*github.IssueComment{botComment("simili-app[bot]", "Duplicate of #7")}, 0},
+		{"configured bot user", []*github.IssueComment{botComment("triage-pat", "Duplicate of #7")}, 0},
+		{"maintainer before bot", []*github.IssueComment{comment("Duplicate of #3"), botComment("triage-pat", "Duplicate of #7")}, 3},
+		{"same repo url", []*github.IssueComment{comment("Duplicate of https://github.com/org/app/issues/33")}, 33},
+		{"other repo url", []*github.IssueComment{comment("Duplicate of https://github.com/org/docs/issues/33")}, 0},
+		{"latest comment wins", []*github.IssueComment{comment("Duplicate of #1"), comment("Actually, no.\nDuplicate of #2")}, 2},
+		{"unrelated mention", []*github.IssueComment{comment("See #5 for context")}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := duplicateTarget(tt.comments, "org", "app", []string{"triage-pat"}); got != tt.want {
+				t.Errorf("duplicateTarget() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHarvestCase(t *testing.T) {
+	opts := harvestOptions{Org: "org", Repo: "app", DuplicateLabel: "duplicate"}
+	transferred := []*github.IssueEvent{{Event: github.String("labeled")}, {Event: github.String("transferred")}}
+
+	tests := []struct {
+		name          string
+		issue         *github.Issue
+		comments      []*github.IssueComment
+		events        []*github.IssueEvent
+		opts          harvestOptions
+		wantDuplicate *int
+		wantIssueRepo string
+		wantLabels    []string
+	}{
+		{
+			name: "labelled duplicate with marker",
+			issue: &github.Issue{
+				Number: github.Int(10), Title: github.String("Crash"), State: github.String("closed"),
+				Labels: []*github.Label{{Name: github.String("bug")}, {Name: github.String("Duplicate")}},
+			},
+			comments:      []*github.IssueComment{comment("Duplicate of #4")},
+			opts:          opts,
+			wantDuplicate: intPtr(4),
+			wantIssueRepo: "app",
+			wantLabels:    []string{"bug"},
+		},
+		{
+			name: "labelled duplicate without target is unscored",
+			issue: &github.Issue{
+				Number: github.Int(11), Title: github.String("Crash"), State: github.String("closed"),
+				Labels: []*github.Label{{Name: github.String("duplicate")}},
+			},
+			opts:          opts,
+			wantDuplicate: nil,
+			wantIssueRepo: "app",
+			wantLabels:    []string{},
+		},
+		{
+			name: "closed as duplicate with marker",
+			issue: &github.Issue{
+				Number: github.Int(16), Title: github.String("Crash"), State: github.String("closed"),
+				StateReason: github.String("duplicate"),
+			},
+			comments:      []*github.IssueComment{comment("Duplicate of #4")},
+			opts:          opts,
+			wantDuplicate: intPtr(4),
+			wantIssueRepo: "app",
+			wantLabels:    []string{},
+		},
+		{
+			name: "marker without duplicate label or reason is not a duplicate",
+			issue: &github.Issue{
+				Number: github.Int(17), Title: github.String("Crash"), State: github.String("closed"),
+				StateReason: github.String("completed"),
+			},
+			comments:      []*github.IssueComment{comment("Duplicate of #4")},
+			opts:          opts,
+			wantDuplicate: intPtr(0),
+			wantIssueRepo: "app",
+			wantLabels:    []string{},
+		},
+		{
+			name: "closed without duplicate signals",
+			issue: &github.Issue{
+				Number: github.Int(12), Title: github.String("Feature"), State: github.String("closed"),
+				Labels: []*github.Label{{Name: github.String("enhancement")}},
+			},
+			opts:          opts,
+			wantDuplicate: intPtr(0),
+			wantIssueRepo: "app",
+			wantLabels:    []string{"enhancement"},
+		},
+		{
+			name:          "open issue has no duplicate verdict",
+			issue:         &github.Issue{Number: github.Int(13), Title: github.String("Open"), State: github.String("open")},
+			opts:          opts,
+			wantDuplicate: nil,
+			wantIssueRepo: "app",
+			wantLabels:    []string{},
+		},
+		{
+			name:          "transferred issue presented in source repo",
+			issue:         &github.Issue{Number: github.Int(14), Title: github.String("Docs"), State: github.String("open")},
+			events:        transferred,
+			opts:          harvestOptions{Org: "org", Repo: "app", DuplicateLabel: "duplicate", TransferSource: "org/intake"},
+			wantDuplicate: nil,
+			wantIssueRepo: "intake",
+			wantLabels:    []string{},
+		},
+		{
+			name:          "transferred issue without source stays in place",
+			issue:         &github.Issue{Number: github.Int(15), Title: github.String("Docs"), State: github.String("open")},
+			events:        transferred,
+			opts:          opts,
+			wantDuplicate: nil,
+			wantIssueRepo: "app",
+			wantLabels:    []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := harvestCase(tt.issue, tt.comments, tt.events, tt.opts)
+
+			if !reflect.DeepEqual(got.DuplicateOf, tt.wantDuplicate) {
+				t.Errorf("DuplicateOf = %v, want %v", got.DuplicateOf, tt.wantDuplicate)
+			}
+			if got.Issue.Repo != tt.wantIssueRepo {
+				t.Errorf("Issue.Repo = %q, want %q", got.Issue.Repo, tt.wantIssueRepo)
+			}
+			if got.Repo != "org/app" {
+				t.Errorf("Repo = %q, want org/app", got.Repo)
+			}
+			if !reflect.DeepEqual(got.Labels, tt.wantLabels) {
+				t.Errorf("Labels = %v, want %v", got.Labels, tt.wantLabels)
+			}
+			if got.Issue.State != "open" || len(got.Issue.Labels) != 0 {
+				t.Errorf("issue should look freshly opened, got state %q labels %v", got.Issue.State, got.Issue.Labels)
+			}
+		})
+	}
+}
@@ -0,0 +1,638 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package commands
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/similigh/simili-bot/internal/core/config"
+	"github.com/similigh/simili-bot/internal/core/pipeline"
+)
+
+var (
+	evalDataset  string
+	evalOutFile  string
+	evalFormat   string
+	evalWorkers  int
+	evalWorkflow string
+	evalNoSweep  bool
+)
+
+// relaxedThreshold replaces confidence thresholds during a sweep run so every
+// prediction is reported with its confidence. It is not 0 because steps
+// treat 0 as "use the default".
+const relaxedThreshold = 0.01
+
+// sweepThresholds are the candidate values tried for every swept setting.
+var sweepThresholds = []float64{0.50, 0.55, 0.60, 0.65, 0.70, 0.75, 0.80, 0.85, 0.90, 0.95}
+
+// potentialDuplicateLabel is added by duplicate_detector; duplicates are
+// scored separately, so it is ignored when scoring labels.
+const potentialDuplicateLabel = "potential-duplicate"
+
+// EvalCase is one line of an evaluation dataset: an issue and its ground truth.
+// Omitted fields are not scored for that issue.
+type EvalCase struct {
+	Issue       pipeline.Issue `json:"issue"`
+	DuplicateOf *int           `json:"duplicate_of,omitempty"` // 0 = not a duplicate
+	Repo        string         `json:"repo,omitempty"`         // Correct repository (org/repo)
+	Labels      []string       `json:"labels"`                 // [] = no labels expected; null = not scored
+}
+
+// Confusion counts binary classification outcomes.
+type Confusion struct {
+	TP int `json:"tp"`
+	FP int `json:"fp"`
+	FN int `json:"fn"`
+	TN int `json:"tn"`
+}
+
+// Precision returns TP / (TP + FP), or 0 when nothing was predicted.
+func (c Confusion) Precision() float64 {
+	return ratio(c.TP, c.TP+c.FP)
+}
+
+// Recall returns TP / (TP + FN), or 0 when nothing was expected.
+func (c Confusion) Recall() float64 {
+	return ratio(c.TP, c.TP+c.FN)
+}
+
+// F1 returns the harmonic mean of precision and recall.
+func (c Confusion) F1() float64 {
+	p, r := c.Precision(), c.Recall()
+	if p+r == 0 {
+		return 0
+	}
+	return 2 * p * r / (p + r)
+}
+
+func ratio(n, d int) float64 {
+	if d == 0 {
+		return 0
+	}
+	return float64(n) / float64(d)
+}
+
+// Metrics is a confusion matrix with its derived scores.
+type Metrics struct {
+	Confusion
+	Labeled   int     `json:"labeled"`
+	Precision float64 `json:"precision"`
+	Recall    float64 `json:"recall"`
+	F1        float64 `json:"f1"`
+}
+
+func newMetrics(c Confusion, labeled int) Metrics {
+	return Metrics{Confusion: c, Labeled: labeled, Precision: c.Precision(), Recall: c.Recall(), F1: c.F1()}
+}
+
+// DuplicateMetrics scores duplicate detection.
+type DuplicateMetrics struct {
+	Metrics
+	WrongTarget int `json:"wrong_target"` // Predicted duplicates of the wrong issue
+}
+
+// RoutingMetrics scores transfer routing. Matrix maps expected repository to
+// predicted repository to count.
+type RoutingMetrics struct {
+	Metrics
+	Matrix map[string]map[string]int `json:"confusion_matrix"`
+}
+
+// LabelMetrics scores suggested labels, micro-averaged and per label.
+type LabelMetrics struct {
+	Metrics
+	PerLabel map[string]Metrics `json:"per_label"`
+}
+
+// SweepPoint is the score of one candidate threshold.
+type SweepPoint struct {
+	Threshold float64 `json:"threshold"`
+	Precision float64 `json:"precision"`
+	Recall    float64 `json:"recall"`
+	F1        float64 `json:"f1"`
+}
+
+// ThresholdSuggestion compares the configured value of a setting with the
+// best value found by the sweep.
+type ThresholdSuggestion struct {
+	Setting   string       `json:"setting"`
+	Current   float64      `json:"current"`
+	CurrentF1 float64      `json:"current_f1"`
+	Suggested float64      `json:"suggested"`
+	BestF1    float64      `json:"best_f1"`
+	Points    []SweepPoint `json:"points"`
+}
+
+// EvalReport is the output of simili eval.
+type EvalReport struct {
+	Cases       int                   `json:"cases"`
+	Failed      int                   `json:"failed"`
+	Duplicates  *DuplicateMetrics     `json:"duplicates,omitempty"`
+	Routing     *RoutingMetrics       `json:"routing,omitempty"`
+	Labels      *LabelMetrics         `json:"labels,omitempty"`
+	Suggestions []ThresholdSuggestion `json:"suggestions,omitempty"`
+}
+
+// evalThresholds are the settings that decide a prediction.
+type evalThresholds struct {
+	Similarity float64
+	Duplicate  float64
+	Routing    float64
+}
+
+// evalPrediction is what the pipeline predicted for one issue, with the
+// scores needed to re-apply different thresholds.
+type evalPrediction struct {
+	DuplicateOf         int
+	DuplicateConfidence float64
+	DuplicateSimilarity float64
+	TransferTarget      string
+	TransferConfidence  float64
+	Labels              []string
+}
+
+var evalCmd = &cobra.Command{
+	Use:   "eval",
+	Short: "Measure duplicate, routing and label quality on a labeled dataset",
+	Long: `Run the pipeline in dry-run mode on issues with known ground truth and report
+precision, recall, F1 and confusion matrices for duplicate detection, routing
+and labels.
+
+The dataset is JSONL, one case per line:
+  {"issue": {...}, "duplicate_of": 42, "repo": "org/repo", "labels": ["bug"]}
+
+Unless --no-sweep is set, thresholds are relaxed for the run and the
+similarity, duplicate confidence and routing confidence thresholds are swept
+offline to suggest better values. Swept results are an approximation: a
+stricter similarity threshold also changes which candidates the LLM sees.`,
+	Run: runEval,
+}
+
+func init() {
+	rootCmd.AddCommand(evalCmd)
+
+	evalCmd.Flags().StringVar(&evalDataset, "dataset", "", "Path to labeled JSONL dataset (required)")
+	evalCmd.Flags().StringVar(&evalOutFile, "out-file", "", "Output file path (stdout if not specified)")
+	evalCmd.Flags().StringVar(&evalFormat, "format", "text", "Output format: text or json")
+	evalCmd.Flags().IntVar(&evalWorkers, "workers", 1, "Number of concurrent workers")
+	evalCmd.Flags().StringVar(&evalWorkflow, "workflow", "issue-triage", "Workflow preset to run")
+	evalCmd.Flags().BoolVar(&evalNoSweep, "no-sweep", false, "Run with the configured thresholds and skip the threshold sweep")
+
+	if err := evalCmd.MarkFlagRequired("dataset"); err != nil {
+		fmt.Printf("Warning: Failed to mark dataset flag as required: %v\n", err)
+	}
+}
+
+func runEval(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+
+	cases, err := loadEvalCases(evalDataset)
+	if err != nil {
+		fmt.Printf("❌ Error loading dataset: %v\n", err)
+		os.Exit(1)
+	}
+
+	cfg := loadPipelineConfig()
+	current := evalThresholds{
+		Similarity: cfg.Defaults.SimilarityThreshold,
+		Duplicate:  cfg.Transfer.DuplicateConfidenceThreshold,
+		Routing:    cfg.Transfer.VDBRouting.ConfidenceThreshold,
+	}
+	if !evalNoSweep {
+		relaxThresholds(cfg)
+	}
+
+	stepNames := withoutIndexer(pipeline.ResolveSteps(cfg.Steps, evalWorkflow))
+	deps, err := initializeDependencies(cfg)
+	if err != nil {
+		fmt.Printf("❌ Error initializing dependencies: %v\n", err)
+		os.Exit(1)
+	}
+	defer deps.Close()
+	deps.DryRun = true
+
+	issues := make([]pipeline.Issue, len(cases))
+	for i, c := range cases {
+		issues[i] = c.Issue
+	}
+	fmt.Printf("Evaluating %d issues with %d workers...\n", len(issues), evalWorkers)
+	results := processBatch(ctx, issues, cfg, deps, stepNames, evalWorkers)
+
+	report := evaluate(cases, results, current, !evalNoSweep)
+
+	var out []byte
+	switch strings.ToLower(evalFormat) {
+	case "json":
+		out, err = json.MarshalIndent(report, "", "  ")
+		if err == nil {
+			out = append(out, '\n')
+		}
+	case "text":
+		var sb strings.Builder
+		writeEvalReport(&sb, report)
+		out = []byte(sb.String())
+	default:
+		err = fmt.Errorf("unsupported format: %s (use 'text' or 'json')", evalFormat)
+	}
+	if err != nil {
+		fmt.Printf("❌ Error formatting report: %v\n", err)
+		os.Exit(1)
+	}
+
+	if evalOutFile == "" {
+		fmt.Print(string(out))
+		return
+	}
+	if err := os.WriteFile(evalOutFile, out, 0644); err != nil {
+		fmt.Printf("❌ Error writing report: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("✓ Report written to %s\n", evalOutFile)
+}
+
+// loadEvalCases reads a JSONL dataset, skipping blank lines.
+func loadEvalCases(path string) ([]EvalCase, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open dataset: %w", err)
+	}
+	defer f.Close()
+
+	var cases []EvalCase
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var c EvalCase
+		if err := json.Unmarshal([]byte(text), &c); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if c.Issue.Org == "" || c.Issue.Repo == "" || c.Issue.Number == 0 || c.Issue.Title == "" {
+			return nil, fmt.Errorf("line %d: issue missing required fields (org, repo, number, title)", line)
+		}
+		cases = append(cases, c)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read dataset: %w", err)
+	}
+	if len(cases) == 0 {
+		return nil, fmt.Errorf("no cases found in dataset")
+	}
+	return cases, nil
+}
+
+// relaxThresholds lowers the swept thresholds so the run reports every
+// candidate prediction; evaluate re-applies the real thresholds offline.
+func relaxThresholds(cfg *config.Config) {
+	if cfg.Defaults.SimilarityThreshold > sweepThresholds[0] {
+		cfg.Defaults.SimilarityThreshold = sweepThresholds[0]
+	}
+	cfg.Transfer.DuplicateConfidenceThreshold = relaxedThreshold
+	cfg.Transfer.VDBRouting.ConfidenceThreshold = relaxedThreshold
+	cfg.Transfer.MediumConfidence = relaxedThreshold
+}
+
+// predictionFrom extracts the scored prediction from a pipeline result.
+func predictionFrom(result *pipeline.Result) evalPrediction {
+	var p evalPrediction
+	if result == nil {
+		return p
+	}
+	if result.IsDuplicate {
+		p.DuplicateOf = result.DuplicateOf
+		p.DuplicateConfidence = result.DuplicateConfidence
+		// The duplicate should be one of the similar issues; if it is not,
+		// or it was found only by its stack trace, the similarity threshold
+		// cannot have filtered it.
+		p.DuplicateSimilarity = 1
+		for _, s := range result.SimilarFound {
+			if s.Number == result.DuplicateOf {
+				if s.Similarity > 0 || !s.SameStackTrace {
+					p.DuplicateSimilarity = s.Similarity
+				}
+				break
+			}
+		}
+	}
+	p.TransferTarget = result.TransferTarget
+	p.TransferConfidence = result.TransferConfidence
+	for _, l := range result.SuggestedLabels {
+		if !strings.EqualFold(l, potentialDuplicateLabel) {
+			p.Labels = append(p.Labels, l)
+		}
+	}
+	return p
+}
+
+// evaluate scores results against the dataset at the given thresholds and,
+// when sweep is set, suggests better thresholds.
+func evaluate(cases []EvalCase, results []BatchResult, current evalThresholds, sweep bool) *EvalReport {
+	report := &EvalReport{Cases: len(cases)}
+	scored := make([]EvalCase, 0, len(cases))
+	preds := make([]evalPrediction, 0, len(cases))
+	for i, c := range cases {
+		if i >= len(results) || results[i].Error != nil || results[i].Result == nil {
+			report.Failed++
+			continue
+		}
+		scored = append(scored, c)
+		preds = append(preds, predictionFrom(results[i].Result))
+	}
+
+	report.Duplicates = scoreDuplicates(scored, preds, current)
+	report.Routing = scoreRouting(scored, preds, current.Routing)
+	report.Labels = scoreLabels(scored, preds)
+
+	if sweep {
+		report.Suggestions = sweepSuggestions(scored, preds, current, report)
+	}
+	return report
+}
+
+func scoreDuplicates(cases []EvalCase, preds []evalPrediction, th evalThresholds) *DuplicateMetrics {
+	var c Confusion
+	labeled, wrong := 0, 0
+	for i, ec := range cases {
+		if ec.DuplicateOf == nil {
+			continue
+		}
+		labeled++
+		p := preds[i]
+		predicted := 0
+		if p.DuplicateOf != 0 && p.DuplicateConfidence >= th.Duplicate && p.DuplicateSimilarity >= th.Similarity {
+			predicted = p.DuplicateOf
+		}
+		expected := *ec.DuplicateOf
+
+		switch {
+		case predicted == 0 && expected == 0:
+			c.TN++
+		case predicted == expected:
+			c.TP++
+		case predicted == 0:
+			c.FN++
+		case expected == 0:
+			c.FP++
+		default:
+			// Duplicate of the wrong issue: a false positive and a miss.
+			c.FP++
+			c.FN++
+			wrong++
+		}
+	}
+	if labeled == 0 {
+		return nil
+	}
+	return &DuplicateMetrics{Metrics: newMetrics(c, labeled), WrongTarget: wrong}
+}
+
+func scoreRouting(cases []EvalCase, preds []evalPrediction, threshold float64) *RoutingMetrics {
+	var c Confusion
+	matrix := make(map[string]map[string]int)
+	labeled := 0
+	for i, ec := range cases {
+		if ec.Repo == "" {
+			continue
+		}
+		labeled++
+		currentRepo := strings.ToLower(ec.Issue.Org + "/" + ec.Issue.Repo)
+		expected := strings.ToLower(ec.Repo)
+		predicted := currentRepo
+		if p := preds[i]; p.TransferTarget != "" && p.TransferConfidence >= threshold {
+			predicted = strings.ToLower(p.TransferTarget)
+		}
+
+		if matrix[expected] == nil {
+			matrix[expected] = make(map[string]int)
+		}
+		matrix[expected][predicted]++
+
+		shouldMove, moved := expected != currentRepo, predicted != currentRepo
+		switch {
+		case !shouldMove && !moved:
+			c.TN++
+		case moved && predicted == expected:
+			c.TP++
+		case moved && shouldMove:
+			c.FP++
+			c.FN++
+		case moved:
+			c.FP++
+		default:
+			c.FN++
+		}
+	}
+	if labeled == 0 {
+		return nil
+	}
+	return &RoutingMetrics{Metrics: newMetrics(c, labeled), Matrix: matrix}
+}
+
+func scoreLabels(cases []EvalCase, preds []evalPrediction) *LabelMetrics {
+	var micro Confusion
+	perLabel := make(map[string]Confusion)
+	labeled := 0
+	for i, ec := range cases {
+		if ec.Labels == nil {
+			continue
+		}
+		labeled++
+		expected := lowerSet(ec.Labels)
+		predicted := lowerSet(preds[i].Labels)
+		for l := range predicted {
+			pc := perLabel[l]
+			if expected[l] {
+				pc.TP++
+				micro.TP++
+			} else {
+				pc.FP++
+				micro.FP++
+			}
+			perLabel[l] = pc
+		}
+		for l := range expected {
+			if !predicted[l] {
+				pc := perLabel[l]
+				pc.FN++
+				micro.FN++
+				perLabel[l] = pc
+			}
+		}
+	}
+	if labeled == 0 {
+		return nil
+	}
+	metrics := &LabelMetrics{Metrics: newMetrics(micro, labeled), PerLabel: make(map[string]Metrics, len(perLabel))}
+	for l, c := range perLabel {
+		metrics.PerLabel[l] = newMetrics(c, labeled)
+	}
+	return metrics
+}
+
+func lowerSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		if v = strings.ToLower(strings.TrimSpace(v)); v != "" {
+			set[v] = true
+		}
+	}
+	return set
+}
+
+// sweepSuggestions grid-searches the similarity and duplicate thresholds
+// together and the routing threshold alone, keeping the best F1. Ties go to
+// the higher, more conservative threshold.
+func sweepSuggestions(cases []EvalCase, preds []evalPrediction, current evalThresholds, report *EvalReport) []ThresholdSuggestion {
+	var suggestions []ThresholdSuggestion
+
+	if report.Duplicates != nil {
+		best, bestF1 := current, report.Duplicates.F1
+		for _, s := range sweepThresholds {
+			for _, d := range sweepThresholds {
+				th := evalThresholds{Similarity: s, Duplicate: d}
+				if f1 := scoreDuplicates(cases, preds, th).F1; f1 > bestF1 || (f1 == bestF1 && s+d >= best.Similarity+best.Duplicate) {
+					best, bestF1 = th, f1
+				}
+			}
+		}
+
+		simPoints := make([]SweepPoint, 0, len(sweepThresholds))
+		dupPoints := make([]SweepPoint, 0, len(sweepThresholds))
+		for _, t := range sweepThresholds {
+			simPoints = append(simPoints, sweepPoint(t, scoreDuplicates(cases, preds, evalThresholds{Similarity: t, Duplicate: best.Duplicate}).Metrics))
+			dupPoints = append(dupPoints, sweepPoint(t, scoreDuplicates(cases, preds, evalThresholds{Similarity: best.Similarity, Duplicate: t}).Metrics))
+		}
+		suggestions = append(suggestions,
+			ThresholdSuggestion{
+				Setting: "defaults.similarity_threshold", Current: current.Similarity, CurrentF1: report.Duplicates.F1,
+				Suggested: best.Similarity, BestF1: bestF1, Points: simPoints,
+			},
+			ThresholdSuggestion{
+				Setting: "transfer.duplicate_confidence_threshold", Current: current.Duplicate, CurrentF1: report.Duplicates.F1,
+				Suggested: best.Duplicate, BestF1: bestF1, Points: dupPoints,
+			},
+		)
+	}
+
+	if report.Routing != nil {
+		best, bestF1 := current.Routing, report.Routing.F1
+		points := make([]SweepPoint, 0, len(sweepThresholds))
+		for _, t := range sweepThresholds {
+			m := scoreRouting(cases, preds, t).Metrics
+			points = append(points, sweepPoint(t, m))
+			if m.F1 > bestF1 || (m.F1 == bestF1 && t >= best) {
+				best, bestF1 = t, m.F1
+			}
+		}
+		suggestions = append(suggestions, ThresholdSuggestion{
+			Setting: "transfer.vdb_routing.confidence_threshold", Current: current.Routing, CurrentF1: report.Routing.F1,
+			Suggested: best, BestF1: bestF1, Points: points,
+		})
+	}
+
+	return suggestions
+}
+
+func sweepPoint(t float64, m Metrics) SweepPoint {
+	return SweepPoint{Threshold: t, Precision: m.Precision, Recall: m.Recall, F1: m.F1}
+}
+
+// writeEvalReport renders report as plain text.
+func writeEvalReport(w io.Writer, report *EvalReport) {
+	fmt.Fprintf(w, "Evaluated %d issues (%d failed)\n", report.Cases, report.Failed)
+
+	writeScores := func(title string, m Metrics) {
+		fmt.Fprintf(w, "\n%s (%d labeled)\n", title, m.Labeled)
+		fmt.Fprintf(w, "  precision %.3f  recall %.3f  f1 %.3f\n", m.Precision, m.Recall, m.F1)
+	}
+
+	if d := report.Duplicates; d != nil {
+		writeScores("Duplicates", d.Metrics)
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+		fmt.Fprintln(tw, "\tpredicted duplicate\tpredicted distinct\t")
+		fmt.Fprintf(tw, "actual duplicate\t%d\t%d\t\n", d.TP, d.FN-d.WrongTarget)
+		fmt.Fprintf(tw, "actual distinct\t%d\t%d\t\n", d.FP-d.WrongTarget, d.TN)
+		tw.Flush()
+		if d.WrongTarget > 0 {
+			fmt.Fprintf(w, "  %d predicted duplicates pointed at the wrong issue\n", d.WrongTarget)
+		}
+	}
+
+	if r := report.Routing; r != nil {
+		writeScores("Routing", r.Metrics)
+		repos := make(map[string]bool)
+		for expected, row := range r.Matrix {
+			repos[expected] = true
+			for predicted := range row {
+				repos[predicted] = true
+			}
+		}
+		names := sortedKeys(repos)
+		fmt.Fprintln(w, "  rows: expected repository, columns: predicted repository")
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+		fmt.Fprintf(tw, "\t%s\t\n", strings.Join(names, "\t"))
+		for _, expected := range names {
+			if r.Matrix[expected] == nil {
+				continue
+			}
+			counts := make([]string, len(names))
+			for i, predicted := range names {
+				counts[i] = fmt.Sprint(r.Matrix[expected][predicted])
+			}
+			fmt.Fprintf(tw, "%s\t%s\t\n", expected, strings.Join(counts, "\t"))
+		}
+		tw.Flush()
+	}
+
+	if l := report.Labels; l != nil {
+		writeScores("Labels (micro-averaged)", l.Metrics)
+		labels := make(map[string]bool, len(l.PerLabel))
+		for name := range l.PerLabel {
+			labels[name] = true
+		}
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+		fmt.Fprintln(tw, "label\ttp\tfp\tfn\tprecision\trecall\tf1\t")
+		for _, name := range sortedKeys(labels) {
+			m := l.PerLabel[name]
+			fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%.3f\t%.3f\t%.3f\t\n", name, m.TP, m.FP, m.FN, m.Precision, m.Recall, m.F1)
+		}
+		tw.Flush()
+	}
+
+	if len(report.Suggestions) > 0 {
+		fmt.Fprintln(w, "\nThreshold suggestions")
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		for _, s := range report.Suggestions {
+			fmt.Fprintf(tw, "  %s\tcurrent %.2f (f1 %.3f)\tsuggested %.2f (f1 %.3f)\n", s.Setting, s.Current, s.CurrentF1, s.Suggested, s.BestF1)
+		}
+		tw.Flush()
+	}
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
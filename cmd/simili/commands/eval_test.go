@@ -0,0 +1,288 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package commands
+
+import (
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/similigh/simili-bot/internal/core/pipeline"
+)
+
+func intPtr(n int) *int { return &n }
+
+func evalIssue(number int) pipeline.Issue {
+	return pipeline.Issue{Org: "org", Repo: "app", Number: number, Title: "issue"}
+}
+
+func approxEqual(a, b float64) bool { return math.Abs(a-b) < 1e-9 }
+
+func TestConfusionScores(t *testing.T) {
+	tests := []struct {
+		name          string
+		c             Confusion
+		wantPrecision float64
+		wantRecall    float64
+		wantF1        float64
+	}{
+		{"empty", Confusion{}, 0, 0, 0},
+		{"perfect", Confusion{TP: 3, TN: 2}, 1, 1, 1},
+		{"mixed", Confusion{TP: 2, FP: 2, FN: 0}, 0.5, 1, 2.0 / 3},
+		{"no true positives", Confusion{FP: 1, FN: 1}, 0, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.Precision(); !approxEqual(got, tt.wantPrecision) {
+				t.Errorf("Precision() = %v, want %v", got, tt.wantPrecision)
+			}
+			if got := tt.c.Recall(); !approxEqual(got, tt.wantRecall) {
+				t.Errorf("Recall() = %v, want %v", got, tt.wantRecall)
+			}
+			if got := tt.c.F1(); !approxEqual(got, tt.wantF1) {
+				t.Errorf("F1() = %v, want %v", got, tt.wantF1)
+			}
+		})
+	}
+}
+
+func TestLoadEvalCases(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		wantErr   bool
+		wantCount int
+	}{
+		{
+			name: "valid cases with blank lines",
+			content: `{"issue": {"org": "o", "repo": "r", "number": 1, "title": "a"}, "duplicate_of": 0}
+
+{"issue": {"org": "o", "repo": "r", "number": 2, "title": "b"}, "repo": "o/other", "labels": ["bug"]}
+`,
+			wantCount: 2,
+		},
+		{
+			name:    "missing title",
+			content: `{"issue": {"org": "o", "repo": "r", "number": 1}}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid json",
+			content: `{"issue": `,
+			wantErr: true,
+		},
+		{
+			name:    "empty dataset",
+			content: "\n\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "dataset.jsonl")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			cases, err := loadEvalCases(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("loadEvalCases() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(cases) != tt.wantCount {
+				t.Errorf("got %d cases, want %d", len(cases), tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestScoreDuplicates(t *testing.T) {
+	cases := []EvalCase{
+		{Issue: evalIssue(1), DuplicateOf: intPtr(10)}, // found
+		{Issue: evalIssue(2), DuplicateOf: intPtr(0)},  // correctly not flagged
+		{Issue: evalIssue(3), DuplicateOf: intPtr(0)},  // false positive
+		{Issue: evalIssue(4), DuplicateOf: intPtr(11)}, // missed
+		{Issue: evalIssue(5), DuplicateOf: intPtr(12)}, // wrong target
+		{Issue: evalIssue(6)},                          // unlabeled
+	}
+	preds := []evalPrediction{
+		{DuplicateOf: 10, DuplicateConfidence: 0.9, DuplicateSimilarity: 0.9},
+		{},
+		{DuplicateOf: 20, DuplicateConfidence: 0.6, DuplicateSimilarity: 0.7},
+		{},
+		{DuplicateOf: 13, DuplicateConfidence: 0.9, DuplicateSimilarity: 0.8},
+		{DuplicateOf: 30, DuplicateConfidence: 0.9, DuplicateSimilarity: 0.9},
+	}
+
+	got := scoreDuplicates(cases, preds, evalThresholds{Similarity: 0.65, Duplicate: 0.5})
+	want := Confusion{TP: 1, FP: 2, FN: 2, TN: 1}
+	if got.Confusion != want {
+		t.Errorf("confusion = %+v, want %+v", got.Confusion, want)
+	}
+	if got.Labeled != 5 || got.WrongTarget != 1 {
+		t.Errorf("labeled = %d, wrong target = %d, want 5 and 1", got.Labeled, got.WrongTarget)
+	}
+
+	// A stricter confidence threshold drops the false positive.
+	got = scoreDuplicates(cases, preds, evalThresholds{Similarity: 0.65, Duplicate: 0.7})
+	want = Confusion{TP: 1, FP: 1, FN: 2, TN: 2}
+	if got.Confusion != want {
+		t.Errorf("strict confusion = %+v, want %+v", got.Confusion, want)
+	}
+
+	if scoreDuplicates(cases[5:], preds[5:], evalThresholds{}) != nil {
+		t.Error("expected nil metrics when no case is labeled")
+	}
+}
+
+func TestScoreRouting(t *testing.T) {
+	cases := []EvalCase{
+		{Issue: evalIssue(1), Repo: "org/app"},  // stays
+		{Issue: evalIssue(2), Repo: "org/docs"}, // routed correctly
+		{Issue: evalIssue(3), Repo: "org/app"},  // wrongly moved
+		{Issue: evalIssue(4), Repo: "org/docs"}, // not moved
+		{Issue: evalIssue(5), Repo: "Org/Docs"}, // moved to the wrong repo
+	}
+	preds := []evalPrediction{
+		{},
+		{TransferTarget: "org/docs", TransferConfidence: 0.9},
+		{TransferTarget: "org/docs", TransferConfidence: 0.6},
+		{TransferTarget: "org/docs", TransferConfidence: 0.4},
+		{TransferTarget: "org/api", TransferConfidence: 0.9},
+	}
+
+	got := scoreRouting(cases, preds, 0.5)
+	want := Confusion{TP: 1, FP: 2, FN: 2, TN: 1}
+	if got.Confusion != want {
+		t.Errorf("confusion = %+v, want %+v", got.Confusion, want)
+	}
+	if n := got.Matrix["org/docs"]["org/app"]; n != 1 {
+		t.Errorf("matrix[org/docs][org/app] = %d, want 1", n)
+	}
+	if n := got.Matrix["org/docs"]["org/api"]; n != 1 {
+		t.Errorf("matrix[org/docs][org/api] = %d, want 1", n)
+	}
+	if n := got.Matrix["org/app"]["org/docs"]; n != 1 {
+		t.Errorf("matrix[org/app][org/docs] = %d, want 1", n)
+	}
+}
+
+func TestScoreLabels(t *testing.T) {
+	cases := []EvalCase{
+		{Issue: evalIssue(1), Labels: []string{"bug", "ui"}},
+		{Issue: evalIssue(2), Labels: []string{}},
+		{Issue: evalIssue(3)}, // unlabeled
+	}
+	preds := []evalPrediction{
+		{Labels: []string{"Bug", "docs"}},
+		{Labels: []string{"docs"}},
+		{Labels: []string{"bug"}},
+	}
+
+	got := scoreLabels(cases, preds)
+	want := Confusion{TP: 1, FP: 2, FN: 1}
+	if got.Confusion != want {
+		t.Errorf("micro confusion = %+v, want %+v", got.Confusion, want)
+	}
+	if got.Labeled != 2 {
+		t.Errorf("labeled = %d, want 2", got.Labeled)
+	}
+	if c := got.PerLabel["docs"].Confusion; c != (Confusion{FP: 2}) {
+		t.Errorf("docs confusion = %+v, want FP 2", c)
+	}
+	if c := got.PerLabel["ui"].Confusion; c != (Confusion{FN: 1}) {
+		t.Errorf("ui confusion = %+v, want FN 1", c)
+	}
+}
+
+func TestPredictionFrom(t *testing.T) {
+	result := &pipeline.Result{
+		IsDuplicate:         true,
+		DuplicateOf:         7,
+		DuplicateConfidence: 0.8,
+		SimilarFound:        []pipeline.SimilarIssue{{Number: 3, Similarity: 0.9}, {Number: 7, Similarity: 0.75}},
+		TransferTarget:      "org/docs",
+		TransferConfidence:  0.6,
+		SuggestedLabels:     []string{"bug", "potential-duplicate"},
+	}
+	got := predictionFrom(result)
+	if got.DuplicateOf != 7 || got.DuplicateSimilarity != 0.75 || got.DuplicateConfidence != 0.8 {
+		t.Errorf("duplicate prediction = %+v", got)
+	}
+	if got.TransferTarget != "org/docs" || got.TransferConfidence != 0.6 {
+		t.Errorf("transfer prediction = %+v", got)
+	}
+	if len(got.Labels) != 1 || got.Labels[0] != "bug" {
+		t.Errorf("labels = %v, want [bug]", got.Labels)
+	}
+}
+
+func TestEvaluateSweep(t *testing.T) {
+	cases := []EvalCase{
+		{Issue: evalIssue(1), DuplicateOf: intPtr(10), Repo: "org/app"},
+		{Issue: evalIssue(2), DuplicateOf: intPtr(0), Repo: "org/docs"},
+		{Issue: evalIssue(3), DuplicateOf: intPtr(0), Repo: "org/app"},
+		{Issue: evalIssue(4), DuplicateOf: intPtr(0)},
+	}
+	results := []BatchResult{
+		{Result: &pipeline.Result{
+			IsDuplicate: true, DuplicateOf: 10, DuplicateConfidence: 0.75,
+			SimilarFound: []pipeline.SimilarIssue{{Number: 10, Similarity: 0.8}},
+		}},
+		{Result: &pipeline.Result{TransferTarget: "org/docs", TransferConfidence: 0.72}},
+		{Result: &pipeline.Result{
+			IsDuplicate: true, DuplicateOf: 11, DuplicateConfidence: 0.6,
+			SimilarFound:   []pipeline.SimilarIssue{{Number: 11, Similarity: 0.7}},
+			TransferTarget: "org/docs", TransferConfidence: 0.55,
+		}},
+		{Error: errors.New("boom")},
+	}
+
+	// At the configured thresholds the true duplicate and the routing are missed.
+	current := evalThresholds{Similarity: 0.85, Duplicate: 0.85, Routing: 0.9}
+	report := evaluate(cases, results, current, true)
+
+	if report.Cases != 4 || report.Failed != 1 {
+		t.Fatalf("cases = %d, failed = %d, want 4 and 1", report.Cases, report.Failed)
+	}
+	if report.Duplicates.F1 != 0 || report.Routing.F1 != 0 {
+		t.Errorf("current F1 = %v / %v, want 0", report.Duplicates.F1, report.Routing.F1)
+	}
+	if len(report.Suggestions) != 3 {
+		t.Fatalf("got %d suggestions, want 3", len(report.Suggestions))
+	}
+
+	bySetting := make(map[string]ThresholdSuggestion)
+	for _, s := range report.Suggestions {
+		bySetting[s.Setting] = s
+		if len(s.Points) != len(sweepThresholds) {
+			t.Errorf("%s: got %d points, want %d", s.Setting, len(s.Points), len(sweepThresholds))
+		}
+	}
+
+	// Best is the highest threshold pair that keeps #1 (0.80/0.75) and
+	// drops #3 (0.70/0.60).
+	sim := bySetting["defaults.similarity_threshold"]
+	dup := bySetting["transfer.duplicate_confidence_threshold"]
+	if !approxEqual(sim.Suggested, 0.80) || !approxEqual(dup.Suggested, 0.75) || sim.BestF1 != 1 {
+		t.Errorf("duplicate suggestion = %.2f/%.2f (f1 %v), want 0.80/0.75 (f1 1)", sim.Suggested, dup.Suggested, sim.BestF1)
+	}
+
+	// Highest routing threshold keeping #2 (0.72) and dropping #3 (0.55).
+	routing := bySetting["transfer.vdb_routing.confidence_threshold"]
+	if !approxEqual(routing.Suggested, 0.70) || routing.BestF1 != 1 {
+		t.Errorf("routing suggestion = %.2f (f1 %v), want 0.70 (f1 1)", routing.Suggested, routing.BestF1)
+	}
+
+	var sb strings.Builder
+	writeEvalReport(&sb, report)
+	for _, want := range []string{"Duplicates (3 labeled)", "Routing (3 labeled)", "transfer.vdb_routing.confidence_threshold"} {
+		if !strings.Contains(sb.String(), want) {
+			t.Errorf("text report missing %q:\n%s", want, sb.String())
+		}
+	}
+}
@@ -0,0 +1,207 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/similigh/simili-bot/internal/core/config"
+	"github.com/similigh/simili-bot/internal/core/state"
+	"github.com/similigh/simili-bot/internal/integrations/ai"
+	"github.com/similigh/simili-bot/internal/integrations/github"
+	"github.com/similigh/simili-bot/internal/steps"
+)
+
+var (
+	feedbackRepo   string
+	feedbackFormat string
+)
+
+var feedbackCmd = &cobra.Command{
+	Use:   "feedback",
+	Short: "Track maintainer feedback on bot decisions",
+	Long: `Decisions the bot acts on (duplicates, transfers, labels, auto-closes) are
+recorded in the state backend when feedback.enabled is set. These commands
+collect human feedback on them and report how often the bot was wrong.`,
+}
+
+var feedbackCollectCmd = &cobra.Command{
+	Use:   "collect",
+	Short: "Record reactions, /undo, reopens and label removals on recorded decisions",
+	Long: `Scan every issue with recorded decisions and store new feedback signals:
+  - reactions by humans on the bot's triage or auto-close comment
+  - /undo comments after a transfer
+  - reopens after an auto-close
+  - removal of bot-applied labels by humans
+
+Run it on a schedule, e.g. next to auto-close.`,
+	Run: runFeedbackCollect,
+}
+
+var feedbackReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Report false-positive rates by step and threshold",
+	Run:   runFeedbackReport,
+}
+
+func init() {
+	rootCmd.AddCommand(feedbackCmd)
+	feedbackCmd.AddCommand(feedbackCollectCmd)
+	feedbackCmd.AddCommand(feedbackReportCmd)
+
+	feedbackCmd.PersistentFlags().StringVar(&feedbackRepo, "repo", "", "Repository (owner/name); falls back to GITHUB_REPOSITORY env var")
+	feedbackReportCmd.Flags().StringVar(&feedbackFormat, "format", "text", "Output format: text or json")
+}
+
+// feedbackStore opens the configured feedback store for a repository.
+// It returns nil when feedback tracking is disabled.
+func feedbackStore(cfg *config.Config, token, org, repo string) state.FeedbackStore {
+	fb := cfg.Feedback
+	if fb.Enabled == nil || !*fb.Enabled {
+		return nil
+	}
+	if fb.Dir != "" {
+		return state.NewLocalFeedbackStore(fb.Dir)
+	}
+	if token == "" {
+		return nil
+	}
+	return stateManager(cfg, token, org, repo)
+}
+
+// stateManager opens the state branch of a repository, or of
+// feedback.state_repo and feedback.branch when set.
+func stateManager(cfg *config.Config, token, org, repo string) *state.GitHubStateManager {
+	fb := cfg.Feedback
+	if stateOrg, stateRepo, ok := strings.Cut(fb.StateRepo, "/"); ok && stateOrg != "" && stateRepo != "" {
+		org, repo = stateOrg, stateRepo
+	}
+	manager := state.NewGitHubStateManager(token, org, repo)
+	if fb.Branch != "" {
+		manager.WithBranch(fb.Branch)
+	}
+	return manager
+}
+
+// dailyUsageStore returns the store that shares llm.max_tokens_per_day
+// between runs on a repository. It returns nil when there is no daily
+// budget or no token to reach the state branch.
+func dailyUsageStore(cfg *config.Config, token, org, repo string) ai.DailyUsageStore {
+	if cfg.LLM.MaxTokensPerDay <= 0 || token == "" || org == "" || repo == "" {
+		return nil
+	}
+	return state.NewRepoUsage(stateManager(cfg, token, org, repo), org, repo)
+}
+
+// feedbackSetup resolves the repository, config, token and store shared by
+// the feedback subcommands, exiting on error.
+func feedbackSetup() (org, repo, token string, cfg *config.Config, store state.FeedbackStore) {
+	target := feedbackRepo
+	if target == "" {
+		target = os.Getenv("GITHUB_REPOSITORY")
+	}
+	org, repo, ok := strings.Cut(target, "/")
+	if !ok || org == "" || repo == "" {
+		fmt.Fprintln(os.Stderr, "Error: --repo owner/name or GITHUB_REPOSITORY is required")
+		os.Exit(1)
+	}
+
+	cfgPath := config.FindConfigPath(cfgFile)
+	if cfgPath == "" {
+		fmt.Fprintln(os.Stderr, "Error: config file not found")
+		os.Exit(1)
+	}
+	cfg, err := config.Load(cfgPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to load config: %v\n", err)
+		os.Exit(1)
+	}
+
+	token = os.Getenv("GITHUB_TOKEN")
+	store = feedbackStore(cfg, token, org, repo)
+	if store == nil {
+		fmt.Fprintln(os.Stderr, "Error: feedback tracking is disabled (set feedback.enabled and GITHUB_TOKEN or feedback.dir)")
+		os.Exit(1)
+	}
+	return org, repo, token, cfg, store
+}
+
+func runFeedbackCollect(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+	org, repo, token, cfg, store := feedbackSetup()
+	if token == "" {
+		fmt.Fprintln(os.Stderr, "Error: GITHUB_TOKEN is required")
+		os.Exit(1)
+	}
+
+	ghClient := github.NewRateLimitedClient(ctx, token, cfg.RateLimitRegistry().Get("github"))
+	result, err := steps.NewFeedbackCollector(ghClient, store, cfg, verbose).Run(ctx, org, repo)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	out, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error marshaling result: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println(string(out))
+}
+
+func runFeedbackReport(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+	org, repo, _, _, store := feedbackSetup()
+
+	records, err := store.ListFeedback(ctx, org, repo)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to list feedback records: %v\n", err)
+		os.Exit(1)
+	}
+	summaries := state.SummarizeFeedback(records)
+
+	switch strings.ToLower(feedbackFormat) {
+	case "json":
+		out, err := json.MarshalIndent(summaries, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error marshaling report: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(out))
+	case "text":
+		writeFeedbackReport(os.Stdout, summaries)
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unsupported format: %s (use 'text' or 'json')\n", feedbackFormat)
+		os.Exit(1)
+	}
+}
+
+// writeFeedbackReport renders feedback summaries as a table.
+func writeFeedbackReport(w io.Writer, summaries []state.FeedbackSummary) {
+	if len(summaries) == 0 {
+		fmt.Fprintln(w, "No decisions recorded yet.")
+		return
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "STEP\tACTION\tTHRESHOLD\tDECISIONS\tFEEDBACK\tNEGATIVE\tPOSITIVE\tFP RATE")
+	for _, s := range summaries {
+		threshold := "-"
+		if s.Threshold > 0 {
+			threshold = fmt.Sprintf("%.2f", s.Threshold)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%d\t%d\t%d\t%.1f%%\n",
+			s.Step, s.Action, threshold, s.Decisions, s.WithFeedback, s.Negative, s.Positive, s.FalsePositiveRate*100)
+	}
+	tw.Flush()
+}
@@ -1,7 +1,7 @@
 // Author: Kaviru Hapuarachchi
 // GitHub: https://github.com/Kavirubc
 // Created: 2026-02-02
-// Last Modified: 2026-02-13
+// Last Modified: 2026-10-18
 
 package commands
 
@@ -20,6 +20,7 @@ import (
 	"github.com/similigh/simili-bot/internal/integrations/ai"
 	similiGithub "github.com/similigh/simili-bot/internal/integrations/github"
 	"github.com/similigh/simili-bot/internal/integrations/qdrant"
+	"github.com/similigh/simili-bot/internal/utils/issueforms"
 	"github.com/similigh/simili-bot/internal/utils/text"
 	"github.com/spf13/cobra"
 )
@@ -87,13 +88,18 @@ func runIndex(cmd *cobra.Command, args []string) {
 		log.Fatal("GitHub token is required (use --token or GITHUB_TOKEN env var)")
 	}
 
-	ghClient := similiGithub.NewClient(ctx, token)
+	limits := cfg.RateLimitRegistry() // Shared by all workers
+	ghClient := similiGithub.NewRateLimitedClient(ctx, token, limits.Get("github"))
 
 	embedder, err := ai.NewEmbedder(cfg.Embedding.APIKey, cfg.Embedding.Model)
 	if err != nil {
 		log.Fatalf("Failed to init embedder: %v", err)
 	}
 	defer embedder.Close()
+	if err := applyEmbeddingFallbacks(embedder, cfg); err != nil {
+		log.Fatalf("Failed to configure embedding fallbacks: %v", err)
+	}
+	embedder.SetRateLimits(limits)
 	embeddingDimensions := cfg.Embedding.Dimensions
 	if dim := embedder.Dimensions(); dim > 0 {
 		embeddingDimensions = dim
@@ -143,7 +149,7 @@ func runIndex(cmd *cobra.Command, args []string) {
 		go func(id int) {
 			defer wg.Done()
 			for job := range jobs {
-				processIssue(ctx, id, job.Issue, ghClient, embedder, qdrantClient, splitter, cfg.Qdrant.Collection, org, repoName, indexDryRun)
+				processIssue(ctx, id, job.Issue, ghClient, embedder, qdrantClient, splitter, cfg.Qdrant.Collection, org, repoName, cfg.Fingerprints.LineNumbers, indexDryRun)
 			}
 		}(i)
 	}
@@ -312,7 +318,7 @@ func processPullRequest(ctx context.Context, workerID int, issue *github.Issue,
 	}
 }
 
-func processIssue(ctx context.Context, workerID int, issue *github.Issue, gh *similiGithub.Client, em *ai.Embedder, qd *qdrant.Client, splitter *text.RecursiveCharacterSplitter, collection, org, repo string, dryRun bool) {
+func processIssue(ctx context.Context, workerID int, issue *github.Issue, gh *similiGithub.Client, em *ai.Embedder, qd *qdrant.Client, splitter *text.RecursiveCharacterSplitter, collection, org, repo string, fingerprintLines, dryRun bool) {
 	// 1. Fetch Comments (with pagination)
 	var allComments []*github.IssueComment
 	page := 1
@@ -367,6 +373,13 @@ func processIssue(ctx context.Context, workerID int, issue *github.Issue, gh *si
 		itemType = "pull_request"
 	}
 
+	assignees := make([]string, 0, len(issue.Assignees))
+	for _, a := range issue.Assignees {
+		assignees = append(assignees, a.GetLogin())
+	}
+
+	fingerprints := text.IssueFingerprints(issue.GetTitle(), issue.GetBody(), comments, fingerprintLines)
+	simhash := text.FormatSimHash(issueforms.SimHash(issue.GetBody()))
 	points := make([]*qdrant.Point, len(chunks))
 	for i, chunk := range chunks {
 		chunkID := uuid.NewMD5(uuid.NameSpaceURL, fmt.Appendf(nil, "%s/%s#%d-chunk-%d", org, repo, issue.GetNumber(), i)).String()
@@ -382,6 +395,10 @@ func processIssue(ctx context.Context, workerID int, issue *github.Issue, gh *si
 				"type":         itemType,
 				"state":        issue.GetState(),
 				"title":        issue.GetTitle(),
+				"assignees":    strings.Join(assignees, ","),
+				"closed_by":    issue.GetClosedBy().GetLogin(),
+				"fingerprints": fingerprints,
+				"simhash":      simhash,
 			},
 		}
 	}
@@ -1,7 +1,7 @@
 // Author: Kaviru Hapuarachchi
 // GitHub: https://github.com/kavirubc
 // Created: 2026-02-05
-// Last Modified: 2026-02-05
+// Last Modified: 2026-10-18
 
 package commands
 
@@ -94,6 +94,9 @@ func runLearn(cmd *cobra.Command, args []string) {
 		log.Fatalf("Failed to initialize embedder: %v", err)
 	}
 	defer embedder.Close()
+	if err := applyEmbeddingFallbacks(embedder, cfg); err != nil {
+		log.Fatalf("Failed to configure embedding fallbacks: %v", err)
+	}
 
 	// 4. Initialize Qdrant Client (unless dry-run)
 	var qdrantClient *qdrant.Client
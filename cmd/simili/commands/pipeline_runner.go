@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"slices"
 	"time"
 
 	"github.com/similigh/simili-bot/internal/core/config"
@@ -49,6 +50,7 @@ func (s *statusReportingStep) Run(ctx *pipeline.Context) error {
 // This function can be called with silent=true to suppress status reporting,
 // useful for batch processing where status updates are not desired.
 func ExecutePipeline(ctx context.Context, issue *pipeline.Issue, cfg *config.Config, deps *pipeline.Dependencies, stepNames []string, silent bool) (*pipeline.Result, error) {
+	cfg, stepNames = repositoryOverrides(cfg, stepNames, issue)
 	pCtx := pipeline.NewContext(ctx, issue, cfg)
 
 	registry := pipeline.NewRegistry()
@@ -113,6 +115,25 @@ func ExecutePipeline(ctx context.Context, issue *pipeline.Issue, cfg *config.Con
 	return pCtx.Result, nil
 }
 
+// repositoryOverrides applies the issue repository's entry in the config:
+// its thresholds and settings are merged onto the top-level config, and its
+// steps or workflow replace stepNames. The indexer is only kept if the caller
+// asked for it, so batch and eval runs stay read-only.
+func repositoryOverrides(cfg *config.Config, stepNames []string, issue *pipeline.Issue) (*config.Config, []string) {
+	repoCfg := cfg.FindRepository(issue.Org, issue.Repo)
+	if repoCfg == nil {
+		return cfg, stepNames
+	}
+	if repoCfg.HasStepOverride() {
+		repoSteps := pipeline.ResolveSteps(repoCfg.Steps, repoCfg.Workflow)
+		if !slices.Contains(stepNames, "indexer") {
+			repoSteps = slices.DeleteFunc(slices.Clone(repoSteps), func(name string) bool { return name == "indexer" })
+		}
+		stepNames = repoSteps
+	}
+	return cfg.ForRepository(issue.Org, issue.Repo), stepNames
+}
+
 func runPipeline(deps *pipeline.Dependencies, stepNames []string, issue *pipeline.Issue, cfg *config.Config) {
 	ctx := context.Background()
 
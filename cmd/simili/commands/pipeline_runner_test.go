@@ -0,0 +1,49 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package commands
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/similigh/simili-bot/internal/core/config"
+	"github.com/similigh/simili-bot/internal/core/pipeline"
+)
+
+func TestRepositoryOverrides(t *testing.T) {
+	cfg := &config.Config{
+		Defaults: config.DefaultsConfig{SimilarityThreshold: 0.65},
+		Repositories: []config.RepositoryConfig{
+			{Org: "acme", Repo: "monorepo", Enabled: true, Workflow: "similarity-only",
+				Defaults: &config.DefaultsConfig{SimilarityThreshold: 0.8}},
+			{Org: "acme", Repo: "docs", Enabled: true},
+		},
+	}
+	base := []string{"gatekeeper", "similarity_search", "indexer"}
+
+	got, names := repositoryOverrides(cfg, base, &pipeline.Issue{Org: "acme", Repo: "monorepo"})
+	if got.Defaults.SimilarityThreshold != 0.8 {
+		t.Errorf("SimilarityThreshold = %v, want 0.8", got.Defaults.SimilarityThreshold)
+	}
+	if !slices.Equal(names, pipeline.Presets["similarity-only"]) {
+		t.Errorf("steps = %v, want similarity-only preset", names)
+	}
+
+	// Without the indexer in the caller's steps (batch, eval), the repository
+	// workflow must not add it back.
+	_, names = repositoryOverrides(cfg, withoutIndexer(base), &pipeline.Issue{Org: "acme", Repo: "monorepo"})
+	if slices.Contains(names, "indexer") {
+		t.Errorf("steps = %v, indexer should stay excluded", names)
+	}
+	if !slices.Contains(pipeline.Presets["similarity-only"], "indexer") {
+		t.Error("filtering must not modify the preset")
+	}
+
+	got, names = repositoryOverrides(cfg, base, &pipeline.Issue{Org: "acme", Repo: "docs"})
+	if got.Defaults.SimilarityThreshold != 0.65 || !slices.Equal(names, base) {
+		t.Errorf("docs repo should keep top-level settings, got %v / %v", got.Defaults, names)
+	}
+}
@@ -1,7 +1,7 @@
 // Author: Kaviru Hapuarachchi
 // GitHub: https://github.com/kavirubc
 // Created: 2026-03-05
-// Last Modified: 2026-03-06
+// Last Modified: 2026-10-18
 
 package commands
 
@@ -149,6 +149,9 @@ func runPRDuplicate(cmd *cobra.Command, args []string) {
 		log.Fatalf("Failed to init embedder: %v", err)
 	}
 	defer embedder.Close()
+	if err := applyEmbeddingFallbacks(embedder, cfg); err != nil {
+		log.Fatalf("Failed to configure embedding fallbacks: %v", err)
+	}
 
 	content := buildPREmbeddingContent(pr.GetTitle(), pr.GetBody(), filePaths)
 	vec, err := embedder.Embed(ctx, content)
@@ -191,6 +194,9 @@ func runPRDuplicate(cmd *cobra.Command, args []string) {
 		llmClient, llmErr := ai.NewLLMClient(llmKey, cfg.LLM.Model)
 		if llmErr == nil {
 			defer llmClient.Close()
+			if cfgErr := configureLLM(llmClient, cfg, dailyUsageStore(cfg, token, org, repoName)); cfgErr != nil {
+				log.Fatalf("Failed to configure LLM client: %v", cfgErr)
+			}
 
 			// DetectDuplicate is issue-focused; restrict candidates to issues
 			// only so DuplicateOf is unambiguously an issue number.
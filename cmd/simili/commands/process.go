@@ -1,7 +1,7 @@
 // Author: Kaviru Hapuarachchi
 // GitHub: https://github.com/kavirubc
 // Created: 2026-02-02
-// Last Modified: 2026-02-17
+// Last Modified: 2026-10-18
 
 package commands
 
@@ -203,11 +203,17 @@ func runProcess() {
 	deps := &pipeline.Dependencies{
 		DryRun: dryRun,
 	}
+	limits := cfg.RateLimitRegistry()
 
 	// Initialize clients with error logging
 	// Embedder
 	embedder, err := ai.NewEmbedder(cfg.Embedding.APIKey, cfg.Embedding.Model)
 	if err == nil {
+		if fbErr := applyEmbeddingFallbacks(embedder, cfg); fbErr != nil {
+			fmt.Printf("Error: Failed to configure embedding fallbacks: %v\n", fbErr)
+			os.Exit(1)
+		}
+		embedder.SetRateLimits(limits)
 		deps.Embedder = embedder
 		if verbose {
 			fmt.Printf("Initialized Embedder (%s) with model: %s\n", embedder.Provider(), embedder.Model())
@@ -255,10 +261,15 @@ func runProcess() {
 	}
 
 	if token != "" {
-		ghClient := github.NewClient(context.Background(), token)
+		ghClient := github.NewRateLimitedClient(context.Background(), token, limits.Get("github"))
 		deps.GitHub = ghClient
 	}
 
+	// Feedback store: records acted-on decisions (disabled unless configured)
+	if !dryRun {
+		deps.Feedback = feedbackStore(cfg, token, issue.Org, issue.Repo)
+	}
+
 	// LLM Client (Gemini/OpenAI auto-selected by available keys)
 	llmKey := cfg.LLM.APIKey
 	if llmKey == "" {
@@ -270,6 +281,11 @@ func runProcess() {
 	}
 	llm, err := ai.NewLLMClient(llmKey, llmModel)
 	if err == nil {
+		if cfgErr := configureLLM(llm, cfg, dailyUsageStore(cfg, token, issue.Org, issue.Repo)); cfgErr != nil {
+			fmt.Printf("Error: %v\n", cfgErr)
+			os.Exit(1)
+		}
+		llm.SetRateLimits(limits)
 		deps.LLMClient = llm
 		if verbose {
 			fmt.Printf("Initialized LLM Client (%s) with model: %s\n", llm.Provider(), llm.Model())
@@ -330,19 +346,29 @@ func githubIssueToPipelineIssue(ghIssue *githubapi.Issue, org, repo string) pipe
 		author = ghIssue.User.GetLogin()
 	}
 
+	var assignees []string
+	for _, a := range ghIssue.Assignees {
+		if a.GetLogin() != "" {
+			assignees = append(assignees, a.GetLogin())
+		}
+	}
+
 	return pipeline.Issue{
-		Org:         org,
-		Repo:        repo,
-		Number:      ghIssue.GetNumber(),
-		Title:       ghIssue.GetTitle(),
-		Body:        ghIssue.GetBody(),
-		State:       ghIssue.GetState(),
-		Labels:      labels,
-		Author:      author,
-		URL:         ghIssue.GetHTMLURL(),
-		CreatedAt:   createdAt,
-		EventType:   "issues",
-		EventAction: "opened",
+		Org:               org,
+		Repo:              repo,
+		Number:            ghIssue.GetNumber(),
+		Title:             ghIssue.GetTitle(),
+		Body:              ghIssue.GetBody(),
+		State:             ghIssue.GetState(),
+		Labels:            labels,
+		Author:            author,
+		AuthorAssociation: ghIssue.GetAuthorAssociation(),
+		Assignees:         assignees,
+		ClosedBy:          ghIssue.GetClosedBy().GetLogin(),
+		URL:               ghIssue.GetHTMLURL(),
+		CreatedAt:         createdAt,
+		EventType:         "issues",
+		EventAction:       "opened",
 	}
 }
 
@@ -421,6 +447,9 @@ func enrichIssueFromGitHubEvent(issue *pipeline.Issue, raw map[string]interface{
 			if sender, ok := raw["sender"].(map[string]interface{}); ok {
 				if login, ok := sender["login"].(string); ok {
 					issue.CommentAuthor = login
+					if action == "closed" && issue.ClosedBy == "" {
+						issue.ClosedBy = login
+					}
 				}
 			}
 		}
@@ -448,6 +477,24 @@ func populateIssuePayload(issue *pipeline.Issue, payload map[string]interface{})
 			issue.Author = login
 		}
 	}
+	if assoc, ok := payload["author_association"].(string); ok {
+		issue.AuthorAssociation = assoc
+	}
+	if assignees, ok := payload["assignees"].([]interface{}); ok {
+		issue.Assignees = nil
+		for _, a := range assignees {
+			if user, ok := a.(map[string]interface{}); ok {
+				if login, ok := user["login"].(string); ok {
+					issue.Assignees = append(issue.Assignees, login)
+				}
+			}
+		}
+	}
+	if closedBy, ok := payload["closed_by"].(map[string]interface{}); ok {
+		if login, ok := closedBy["login"].(string); ok {
+			issue.ClosedBy = login
+		}
+	}
 	if createdAt, ok := payload["created_at"].(string); ok {
 		if t, err := time.Parse(time.RFC3339, createdAt); err == nil {
 			issue.CreatedAt = t
@@ -117,6 +117,31 @@ func TestEnrichIssueFromGitHubEvent_PRComment(t *testing.T) {
 	}
 }
 
+func TestEnrichIssueFromGitHubEvent_Closed(t *testing.T) {
+	issue := &pipeline.Issue{}
+	raw := map[string]interface{}{
+		"action": "closed",
+		"issue": map[string]interface{}{
+			"number": float64(7),
+			"state":  "closed",
+			"assignees": []interface{}{
+				map[string]interface{}{"login": "alice"},
+				map[string]interface{}{"login": "bob"},
+			},
+		},
+		"sender": map[string]interface{}{"login": "maintainer"},
+	}
+
+	enrichIssueFromGitHubEvent(issue, raw)
+
+	if len(issue.Assignees) != 2 || issue.Assignees[0] != "alice" {
+		t.Fatalf("expected assignees to be parsed, got %v", issue.Assignees)
+	}
+	if issue.ClosedBy != "maintainer" {
+		t.Fatalf("expected the sender to be recorded as closer, got %q", issue.ClosedBy)
+	}
+}
+
 func TestGithubIssueToPipelineIssue(t *testing.T) {
 	createdAt := githubapi.Timestamp{Time: time.Date(2026, 2, 13, 10, 0, 0, 0, time.UTC)}
 	ghIssue := &githubapi.Issue{
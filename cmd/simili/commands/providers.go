@@ -0,0 +1,72 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	similiConfig "github.com/similigh/simili-bot/internal/core/config"
+	"github.com/similigh/simili-bot/internal/integrations/ai"
+)
+
+// providerSpecs converts configured fallbacks into AI provider specs.
+func providerSpecs(fallbacks []similiConfig.ProviderFallback) []ai.ProviderSpec {
+	specs := make([]ai.ProviderSpec, 0, len(fallbacks))
+	for _, fb := range fallbacks {
+		specs = append(specs, ai.ProviderSpec{
+			Provider:   fb.Provider,
+			APIKey:     fb.APIKey,
+			Model:      fb.Model,
+			Dimensions: fb.Dimensions,
+		})
+	}
+	return specs
+}
+
+// breakerCooldown returns the configured circuit breaker cooldown.
+func breakerCooldown(cb similiConfig.CircuitBreakerConfig) time.Duration {
+	return time.Duration(cb.CooldownSeconds) * time.Second
+}
+
+// configureLLM applies the configured prompt templates, daily token budget
+// and fallback chain to llm. usage shares the budget across runs; it may be
+// nil.
+func configureLLM(llm *ai.LLMClient, cfg *similiConfig.Config, usage ai.DailyUsageStore) error {
+	if err := llm.LoadPrompts(cfg.Prompts.Dir, cfg.Prompts.Overrides); err != nil {
+		return err
+	}
+	llm.SetDailyTokenBudget(cfg.LLM.MaxTokensPerDay, usage)
+	if err := applyLLMFallbacks(llm, cfg); err != nil {
+		return fmt.Errorf("failed to configure LLM fallbacks: %w", err)
+	}
+	return nil
+}
+
+// applyLLMFallbacks attaches the configured fallback chain and circuit breaker.
+func applyLLMFallbacks(llm *ai.LLMClient, cfg *similiConfig.Config) error {
+	if len(cfg.LLM.Fallbacks) == 0 {
+		return nil
+	}
+	if err := llm.AddFallbacks(providerSpecs(cfg.LLM.Fallbacks)); err != nil {
+		return err
+	}
+	llm.SetCircuitBreaker(cfg.LLM.CircuitBreaker.FailureThreshold, breakerCooldown(cfg.LLM.CircuitBreaker))
+	return nil
+}
+
+// applyEmbeddingFallbacks attaches the configured embedding fallback chain
+// and circuit breaker.
+func applyEmbeddingFallbacks(embedder *ai.Embedder, cfg *similiConfig.Config) error {
+	if len(cfg.Embedding.Fallbacks) == 0 {
+		return nil
+	}
+	if err := embedder.AddFallbacks(providerSpecs(cfg.Embedding.Fallbacks)); err != nil {
+		return err
+	}
+	embedder.SetCircuitBreaker(cfg.Embedding.CircuitBreaker.FailureThreshold, breakerCooldown(cfg.Embedding.CircuitBreaker))
+	return nil
+}
@@ -0,0 +1,35 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	similiConfig "github.com/similigh/simili-bot/internal/core/config"
+	"github.com/similigh/simili-bot/internal/integrations/ai"
+)
+
+func TestConfigureLLM(t *testing.T) {
+	t.Setenv("GEMINI_API_KEY", "")
+	t.Setenv("OPENAI_API_KEY", "sk-test")
+	llm, err := ai.NewLLMClient("")
+	if err != nil {
+		t.Fatalf("NewLLMClient() error = %v", err)
+	}
+	t.Cleanup(func() { _ = llm.Close() })
+
+	cfg := &similiConfig.Config{}
+	cfg.ApplyDefaults()
+	if err := configureLLM(llm, cfg, nil); err != nil {
+		t.Fatalf("configureLLM() error = %v", err)
+	}
+
+	cfg.Prompts.Overrides = map[string]string{"triage": "{{ .Missing"}
+	if err := configureLLM(llm, cfg, nil); err == nil || !strings.Contains(err.Error(), "failed to load prompt templates") {
+		t.Errorf("Expected a prompt template error, got %v", err)
+	}
+}
@@ -1,15 +1,18 @@
 // Author: Kaviru Hapuarachchi
 // GitHub: https://github.com/kavirubc
 // Created: 2026-02-02
-// Last Modified: 2026-02-02
+// Last Modified: 2026-10-18
 
 package commands
 
 import (
 	"fmt"
+	"log"
 	"os"
 
 	"github.com/spf13/cobra"
+
+	"github.com/similigh/simili-bot/internal/core/config"
 )
 
 var (
@@ -28,6 +31,10 @@ The CLI allows you to run the bot pipeline locally for testing and development.`
 	// Uncomment the following line if your bare application
 	// has an action associated with it:
 	// Run: func(cmd *cobra.Command, args []string) { },
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		// Secrets resolved from the config are masked in every log line.
+		log.SetOutput(config.NewRedactingWriter(os.Stderr))
+	},
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
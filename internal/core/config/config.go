@@ -1,7 +1,7 @@
 // Author: Kaviru Hapuarachchi
 // GitHub: https://github.com/kavirubc
 // Created: 2026-02-02
-// Last Modified: 2026-03-06
+// Last Modified: 2026-10-18
 
 // Package config handles loading and merging Simili configuration.
 package config
@@ -12,14 +12,21 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/similigh/simili-bot/internal/utils/ratelimit"
 	"gopkg.in/yaml.v3"
 )
 
 // Config is the root configuration structure.
 type Config struct {
 	// Extends allows inheriting from a remote config (e.g., "org/repo@branch").
+	// The parent may extend another config in turn.
 	Extends string `yaml:"extends,omitempty"`
 
+	// Merge sets how lists are combined with the extended config, keyed by
+	// dotted path (e.g. "bot_users": "append", "repositories": "merge-by-key").
+	// Lists are replaced by default.
+	Merge map[string]string `yaml:"merge,omitempty"`
+
 	// Qdrant configures the vector database connection.
 	Qdrant QdrantConfig `yaml:"qdrant"`
 
@@ -54,6 +61,186 @@ type Config struct {
 	// to prevent infinite comment loops. Built-in heuristics (e.g. "[bot]" suffix,
 	// "gh-simili" prefix) always apply in addition to this list.
 	BotUsers []string `yaml:"bot_users,omitempty"`
+
+	// Plugins configures out-of-process steps declared as "exec:<command>" in Steps.
+	Plugins PluginsConfig `yaml:"plugins,omitempty"`
+
+	// Classifiers declares prompt-driven steps referenced as "llm_classifier:<name>" in Steps.
+	Classifiers []ClassifierConfig `yaml:"classifiers,omitempty"`
+
+	// Prompts overrides the built-in LLM prompt templates.
+	Prompts PromptsConfig `yaml:"prompts,omitempty"`
+
+	// RateLimits caps request and token rates per provider ("gemini", "openai", "github").
+	RateLimits map[string]RateLimitConfig `yaml:"rate_limits,omitempty"`
+
+	// Feedback configures recording of bot decisions and maintainer feedback on them.
+	Feedback FeedbackConfig `yaml:"feedback,omitempty"`
+
+	// Comments configures how the triage report comment is kept up to date across runs.
+	Comments CommentsConfig `yaml:"comments,omitempty"`
+
+	// Labels constrains the labels triage may suggest.
+	Labels LabelsConfig `yaml:"labels,omitempty"`
+
+	// Owners configures the owner_suggester step.
+	Owners OwnersConfig `yaml:"owners,omitempty"`
+
+	// Severity configures the severity_estimator step.
+	Severity SeverityConfig `yaml:"severity,omitempty"`
+
+	// NeedsInfo configures the needs_info step and the close-stale command.
+	NeedsInfo NeedsInfoConfig `yaml:"needs_info,omitempty"`
+
+	// Templates configures the template_checker step.
+	Templates TemplatesConfig `yaml:"templates,omitempty"`
+
+	// Fingerprints configures stack trace fingerprinting and the
+	// fingerprint_match step.
+	Fingerprints FingerprintsConfig `yaml:"fingerprints,omitempty"`
+
+	// NearDuplicate configures the SimHash pre-check in duplicate_detector.
+	NearDuplicate NearDuplicateConfig `yaml:"near_duplicate,omitempty"`
+}
+
+// NearDuplicateConfig configures flagging copy-pasted re-posts without an LLM
+// call: a candidate whose body SimHash is within MaxDistance bits of the
+// issue's is reported as a duplicate directly. Signatures are always stored
+// when indexing.
+type NearDuplicateConfig struct {
+	Enabled     *bool `yaml:"enabled,omitempty"`      // Default: false
+	MaxDistance int   `yaml:"max_distance,omitempty"` // Max differing bits out of 64 (default: 3)
+}
+
+// FingerprintsConfig configures stack trace fingerprints. They are always
+// stored when indexing; Enabled turns on matching them in fingerprint_match.
+type FingerprintsConfig struct {
+	Enabled     *bool `yaml:"enabled,omitempty"`      // Default: false
+	LineNumbers bool  `yaml:"line_numbers,omitempty"` // Include line numbers, so only crashes on the same version match (re-index after changing)
+}
+
+// TemplatesConfig configures checking issues against the repository's issue
+// forms (.github/ISSUE_TEMPLATE/*.yml).
+type TemplatesConfig struct {
+	Enabled *bool `yaml:"enabled,omitempty"` // Default: false
+}
+
+// NeedsInfoConfig configures the needs-info loop: issues scoring below
+// Threshold in the quality check are labelled and their author is asked for
+// the missing details. The label is removed once the author's edit or reply
+// brings the issue above the threshold, and close-stale closes issues that
+// got no reply within StaleDays.
+type NeedsInfoConfig struct {
+	Enabled      *bool   `yaml:"enabled,omitempty"`       // Default: false
+	Threshold    float64 `yaml:"threshold,omitempty"`     // Quality score below which more information is requested (default: 0.5)
+	Label        string  `yaml:"label,omitempty"`         // Default: "needs-info"
+	StaleDays    int     `yaml:"stale_days,omitempty"`    // Days without a reply before close-stale closes the issue (default: 14)
+	CloseMessage string  `yaml:"close_message,omitempty"` // Comment posted when closing a stale issue
+}
+
+// SeverityConfig configures priority estimation, from P0 (most urgent) to P3.
+type SeverityConfig struct {
+	Enabled       *bool             `yaml:"enabled,omitempty"`        // Default: false
+	Labels        map[string]string `yaml:"labels,omitempty"`         // Priority -> label (default: "P0" -> "priority/P0", ...)
+	MinConfidence float64           `yaml:"min_confidence,omitempty"` // Estimates below this get no label (default: 0.6)
+	OnCall        string            `yaml:"on_call,omitempty"`        // Handle paged in a separate comment for P0 issues, e.g. "@acme/on-call"
+}
+
+// OwnersConfig configures owner suggestions, drawn from CODEOWNERS entries
+// for paths the issue mentions or its linked pull requests touch, the
+// assignees and closers of similar past issues, and the Areas map.
+type OwnersConfig struct {
+	Enabled        *bool       `yaml:"enabled,omitempty"`         // Default: false
+	Mode           string      `yaml:"mode,omitempty"`            // "suggest" (default) or "assign" (also assign suggested users to unassigned issues)
+	MaxSuggestions int         `yaml:"max_suggestions,omitempty"` // Default: 3
+	Areas          []OwnerArea `yaml:"areas,omitempty"`
+}
+
+// OwnerArea maps an area of the project to the people or team owning it.
+type OwnerArea struct {
+	Area   string   `yaml:"area"`
+	Labels []string `yaml:"labels,omitempty"` // Labels or glob patterns that put an issue in the area (default: the area name)
+	Owners []string `yaml:"owners"`           // "@user" or "@org/team"
+}
+
+// LabelsConfig restricts triage suggestions, which are always limited to
+// labels that exist in the repository. Entries are case-insensitive and may
+// use glob patterns such as "area/*".
+type LabelsConfig struct {
+	Allow []string `yaml:"allow,omitempty"` // Only these repository labels may be suggested (default: all)
+	Deny  []string `yaml:"deny,omitempty"`  // Labels never suggested
+
+	// Groups declares label dimensions (e.g. type, area, priority) that
+	// triage picks one label from.
+	Groups []LabelGroup `yaml:"groups,omitempty"`
+}
+
+// LabelGroup is a label dimension such as "type" with members "type/*".
+type LabelGroup struct {
+	Name          string   `yaml:"name"`
+	Members       []string `yaml:"members"`                  // Labels or glob patterns
+	Exclusive     bool     `yaml:"exclusive,omitempty"`      // At most one member per issue; applying one removes the others
+	Required      bool     `yaml:"required,omitempty"`       // Triage must always pick a member
+	MinConfidence float64  `yaml:"min_confidence,omitempty"` // Picks below this are ignored, unless the group is required
+}
+
+// CommentsConfig configures updates of the bot's triage report comment.
+type CommentsConfig struct {
+	Update           string `yaml:"update,omitempty"`            // "edit" (default), "minimize" (hide the old report, post a new one) or "new"
+	ChangelogEntries int    `yaml:"changelog_entries,omitempty"` // Changelog entries kept in the report (default: 10)
+}
+
+// FeedbackConfig configures where decisions and feedback signals are stored.
+// Records go to the state branch unless Dir is set.
+type FeedbackConfig struct {
+	Enabled   *bool  `yaml:"enabled,omitempty"`
+	StateRepo string `yaml:"state_repo,omitempty"` // owner/name holding the state branch (default: the issue's repository)
+	Branch    string `yaml:"branch,omitempty"`     // Default: "simili-state"
+	Dir       string `yaml:"dir,omitempty"`        // Local directory used instead of the state branch
+}
+
+// RateLimitConfig caps the rate of calls to one provider. Zero means unlimited.
+type RateLimitConfig struct {
+	RequestsPerMinute int `yaml:"requests_per_minute,omitempty"`
+	TokensPerMinute   int `yaml:"tokens_per_minute,omitempty"` // AI providers only
+}
+
+// PromptsConfig overrides LLM prompt templates (triage, response, route_issue,
+// quality_assessment, explain_transfer, duplicate_detection, severity).
+type PromptsConfig struct {
+	Dir       string            `yaml:"dir,omitempty"`       // Directory containing <name>.tmpl files
+	Overrides map[string]string `yaml:"overrides,omitempty"` // Inline templates by name (take precedence over Dir)
+}
+
+// PluginsConfig configures out-of-process plugin steps.
+type PluginsConfig struct {
+	TimeoutSeconds int `yaml:"timeout_seconds,omitempty"` // Per-invocation timeout (default: 30)
+}
+
+// ClassifierConfig defines a declarative LLM classifier step.
+type ClassifierConfig struct {
+	Name        string            `yaml:"name"`
+	Prompt      string            `yaml:"prompt"`                // text/template over .Issue and .SimilarIssues
+	Output      map[string]string `yaml:"output"`                // field -> "string" | "number" | "boolean" | "array"
+	Rules       []ClassifierRule  `yaml:"rules,omitempty"`       // Mapping from output fields to actions
+	Temperature *float64          `yaml:"temperature,omitempty"` // Default: 0.2
+}
+
+// ClassifierRule maps a classifier output field to labels, comment sections or metadata.
+// A rule without a condition fires whenever the field is present and not false/empty.
+type ClassifierRule struct {
+	Field string `yaml:"field"`
+
+	// Conditions (all that are set must match).
+	Equals string   `yaml:"equals,omitempty"` // Case-insensitive string comparison
+	In     []string `yaml:"in,omitempty"`     // Case-insensitive membership
+	Min    *float64 `yaml:"min,omitempty"`    // Numeric lower bound (inclusive)
+	Max    *float64 `yaml:"max,omitempty"`    // Numeric upper bound (inclusive)
+
+	// Actions. Label and Comment are templates over .Value and .Output.
+	Label    string `yaml:"label,omitempty"`
+	Comment  string `yaml:"comment,omitempty"`
+	Metadata string `yaml:"metadata,omitempty"` // Metadata key that receives the field value
 }
 
 // AutoCloseConfig configures the auto-close behavior for duplicate issues.
@@ -77,6 +264,29 @@ type EmbeddingConfig struct {
 	APIKey     string `yaml:"api_key"`
 	Model      string `yaml:"model,omitempty"`
 	Dimensions int    `yaml:"dimensions,omitempty"`
+
+	// Fallbacks are tried in order when the provider fails. They must use the
+	// primary provider and model (e.g. with another API key), so every stored
+	// vector comes from the same model.
+	Fallbacks []ProviderFallback `yaml:"fallbacks,omitempty"`
+
+	// CircuitBreaker applies to every embedding provider.
+	CircuitBreaker CircuitBreakerConfig `yaml:"circuit_breaker,omitempty"`
+}
+
+// ProviderFallback is an alternative provider/model in a fallback chain.
+type ProviderFallback struct {
+	Provider   string `yaml:"provider,omitempty"` // Inferred from api_key when empty
+	APIKey     string `yaml:"api_key,omitempty"`  // Defaults to GEMINI_API_KEY / OPENAI_API_KEY
+	Model      string `yaml:"model,omitempty"`
+	Dimensions int    `yaml:"dimensions,omitempty"` // Embedding fallbacks only; must match the primary's dimensions
+}
+
+// CircuitBreakerConfig controls when a failing provider is skipped in favor
+// of its fallbacks.
+type CircuitBreakerConfig struct {
+	FailureThreshold int `yaml:"failure_threshold,omitempty"` // Consecutive failures before opening (default: 3)
+	CooldownSeconds  int `yaml:"cooldown_seconds,omitempty"`  // Time before a trial call is allowed (default: 60)
 }
 
 // LLMConfig holds LLM provider settings.
@@ -85,6 +295,27 @@ type LLMConfig struct {
 	APIKey      string   `yaml:"api_key"`
 	Model       string   `yaml:"model,omitempty"`
 	Temperature *float64 `yaml:"temperature,omitempty"`
+
+	// Token budgets (prompt + completion). 0 = unlimited. The daily budget
+	// resets at UTC midnight. process and pr-duplicate share it between runs
+	// through the state branch; batch and the web server track it per process.
+	MaxTokensPerRun int `yaml:"max_tokens_per_run,omitempty"`
+	MaxTokensPerDay int `yaml:"max_tokens_per_day,omitempty"`
+
+	// Fallbacks are tried in order when the provider fails or its circuit is open.
+	Fallbacks []ProviderFallback `yaml:"fallbacks,omitempty"`
+
+	// CircuitBreaker applies to every LLM provider.
+	CircuitBreaker CircuitBreakerConfig `yaml:"circuit_breaker,omitempty"`
+
+	// Pricing maps model names (LLM and embedding) to USD prices per million tokens.
+	Pricing map[string]ModelPricing `yaml:"pricing,omitempty"`
+}
+
+// ModelPricing is the price of a model in USD per million tokens.
+type ModelPricing struct {
+	InputPerMillion  float64 `yaml:"input_per_million"`
+	OutputPerMillion float64 `yaml:"output_per_million,omitempty"`
 }
 
 // DefaultsConfig holds default behavior settings.
@@ -95,6 +326,13 @@ type DefaultsConfig struct {
 	// DuplicateCandidates is the maximum number of similar issues sent to the LLM
 	// for duplicate/relation analysis. Default: 5.
 	DuplicateCandidates int `yaml:"duplicate_candidates,omitempty"`
+	// EditRetriage makes the edit_filter step re-triage "edited" events only
+	// when the title changed or the meaning moved by more than
+	// EditSemanticDelta; other edits just refresh the index. Default: true.
+	EditRetriage *bool `yaml:"edit_retriage,omitempty"`
+	// EditSemanticDelta is the cosine distance between the edited issue and
+	// its indexed version above which it is re-triaged. Default: 0.05.
+	EditSemanticDelta float64 `yaml:"edit_semantic_delta,omitempty"`
 }
 
 // RepositoryConfig defines a repository and its settings.
@@ -104,6 +342,42 @@ type RepositoryConfig struct {
 	Description string   `yaml:"description,omitempty"` // For LLM routing
 	Labels      []string `yaml:"labels,omitempty"`
 	Enabled     bool     `yaml:"enabled"`
+
+	// Overrides merged onto the top-level settings for issues in this
+	// repository (see ForRepository). Unset fields inherit the top-level value.
+	Workflow  string           `yaml:"workflow,omitempty"`
+	Steps     []string         `yaml:"steps,omitempty"`
+	Defaults  *DefaultsConfig  `yaml:"defaults,omitempty"`
+	Transfer  *TransferConfig  `yaml:"transfer,omitempty"`
+	AutoClose *AutoCloseConfig `yaml:"auto_close,omitempty"`
+	// LabelPolicy overrides the top-level labels settings (the "labels" key
+	// here lists the repository's routing labels).
+	LabelPolicy *LabelsConfig `yaml:"label_policy,omitempty"`
+
+	// overrides holds the override sections as written, so ForRepository
+	// applies explicit false and zero values too.
+	overrides map[string]*yaml.Node
+}
+
+// UnmarshalYAML decodes a repositories entry and keeps the YAML of its
+// override sections.
+func (r *RepositoryConfig) UnmarshalYAML(value *yaml.Node) error {
+	type plain RepositoryConfig
+	if err := value.Decode((*plain)(r)); err != nil {
+		return err
+	}
+	r.overrides = make(map[string]*yaml.Node)
+	for _, key := range []string{"defaults", "transfer", "auto_close", "label_policy"} {
+		if n := mappingValue(documentRoot(value), key); !isNull(n) {
+			r.overrides[key] = n
+		}
+	}
+	return nil
+}
+
+// HasStepOverride reports whether the repository selects its own steps or workflow.
+func (r *RepositoryConfig) HasStepOverride() bool {
+	return r.Workflow != "" || len(r.Steps) > 0
 }
 
 // TransferRule defines a rule for transferring issues to another repository.
@@ -207,58 +481,112 @@ func Load(path string) (*Config, error) {
 // LoadWithInheritance loads a config and resolves the 'extends' chain.
 // The fetcher function is used to retrieve remote configs.
 func LoadWithInheritance(path string, fetcher func(ref string) ([]byte, error)) (*Config, error) {
+	cfg, err := Resolve(path, fetcher)
+	if err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Resolve loads a config, follows its 'extends' chain and applies defaults
+// without validating the result. Each level is deep-merged onto its parent:
+// keys set in the child override the parent (explicit false and zero values
+// included), and lists are replaced unless the child's `merge` section
+// chooses another strategy. Cycles in the chain are reported as errors.
+func Resolve(path string, fetcher func(ref string) ([]byte, error)) (*Config, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	cfg, err := parseRaw(data)
+	merged, err := resolveChain(data, fetcher)
 	if err != nil {
 		return nil, err
 	}
 
-	if cfg.Extends == "" {
-		cfg.applyDefaults()
-		if err := cfg.Validate(); err != nil {
-			return nil, err
+	var cfg Config
+	if merged != nil {
+		if err := merged.Decode(&cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse config: %w", err)
 		}
-		return cfg, nil
 	}
+	// The chain is resolved; these only describe how it was built.
+	cfg.Extends = ""
+	cfg.Merge = nil
 
-	// Fetch and parse the parent config
-	parentData, err := fetcher(cfg.Extends)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch parent config '%s': %w", cfg.Extends, err)
-	}
+	cfg.applyDefaults()
+	return &cfg, nil
+}
 
-	parentCfg, err := parseRaw(parentData)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse parent config: %w", err)
-	}
+// Parse parses a single config file's YAML content and resolves its ${...}
+// references, without following 'extends' or applying defaults.
+func Parse(data []byte) (*Config, error) {
+	return parseRaw(data)
+}
 
-	// Merge: child overrides parent
-	merged := mergeConfigs(parentCfg, cfg)
-	merged.applyDefaults()
-	if err := merged.Validate(); err != nil {
-		return nil, err
+// Redacted returns a copy of the config with API keys masked, for display.
+func (c *Config) Redacted() *Config {
+	out := *c
+	out.Qdrant.APIKey = redact(c.Qdrant.APIKey)
+	out.Embedding.APIKey = redact(c.Embedding.APIKey)
+	out.LLM.APIKey = redact(c.LLM.APIKey)
+	out.Embedding.Fallbacks = redactFallbacks(c.Embedding.Fallbacks)
+	out.LLM.Fallbacks = redactFallbacks(c.LLM.Fallbacks)
+	return &out
+}
+
+func redactFallbacks(fallbacks []ProviderFallback) []ProviderFallback {
+	if fallbacks == nil {
+		return nil
 	}
+	out := make([]ProviderFallback, len(fallbacks))
+	for i, fb := range fallbacks {
+		fb.APIKey = redact(fb.APIKey)
+		out[i] = fb
+	}
+	return out
+}
 
-	return merged, nil
+func redact(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return "***"
 }
 
-// parseRaw parses YAML content and expands environment variables without applying defaults.
+// parseRaw parses YAML content and resolves ${...} references without applying defaults.
 func parseRaw(data []byte) (*Config, error) {
-	// Expand environment variables in the YAML content
-	expanded := os.ExpandEnv(string(data))
+	doc, err := parseNode(data, resolveReference)
+	if err != nil {
+		return nil, err
+	}
 
 	var cfg Config
-	if err := yaml.Unmarshal([]byte(expanded), &cfg); err != nil {
-		return nil, fmt.Errorf("failed to parse config: %w", err)
+	if len(doc.Content) > 0 {
+		if err := doc.Decode(&cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse config: %w", err)
+		}
 	}
 
 	return &cfg, nil
 }
 
+// parseNode parses YAML content into a node tree and resolves the ${...}
+// references in its values with resolve (see resolveReference).
+func parseNode(data []byte, resolve referenceResolver) (*yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+	if err := expandReferences(&doc, resolve); err != nil {
+		return nil, fmt.Errorf("failed to resolve config reference: %w", err)
+	}
+	return &doc, nil
+}
+
 // Validate ensures required configuration fields are present.
 // Note: llm.api_key is intentionally not required here — the process command
 // falls back to embedding.api_key when llm.api_key is unset, so rejecting the
@@ -288,6 +616,54 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// FindRepository returns the repositories entry for org/repo, or nil if there is none.
+func (c *Config) FindRepository(org, repo string) *RepositoryConfig {
+	for i := range c.Repositories {
+		r := &c.Repositories[i]
+		if r.Org == org && r.Repo == repo {
+			return r
+		}
+	}
+	return nil
+}
+
+// ForRepository returns the effective config for issues in org/repo: the
+// overrides of its repositories entry merged onto the top-level settings.
+// Every key written in an override section applies, including false and
+// zero values. It returns c itself when the repository has no entry.
+func (c *Config) ForRepository(org, repo string) *Config {
+	r := c.FindRepository(org, repo)
+	if r == nil {
+		return c
+	}
+
+	out := *c
+	if r.Workflow != "" {
+		out.Workflow = r.Workflow
+	}
+	if len(r.Steps) > 0 {
+		out.Steps = r.Steps
+	}
+	applyOverride(&out.Defaults, r.Defaults, r.overrides["defaults"])
+	applyOverride(&out.Transfer, r.Transfer, r.overrides["transfer"])
+	applyOverride(&out.AutoClose, r.AutoClose, r.overrides["auto_close"])
+	applyOverride(&out.Labels, r.LabelPolicy, r.overrides["label_policy"])
+	return &out
+}
+
+// RateLimitRegistry builds the limiters configured under rate_limits. Create
+// it once per process so every client and worker draws from the same buckets.
+func (c *Config) RateLimitRegistry() *ratelimit.Registry {
+	limits := make(map[string]ratelimit.Limit, len(c.RateLimits))
+	for name, limit := range c.RateLimits {
+		limits[name] = ratelimit.Limit{
+			RequestsPerMinute: limit.RequestsPerMinute,
+			TokensPerMinute:   limit.TokensPerMinute,
+		}
+	}
+	return ratelimit.NewRegistry(limits)
+}
+
 // FindConfigPath searches for a config file in standard locations.
 func FindConfigPath(explicit string) string {
 	if explicit != "" {
@@ -334,6 +710,13 @@ func (c *Config) applyDefaults() {
 	if c.Defaults.DuplicateCandidates <= 0 {
 		c.Defaults.DuplicateCandidates = 5
 	}
+	if c.Defaults.EditRetriage == nil {
+		t := true
+		c.Defaults.EditRetriage = &t
+	}
+	if c.Defaults.EditSemanticDelta == 0 {
+		c.Defaults.EditSemanticDelta = 0.05
+	}
 	if c.Defaults.CrossRepoSearch == nil {
 		t := true
 		c.Defaults.CrossRepoSearch = &t
@@ -404,175 +787,75 @@ func (c *Config) applyDefaults() {
 	if c.ClaudeCode.ReviewChecklist.TriggerLabel == "" {
 		c.ClaudeCode.ReviewChecklist.TriggerLabel = "review-checklist"
 	}
-}
-
-// mergeConfigs merges a child config onto a parent config.
-// Non-zero values in child override parent.
-func mergeConfigs(parent, child *Config) *Config {
-	result := *parent
-
-	// String fields: override if non-empty
-	if child.Workflow != "" {
-		result.Workflow = child.Workflow
+	// Plugin defaults
+	if c.Plugins.TimeoutSeconds <= 0 {
+		c.Plugins.TimeoutSeconds = 30
 	}
-	if len(child.Steps) > 0 {
-		result.Steps = child.Steps
-	}
-
-	// Qdrant: override if any field is set
-	if child.Qdrant.URL != "" {
-		result.Qdrant.URL = child.Qdrant.URL
+	// Comment defaults
+	if c.Comments.Update == "" {
+		c.Comments.Update = "edit"
 	}
-	if child.Qdrant.APIKey != "" {
-		result.Qdrant.APIKey = child.Qdrant.APIKey
+	if c.Comments.ChangelogEntries == 0 {
+		c.Comments.ChangelogEntries = 10
 	}
-	if child.Qdrant.Collection != "" {
-		result.Qdrant.Collection = child.Qdrant.Collection
-	}
-	if child.Qdrant.PRCollection != "" {
-		result.Qdrant.PRCollection = child.Qdrant.PRCollection
-	}
-
-	// Embedding: override if any field is set
-	if child.Embedding.Provider != "" {
-		result.Embedding.Provider = child.Embedding.Provider
-	}
-	if child.Embedding.APIKey != "" {
-		result.Embedding.APIKey = child.Embedding.APIKey
-	}
-	if child.Embedding.Model != "" {
-		result.Embedding.Model = child.Embedding.Model
-	}
-
-	// LLM: override if any field is set
-	if child.LLM.Provider != "" {
-		result.LLM.Provider = child.LLM.Provider
-	}
-	if child.LLM.APIKey != "" {
-		result.LLM.APIKey = child.LLM.APIKey
-	}
-	if child.LLM.Model != "" {
-		result.LLM.Model = child.LLM.Model
-	}
-	if child.LLM.Temperature != nil {
-		result.LLM.Temperature = child.LLM.Temperature
-	}
-
-	// Defaults: override if non-zero
-	if child.Defaults.SimilarityThreshold != 0 {
-		result.Defaults.SimilarityThreshold = child.Defaults.SimilarityThreshold
-	}
-	if child.Defaults.MaxSimilarToShow != 0 {
-		result.Defaults.MaxSimilarToShow = child.Defaults.MaxSimilarToShow
-	}
-	if child.Defaults.CrossRepoSearch != nil {
-		result.Defaults.CrossRepoSearch = child.Defaults.CrossRepoSearch
-	}
-	if child.Defaults.DuplicateCandidates != 0 {
-		result.Defaults.DuplicateCandidates = child.Defaults.DuplicateCandidates
-	}
-
-	// Repositories: child completely overrides if non-empty
-	if len(child.Repositories) > 0 {
-		result.Repositories = child.Repositories
-	}
-
-	// Transfer: override if fields are set
-	if child.Transfer.Enabled != nil {
-		result.Transfer.Enabled = child.Transfer.Enabled
-	}
-	if child.Transfer.LLMRoutingEnabled != nil {
-		result.Transfer.LLMRoutingEnabled = child.Transfer.LLMRoutingEnabled
-	}
-	if len(child.Transfer.Rules) > 0 {
-		result.Transfer.Rules = child.Transfer.Rules
-	}
-	if child.Transfer.HighConfidence != 0 {
-		result.Transfer.HighConfidence = child.Transfer.HighConfidence
-	}
-	if child.Transfer.MediumConfidence != 0 {
-		result.Transfer.MediumConfidence = child.Transfer.MediumConfidence
-	}
-	if child.Transfer.DuplicateConfidenceThreshold != 0 {
-		result.Transfer.DuplicateConfidenceThreshold = child.Transfer.DuplicateConfidenceThreshold
-	}
-	if child.Transfer.RepoCollection != "" {
-		result.Transfer.RepoCollection = child.Transfer.RepoCollection
-	}
-	if child.Transfer.Strategy != "" {
-		result.Transfer.Strategy = child.Transfer.Strategy
-	}
-	if child.Transfer.VDBRouting.Enabled != nil {
-		result.Transfer.VDBRouting.Enabled = child.Transfer.VDBRouting.Enabled
-	}
-	if child.Transfer.VDBRouting.ConfidenceThreshold != 0 {
-		result.Transfer.VDBRouting.ConfidenceThreshold = child.Transfer.VDBRouting.ConfidenceThreshold
-	}
-	if child.Transfer.VDBRouting.MinSamplesPerRepo != 0 {
-		result.Transfer.VDBRouting.MinSamplesPerRepo = child.Transfer.VDBRouting.MinSamplesPerRepo
-	}
-	if child.Transfer.VDBRouting.MaxCandidates != 0 {
-		result.Transfer.VDBRouting.MaxCandidates = child.Transfer.VDBRouting.MaxCandidates
-	}
-	if child.Transfer.VDBRouting.ExplainDecision {
-		result.Transfer.VDBRouting.ExplainDecision = child.Transfer.VDBRouting.ExplainDecision
-	}
-
-	// AutoClose: override if fields are set.
-	// DryRun is always copied so a child config can explicitly set it to false.
-	if child.AutoClose.GracePeriodHours != 0 {
-		result.AutoClose.GracePeriodHours = child.AutoClose.GracePeriodHours
-	}
-	result.AutoClose.DryRun = child.AutoClose.DryRun
-
-	// ClaudeCode: override if fields are set.
-	if child.ClaudeCode.Enabled != nil {
-		result.ClaudeCode.Enabled = child.ClaudeCode.Enabled
+	// Owner suggestion defaults
+	if c.Owners.Enabled == nil {
+		f := false
+		c.Owners.Enabled = &f
 	}
-	if child.ClaudeCode.TriggerPhrase != "" {
-		result.ClaudeCode.TriggerPhrase = child.ClaudeCode.TriggerPhrase
+	if c.Owners.Mode == "" {
+		c.Owners.Mode = "suggest"
 	}
-	if child.ClaudeCode.IssueImplement.Enabled != nil {
-		result.ClaudeCode.IssueImplement.Enabled = child.ClaudeCode.IssueImplement.Enabled
+	if c.Owners.MaxSuggestions == 0 {
+		c.Owners.MaxSuggestions = 3
 	}
-	if child.ClaudeCode.IssueImplement.TriggerLabel != "" {
-		result.ClaudeCode.IssueImplement.TriggerLabel = child.ClaudeCode.IssueImplement.TriggerLabel
+	// Severity defaults
+	if c.Severity.Enabled == nil {
+		f := false
+		c.Severity.Enabled = &f
 	}
-	if child.ClaudeCode.IssueImplement.BaseBranch != "" {
-		result.ClaudeCode.IssueImplement.BaseBranch = child.ClaudeCode.IssueImplement.BaseBranch
+	if c.Severity.Labels == nil {
+		c.Severity.Labels = map[string]string{"P0": "priority/P0", "P1": "priority/P1", "P2": "priority/P2", "P3": "priority/P3"}
 	}
-	if child.ClaudeCode.DocSync.Enabled != nil {
-		result.ClaudeCode.DocSync.Enabled = child.ClaudeCode.DocSync.Enabled
+	if c.Severity.MinConfidence == 0 {
+		c.Severity.MinConfidence = 0.6
 	}
-	if len(child.ClaudeCode.DocSync.WatchPaths) > 0 {
-		result.ClaudeCode.DocSync.WatchPaths = child.ClaudeCode.DocSync.WatchPaths
+	// Needs-info defaults
+	if c.NeedsInfo.Enabled == nil {
+		f := false
+		c.NeedsInfo.Enabled = &f
 	}
-	if len(child.ClaudeCode.DocSync.DocPaths) > 0 {
-		result.ClaudeCode.DocSync.DocPaths = child.ClaudeCode.DocSync.DocPaths
+	if c.NeedsInfo.Threshold == 0 {
+		c.NeedsInfo.Threshold = 0.5
 	}
-	if child.ClaudeCode.SecurityReview.Enabled != nil {
-		result.ClaudeCode.SecurityReview.Enabled = child.ClaudeCode.SecurityReview.Enabled
+	if c.NeedsInfo.Label == "" {
+		c.NeedsInfo.Label = "needs-info"
 	}
-	if child.ClaudeCode.SecurityReview.TriggerLabel != "" {
-		result.ClaudeCode.SecurityReview.TriggerLabel = child.ClaudeCode.SecurityReview.TriggerLabel
+	if c.NeedsInfo.StaleDays == 0 {
+		c.NeedsInfo.StaleDays = 14
 	}
-	if child.ClaudeCode.ReviewChecklist.Enabled != nil {
-		result.ClaudeCode.ReviewChecklist.Enabled = child.ClaudeCode.ReviewChecklist.Enabled
+	if c.NeedsInfo.CloseMessage == "" {
+		c.NeedsInfo.CloseMessage = "This issue was closed because the information requested above was not provided. " +
+			"If you can share the missing details, please reopen the issue and add them."
 	}
-	if child.ClaudeCode.ReviewChecklist.TriggerLabel != "" {
-		result.ClaudeCode.ReviewChecklist.TriggerLabel = child.ClaudeCode.ReviewChecklist.TriggerLabel
+	// Template checking defaults
+	if c.Templates.Enabled == nil {
+		f := false
+		c.Templates.Enabled = &f
 	}
-	if len(child.ClaudeCode.ReviewChecklist.Items) > 0 {
-		result.ClaudeCode.ReviewChecklist.Items = child.ClaudeCode.ReviewChecklist.Items
+	// Fingerprint matching defaults
+	if c.Fingerprints.Enabled == nil {
+		f := false
+		c.Fingerprints.Enabled = &f
 	}
-	if child.ClaudeCode.Maintenance.Enabled != nil {
-		result.ClaudeCode.Maintenance.Enabled = child.ClaudeCode.Maintenance.Enabled
+	// Near-duplicate pre-check defaults
+	if c.NearDuplicate.Enabled == nil {
+		f := false
+		c.NearDuplicate.Enabled = &f
 	}
-	if len(child.ClaudeCode.Maintenance.Tasks) > 0 {
-		result.ClaudeCode.Maintenance.Tasks = child.ClaudeCode.Maintenance.Tasks
+	if c.NearDuplicate.MaxDistance <= 0 {
+		c.NearDuplicate.MaxDistance = 3
 	}
-
-	return &result
 }
 
 // ParseExtendsRef parses "org/repo@branch" into components.
@@ -1,7 +1,7 @@
 // Author: Kaviru Hapuarachchi
 // GitHub: https://github.com/Kavirubc
 // Created: 2026-02-02
-// Last Modified: 2026-03-06
+// Last Modified: 2026-10-18
 
 package config
 
@@ -93,6 +93,104 @@ func TestMergeConfigsLLM(t *testing.T) {
 	}
 }
 
+func TestMergeConfigsPrompts(t *testing.T) {
+	parent := &Config{Prompts: PromptsConfig{
+		Dir:       ".github/prompts",
+		Overrides: map[string]string{"triage": "org triage", "response": "org response"},
+	}}
+	child := &Config{Prompts: PromptsConfig{
+		Overrides: map[string]string{"triage": "repo triage"},
+	}}
+
+	merged := mergeConfigs(parent, child)
+	if merged.Prompts.Dir != ".github/prompts" {
+		t.Errorf("Expected inherited prompts dir, got %q", merged.Prompts.Dir)
+	}
+	if merged.Prompts.Overrides["triage"] != "repo triage" {
+		t.Errorf("Expected child triage override, got %q", merged.Prompts.Overrides["triage"])
+	}
+	if merged.Prompts.Overrides["response"] != "org response" {
+		t.Errorf("Expected inherited response override, got %q", merged.Prompts.Overrides["response"])
+	}
+	if parent.Prompts.Overrides["triage"] != "org triage" {
+		t.Error("mergeConfigs must not modify the parent overrides")
+	}
+}
+
+func TestMergeConfigsFallbacks(t *testing.T) {
+	parent := &Config{
+		LLM: LLMConfig{
+			Fallbacks:      []ProviderFallback{{Provider: "openai", Model: "gpt-4o-mini"}},
+			CircuitBreaker: CircuitBreakerConfig{FailureThreshold: 5, CooldownSeconds: 30},
+		},
+		Embedding: EmbeddingConfig{
+			Fallbacks: []ProviderFallback{{Provider: "openai", Model: "text-embedding-3-large"}},
+		},
+	}
+	child := &Config{LLM: LLMConfig{
+		CircuitBreaker: CircuitBreakerConfig{CooldownSeconds: 120},
+	}}
+
+	merged := mergeConfigs(parent, child)
+	if len(merged.LLM.Fallbacks) != 1 || merged.LLM.Fallbacks[0].Model != "gpt-4o-mini" {
+		t.Errorf("Expected inherited LLM fallbacks, got %+v", merged.LLM.Fallbacks)
+	}
+	if len(merged.Embedding.Fallbacks) != 1 {
+		t.Errorf("Expected inherited embedding fallbacks, got %+v", merged.Embedding.Fallbacks)
+	}
+	if merged.LLM.CircuitBreaker.FailureThreshold != 5 || merged.LLM.CircuitBreaker.CooldownSeconds != 120 {
+		t.Errorf("Unexpected circuit breaker merge: %+v", merged.LLM.CircuitBreaker)
+	}
+}
+
+func TestMergeConfigsRateLimits(t *testing.T) {
+	parent := &Config{RateLimits: map[string]RateLimitConfig{
+		"openai": {RequestsPerMinute: 500, TokensPerMinute: 200000},
+		"github": {RequestsPerMinute: 60},
+	}}
+	child := &Config{RateLimits: map[string]RateLimitConfig{
+		"github": {RequestsPerMinute: 30},
+	}}
+
+	merged := mergeConfigs(parent, child)
+	if merged.RateLimits["openai"].TokensPerMinute != 200000 {
+		t.Errorf("Expected inherited openai limit, got %+v", merged.RateLimits["openai"])
+	}
+	if merged.RateLimits["github"].RequestsPerMinute != 30 {
+		t.Errorf("Expected child github limit, got %+v", merged.RateLimits["github"])
+	}
+	if parent.RateLimits["github"].RequestsPerMinute != 60 {
+		t.Error("mergeConfigs must not modify the parent rate limits")
+	}
+}
+
+func TestRateLimitRegistry(t *testing.T) {
+	cfg := &Config{RateLimits: map[string]RateLimitConfig{
+		"openai": {RequestsPerMinute: 500},
+	}}
+	limits := cfg.RateLimitRegistry()
+	if limits.Get("openai") == nil {
+		t.Error("Expected a limiter for the configured provider")
+	}
+	if limits.Get("gemini") != nil {
+		t.Error("Expected no limiter for an unconfigured provider")
+	}
+}
+
+func TestMergeConfigsFeedback(t *testing.T) {
+	enabled := true
+	parent := &Config{Feedback: FeedbackConfig{Enabled: &enabled, StateRepo: "org/state"}}
+	child := &Config{Feedback: FeedbackConfig{Branch: "bot-state"}}
+
+	merged := mergeConfigs(parent, child)
+	if merged.Feedback.Enabled == nil || !*merged.Feedback.Enabled {
+		t.Error("Expected feedback to stay enabled")
+	}
+	if merged.Feedback.StateRepo != "org/state" || merged.Feedback.Branch != "bot-state" {
+		t.Errorf("Unexpected merged feedback config: %+v", merged.Feedback)
+	}
+}
+
 func TestLoadConfigWithLLM(t *testing.T) {
 	yamlContent := `
 qdrant:
@@ -387,3 +485,102 @@ embedding:
 		t.Fatalf("Expected error %q, got %q", wantErr, err.Error())
 	}
 }
+
+func TestForRepository(t *testing.T) {
+	data := []byte(`
+qdrant:
+  url: http://localhost:6334
+  api_key: key
+  collection: issues
+embedding:
+  api_key: key
+defaults:
+  similarity_threshold: 0.65
+  max_similar_to_show: 5
+transfer:
+  duplicate_confidence_threshold: 0.8
+  medium_confidence: 0.6
+  vdb_routing:
+    explain_decision: true
+auto_close:
+  grace_period_hours: 72
+  dry_run: true
+labels:
+  deny: [wontfix]
+steps: [gatekeeper, similarity_search, duplicate_detector]
+repositories:
+  - org: acme
+    repo: monorepo
+    enabled: true
+    workflow: similarity-only
+    defaults:
+      similarity_threshold: 0.8
+    transfer:
+      duplicate_confidence_threshold: 0.95
+    auto_close:
+      grace_period_hours: 24
+    label_policy:
+      allow: ["area/*"]
+  - org: acme
+    repo: docs
+    enabled: true
+  - org: acme
+    repo: live
+    enabled: true
+    auto_close:
+      dry_run: false
+    transfer:
+      vdb_routing:
+        explain_decision: false
+`)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "simili.yaml")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	mono := cfg.ForRepository("acme", "monorepo")
+	if mono.Defaults.SimilarityThreshold != 0.8 || mono.Defaults.MaxSimilarToShow != 5 {
+		t.Errorf("Unexpected monorepo defaults: %+v", mono.Defaults)
+	}
+	if mono.Transfer.DuplicateConfidenceThreshold != 0.95 || mono.Transfer.MediumConfidence != 0.6 {
+		t.Errorf("Unexpected monorepo transfer config: %+v", mono.Transfer)
+	}
+	if mono.AutoClose.GracePeriodHours != 24 || !mono.AutoClose.DryRun {
+		t.Errorf("Unexpected monorepo auto_close config: %+v", mono.AutoClose)
+	}
+	if len(mono.Labels.Allow) != 1 || len(mono.Labels.Deny) != 1 {
+		t.Errorf("Unexpected monorepo label policy: %+v", mono.Labels)
+	}
+	if mono.Workflow != "similarity-only" {
+		t.Errorf("Expected monorepo workflow override, got %q", mono.Workflow)
+	}
+	if cfg.Defaults.SimilarityThreshold != 0.65 || cfg.Transfer.DuplicateConfidenceThreshold != 0.8 {
+		t.Error("ForRepository must not modify the top-level config")
+	}
+
+	if docs := cfg.ForRepository("acme", "docs"); docs.Defaults.SimilarityThreshold != 0.65 || docs.AutoClose.GracePeriodHours != 72 {
+		t.Errorf("Expected docs repo to inherit top-level settings, got %+v / %+v", docs.Defaults, docs.AutoClose)
+	}
+	// Explicit false overrides a top-level true.
+	live := cfg.ForRepository("acme", "live")
+	if live.AutoClose.DryRun || live.AutoClose.GracePeriodHours != 72 {
+		t.Errorf("Expected live repo to turn dry_run off and keep the grace period, got %+v", live.AutoClose)
+	}
+	if live.Transfer.VDBRouting.ExplainDecision || live.Transfer.DuplicateConfidenceThreshold != 0.8 {
+		t.Errorf("Expected live repo to turn explain_decision off, got %+v", live.Transfer)
+	}
+	if !cfg.AutoClose.DryRun || !cfg.Transfer.VDBRouting.ExplainDecision {
+		t.Error("ForRepository must not modify the top-level config")
+	}
+	if other := cfg.ForRepository("acme", "unknown"); other != cfg {
+		t.Error("Expected unconfigured repository to get the top-level config")
+	}
+	if cfg.FindRepository("acme", "docs").HasStepOverride() {
+		t.Error("docs repo has no step override")
+	}
+}
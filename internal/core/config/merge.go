@@ -0,0 +1,402 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// maxExtendsDepth bounds the extends chain in case a fetcher keeps
+// returning new references.
+const maxExtendsDepth = 10
+
+// List merge strategies accepted in the `merge` section of a config.
+const (
+	mergeReplace = "replace"      // Child list replaces the parent list (default)
+	mergeAppend  = "append"       // Child items are appended to the parent list
+	mergeByKey   = "merge-by-key" // Items with the same key are deep-merged, others appended
+)
+
+// defaultMergeKeys are the identifying fields used by merge-by-key for
+// lists whose strategy does not name a key explicitly.
+var defaultMergeKeys = map[string][]string{
+	"repositories":        {"org", "repo"},
+	"transfer.rules":      {"name"},
+	"classifiers":         {"name"},
+	"labels.groups":       {"name"},
+	"owners.areas":        {"area"},
+	"llm.fallbacks":       {"provider", "model"},
+	"embedding.fallbacks": {"provider", "model"},
+}
+
+// listStrategy is a parsed entry of the `merge` section.
+type listStrategy struct {
+	mode string
+	keys []string
+}
+
+// parseListStrategies parses a `merge` section: dotted list paths mapped to
+// "replace", "append", "merge-by-key" or "merge-by-key:field[,field]".
+// Without key fields, merge-by-key uses defaultMergeKeys; lists of scalars
+// need no key and are merged as an ordered union.
+func parseListStrategies(raw map[string]string) (map[string]listStrategy, error) {
+	strategies := make(map[string]listStrategy, len(raw))
+	for path, value := range raw {
+		mode, keyList, hasKeys := strings.Cut(strings.TrimSpace(value), ":")
+		s := listStrategy{mode: mode}
+		switch mode {
+		case mergeReplace, mergeAppend:
+			if hasKeys {
+				return nil, fmt.Errorf("merge strategy %q for %s does not take keys", value, path)
+			}
+		case mergeByKey:
+			if hasKeys {
+				for _, k := range strings.Split(keyList, ",") {
+					if k = strings.TrimSpace(k); k != "" {
+						s.keys = append(s.keys, k)
+					}
+				}
+			} else {
+				s.keys = defaultMergeKeys[path]
+			}
+		default:
+			return nil, fmt.Errorf("unknown merge strategy %q for %s (use replace, append or merge-by-key)", value, path)
+		}
+		strategies[path] = s
+	}
+	return strategies, nil
+}
+
+// configLayer is one file of an extends chain.
+type configLayer struct {
+	node       *yaml.Node
+	extends    string
+	strategies map[string]listStrategy
+}
+
+// parseLayer parses a config file into a YAML node with its references
+// resolved by resolve, reading the extends reference and list strategies it
+// declares.
+func parseLayer(data []byte, resolve referenceResolver) (*configLayer, error) {
+	doc, err := parseNode(data, resolve)
+	if err != nil {
+		return nil, err
+	}
+	layer := &configLayer{node: doc}
+	if len(doc.Content) == 0 {
+		return layer, nil
+	}
+
+	var header struct {
+		Extends string            `yaml:"extends"`
+		Merge   map[string]string `yaml:"merge"`
+	}
+	if err := doc.Decode(&header); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+	strategies, err := parseListStrategies(header.Merge)
+	if err != nil {
+		return nil, err
+	}
+	layer.extends = strings.TrimSpace(header.Extends)
+	layer.strategies = strategies
+	return layer, nil
+}
+
+// resolveChain follows the extends references of a config file and returns
+// the merged YAML node. Each file's list strategies apply when it is merged
+// onto its parent. Fetched parents may only use env references.
+func resolveChain(data []byte, fetcher func(ref string) ([]byte, error)) (*yaml.Node, error) {
+	layer, err := parseLayer(data, resolveReference)
+	if err != nil {
+		return nil, err
+	}
+	chain := []*configLayer{layer}
+	seen := make(map[string]bool)
+
+	for ref := layer.extends; ref != ""; ref = chain[len(chain)-1].extends {
+		org, repo, branch, path, err := ParseExtendsRef(ref)
+		if err != nil {
+			return nil, err
+		}
+		key := fmt.Sprintf("%s/%s@%s:%s", org, repo, branch, path)
+		if seen[key] {
+			return nil, fmt.Errorf("extends cycle detected at '%s'", ref)
+		}
+		seen[key] = true
+		if len(chain) > maxExtendsDepth {
+			return nil, fmt.Errorf("extends chain is deeper than %d levels", maxExtendsDepth)
+		}
+		if fetcher == nil {
+			return nil, fmt.Errorf("cannot fetch parent config '%s': no fetcher configured", ref)
+		}
+
+		parentData, err := fetcher(ref)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch parent config '%s': %w", ref, err)
+		}
+		parent, err := parseLayer(parentData, resolveEnvReference)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse parent config '%s': %w", ref, err)
+		}
+		if err := checkParentSteps(parent.node); err != nil {
+			return nil, fmt.Errorf("invalid parent config '%s': %w", ref, err)
+		}
+		chain = append(chain, parent)
+	}
+
+	// Merge from the root ancestor down to the local file.
+	merged := documentRoot(chain[len(chain)-1].node)
+	for i := len(chain) - 2; i >= 0; i-- {
+		merged = mergeNodes(merged, documentRoot(chain[i].node), "", chain[i].strategies)
+	}
+	return merged, nil
+}
+
+// checkParentSteps rejects "exec:" plugin steps in a config fetched through
+// extends. Like ${cmd:...} references, they would run commands chosen by
+// another repository on the machine that loads the config.
+func checkParentSteps(doc *yaml.Node) error {
+	root := documentRoot(doc)
+	lists := []*yaml.Node{mappingValue(root, "steps")}
+	if repos := mappingValue(root, "repositories"); repos != nil && repos.Kind == yaml.SequenceNode {
+		for _, repo := range repos.Content {
+			lists = append(lists, mappingValue(repo, "steps"))
+		}
+	}
+	for _, list := range lists {
+		if list == nil || list.Kind != yaml.SequenceNode {
+			continue
+		}
+		for _, item := range list.Content {
+			if strings.HasPrefix(strings.TrimSpace(item.Value), "exec:") {
+				return fmt.Errorf("step %q is not allowed in extended configs", item.Value)
+			}
+		}
+	}
+	return nil
+}
+
+// mappingValue returns the value of key in a mapping node, or nil.
+func mappingValue(n *yaml.Node, key string) *yaml.Node {
+	if n == nil || n.Kind != yaml.MappingNode {
+		return nil
+	}
+	if idx := mappingIndex(n, key); idx >= 0 {
+		return n.Content[idx+1]
+	}
+	return nil
+}
+
+// documentRoot unwraps a document node to its top-level value.
+func documentRoot(n *yaml.Node) *yaml.Node {
+	if n != nil && n.Kind == yaml.DocumentNode {
+		if len(n.Content) == 0 {
+			return nil
+		}
+		return n.Content[0]
+	}
+	return n
+}
+
+// isNull reports whether a node is absent or an explicit YAML null.
+func isNull(n *yaml.Node) bool {
+	return n == nil || (n.Kind == yaml.ScalarNode && n.Tag == "!!null")
+}
+
+// mergeNodes deep-merges child onto parent. Mappings are merged key by key,
+// lists follow the strategy for their path, and any other child value
+// replaces the parent's — including explicit false and zero values. A null
+// child keeps the parent value. Neither input is modified.
+func mergeNodes(parent, child *yaml.Node, path string, strategies map[string]listStrategy) *yaml.Node {
+	if isNull(child) {
+		return parent
+	}
+	if isNull(parent) {
+		return child
+	}
+	if parent.Kind == yaml.AliasNode {
+		parent = parent.Alias
+	}
+	if child.Kind == yaml.AliasNode {
+		child = child.Alias
+	}
+
+	switch {
+	case parent.Kind == yaml.MappingNode && child.Kind == yaml.MappingNode:
+		out := *parent
+		out.Content = append([]*yaml.Node(nil), parent.Content...)
+		for i := 0; i+1 < len(child.Content); i += 2 {
+			key, value := child.Content[i], child.Content[i+1]
+			childPath := joinPath(path, key.Value)
+			if idx := mappingIndex(&out, key.Value); idx >= 0 {
+				out.Content[idx+1] = mergeNodes(out.Content[idx+1], value, childPath, strategies)
+			} else {
+				out.Content = append(out.Content, key, value)
+			}
+		}
+		return &out
+
+	case parent.Kind == yaml.SequenceNode && child.Kind == yaml.SequenceNode:
+		s := strategies[path]
+		switch s.mode {
+		case mergeAppend:
+			out := *child
+			out.Content = append(append([]*yaml.Node(nil), parent.Content...), child.Content...)
+			return &out
+		case mergeByKey:
+			return mergeSequenceByKey(parent, child, path, s.keys, strategies)
+		}
+		return child
+	}
+	return child
+}
+
+// mergeSequenceByKey deep-merges child items into parent items with the same
+// key and appends the rest. Scalar lists are merged as an ordered union.
+func mergeSequenceByKey(parent, child *yaml.Node, path string, keys []string, strategies map[string]listStrategy) *yaml.Node {
+	out := *child
+	out.Content = append([]*yaml.Node(nil), parent.Content...)
+	for _, item := range child.Content {
+		id, ok := itemKey(item, keys)
+		match := -1
+		if ok {
+			for i, existing := range out.Content {
+				if existingID, ok := itemKey(existing, keys); ok && existingID == id {
+					match = i
+					break
+				}
+			}
+		}
+		if match >= 0 {
+			out.Content[match] = mergeNodes(out.Content[match], item, path, strategies)
+		} else {
+			out.Content = append(out.Content, item)
+		}
+	}
+	return &out
+}
+
+// itemKey identifies a list item for merge-by-key: the scalar value itself,
+// or the values of the key fields of a mapping.
+func itemKey(n *yaml.Node, keys []string) (string, bool) {
+	if n.Kind == yaml.AliasNode {
+		n = n.Alias
+	}
+	if n.Kind == yaml.ScalarNode {
+		return n.Value, true
+	}
+	if n.Kind != yaml.MappingNode || len(keys) == 0 {
+		return "", false
+	}
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		idx := mappingIndex(n, k)
+		if idx < 0 {
+			return "", false
+		}
+		parts[i] = n.Content[idx+1].Value
+	}
+	return strings.Join(parts, "\x00"), true
+}
+
+// mappingIndex returns the index of key in a mapping node's content, or -1.
+func mappingIndex(n *yaml.Node, key string) int {
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// applyOverride overlays a repository override section (a pointer, possibly
+// nil) onto dst. The keys written in its YAML node are applied as they are;
+// without a node, as for entries built in code, only non-zero fields apply.
+func applyOverride[T any](dst *T, override *T, node *yaml.Node) {
+	if override == nil {
+		return
+	}
+	if node == nil {
+		mergeValue(reflect.ValueOf(dst).Elem(), reflect.ValueOf(override).Elem())
+		return
+	}
+	overlayNode(reflect.ValueOf(dst).Elem(), reflect.ValueOf(override).Elem(), node)
+}
+
+// overlayNode copies the fields of src named in node, which src was decoded
+// from, onto dst. Nested mappings are applied key by key; null values keep
+// the dst value.
+func overlayNode(dst, src reflect.Value, node *yaml.Node) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if node.Kind != yaml.MappingNode || dst.Kind() != reflect.Struct {
+		dst.Set(src)
+		return
+	}
+	_, fields := yamlFields(dst.Type())
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		field, ok := fields[node.Content[i].Value]
+		if !ok || isNull(node.Content[i+1]) {
+			continue
+		}
+		overlayNode(dst.FieldByIndex(field.Index), src.FieldByIndex(field.Index), node.Content[i+1])
+	}
+}
+
+// mergeConfigs merges a child config onto a parent config.
+// Non-zero child fields override the parent, maps are merged by key and
+// non-empty slices replace the parent's. Zero values (including false) never
+// override; use the YAML-level merge of LoadWithInheritance for that.
+func mergeConfigs(parent, child *Config) *Config {
+	result := *parent
+	mergeValue(reflect.ValueOf(&result).Elem(), reflect.ValueOf(child).Elem())
+	return &result
+}
+
+// mergeValue overlays the non-zero parts of src onto dst.
+func mergeValue(dst, src reflect.Value) {
+	switch src.Kind() {
+	case reflect.Struct:
+		for i := 0; i < src.NumField(); i++ {
+			if dst.Field(i).CanSet() {
+				mergeValue(dst.Field(i), src.Field(i))
+			}
+		}
+	case reflect.Map:
+		if src.Len() == 0 {
+			return
+		}
+		merged := reflect.MakeMapWithSize(src.Type(), dst.Len()+src.Len())
+		for _, m := range []reflect.Value{dst, src} {
+			iter := m.MapRange()
+			for iter.Next() {
+				merged.SetMapIndex(iter.Key(), iter.Value())
+			}
+		}
+		dst.Set(merged)
+	case reflect.Slice:
+		if src.Len() > 0 {
+			dst.Set(src)
+		}
+	default:
+		if !src.IsZero() {
+			dst.Set(src)
+		}
+	}
+}
@@ -0,0 +1,259 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// writeChild writes a local config file and returns its path.
+func writeChild(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "simili.yaml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	return path
+}
+
+// mapFetcher serves remote configs from a map keyed by extends reference.
+func mapFetcher(configs map[string]string) func(ref string) ([]byte, error) {
+	return func(ref string) ([]byte, error) {
+		content, ok := configs[ref]
+		if !ok {
+			return nil, fmt.Errorf("unexpected ref: %s", ref)
+		}
+		return []byte(content), nil
+	}
+}
+
+func TestResolveMultiLevelExtends(t *testing.T) {
+	remotes := map[string]string{
+		"org/base@main": `
+qdrant:
+  url: https://qdrant.example
+  api_key: key
+  collection: base
+embedding:
+  api_key: key
+  dimensions: 768
+bot_users: [renovate]
+auto_close:
+  grace_period_hours: 48
+  dry_run: true
+defaults:
+  similarity_threshold: 0.6
+`,
+		"org/team@main": `
+extends: org/base@main
+qdrant:
+  collection: team
+defaults:
+  max_similar_to_show: 3
+`,
+	}
+	path := writeChild(t, `
+extends: org/team@main
+auto_close:
+  dry_run: false
+`)
+
+	cfg, err := LoadWithInheritance(path, mapFetcher(remotes))
+	if err != nil {
+		t.Fatalf("LoadWithInheritance() error = %v", err)
+	}
+	if cfg.Qdrant.Collection != "team" || cfg.Qdrant.URL != "https://qdrant.example" {
+		t.Errorf("Unexpected qdrant config: %+v", cfg.Qdrant)
+	}
+	if cfg.Embedding.Dimensions != 768 {
+		t.Errorf("Embedding.Dimensions = %d, want 768 from the base config", cfg.Embedding.Dimensions)
+	}
+	if !slices.Equal(cfg.BotUsers, []string{"renovate"}) {
+		t.Errorf("BotUsers = %v, want [renovate]", cfg.BotUsers)
+	}
+	if cfg.AutoClose.DryRun {
+		t.Error("Expected the child's explicit dry_run: false to override the base config")
+	}
+	if cfg.AutoClose.GracePeriodHours != 48 {
+		t.Errorf("GracePeriodHours = %d, want 48", cfg.AutoClose.GracePeriodHours)
+	}
+	if cfg.Defaults.SimilarityThreshold != 0.6 || cfg.Defaults.MaxSimilarToShow != 3 {
+		t.Errorf("Unexpected defaults: %+v", cfg.Defaults)
+	}
+	if cfg.Extends != "" {
+		t.Errorf("Expected resolved config to have no extends, got %q", cfg.Extends)
+	}
+}
+
+func TestResolveListStrategies(t *testing.T) {
+	remotes := map[string]string{
+		"org/base@main": `
+bot_users: [renovate]
+steps: [gatekeeper, similarity_search]
+repositories:
+  - org: acme
+    repo: app
+    enabled: true
+    description: App
+  - org: acme
+    repo: docs
+    enabled: true
+transfer:
+  rules:
+    - name: docs
+      target: acme/docs
+      labels: [documentation]
+`,
+	}
+	path := writeChild(t, `
+extends: org/base@main
+merge:
+  bot_users: append
+  repositories: merge-by-key
+  transfer.rules: merge-by-key
+bot_users: [dependabot]
+steps: [gatekeeper]
+repositories:
+  - org: acme
+    repo: app
+    enabled: false
+  - org: acme
+    repo: infra
+    enabled: true
+transfer:
+  rules:
+    - name: docs
+      priority: 5
+`)
+
+	cfg, err := Resolve(path, mapFetcher(remotes))
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if !slices.Equal(cfg.BotUsers, []string{"renovate", "dependabot"}) {
+		t.Errorf("BotUsers = %v, want appended list", cfg.BotUsers)
+	}
+	if !slices.Equal(cfg.Steps, []string{"gatekeeper"}) {
+		t.Errorf("Steps = %v, want the child list to replace the parent", cfg.Steps)
+	}
+	if len(cfg.Repositories) != 3 {
+		t.Fatalf("Repositories = %+v, want 3 entries", cfg.Repositories)
+	}
+	app := cfg.FindRepository("acme", "app")
+	if app == nil || app.Enabled || app.Description != "App" {
+		t.Errorf("Expected app entry to be deep-merged, got %+v", app)
+	}
+	if cfg.FindRepository("acme", "infra") == nil {
+		t.Error("Expected new repository entry to be appended")
+	}
+	if len(cfg.Transfer.Rules) != 1 {
+		t.Fatalf("Transfer.Rules = %+v, want 1 merged rule", cfg.Transfer.Rules)
+	}
+	rule := cfg.Transfer.Rules[0]
+	if rule.Priority != 5 || rule.Target != "acme/docs" || !slices.Equal(rule.Labels, []string{"documentation"}) {
+		t.Errorf("Unexpected merged rule: %+v", rule)
+	}
+}
+
+func TestResolveExtendsCycle(t *testing.T) {
+	remotes := map[string]string{
+		"org/a@main": "extends: org/b@main\n",
+		"org/b@main": "extends: org/a@main\n",
+	}
+	path := writeChild(t, "extends: org/a@main\n")
+
+	_, err := Resolve(path, mapFetcher(remotes))
+	if err == nil || !strings.Contains(err.Error(), "extends cycle detected at 'org/a@main'") {
+		t.Fatalf("Expected cycle error, got %v", err)
+	}
+}
+
+func TestResolveExtendsOnlyExpandsEnv(t *testing.T) {
+	secretPath := filepath.Join(t.TempDir(), "runner_secret")
+	if err := os.WriteFile(secretPath, []byte("runner-secret-value\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("SIMILI_TEST_COLLECTION", "shared")
+	t.Setenv(AllowCmdEnv, "true")
+
+	// The local file may read files; a fetched parent may only read env.
+	path := writeChild(t, "extends: org/base@main\nqdrant:\n  api_key: ${file:"+secretPath+"}\n")
+	cfg, err := Resolve(path, mapFetcher(map[string]string{
+		"org/base@main": "qdrant:\n  collection: ${SIMILI_TEST_COLLECTION}\n",
+	}))
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if cfg.Qdrant.APIKey != "runner-secret-value" || cfg.Qdrant.Collection != "shared" {
+		t.Errorf("Unexpected qdrant config: %+v", cfg.Qdrant)
+	}
+
+	for _, ref := range []string{"${file:" + secretPath + "}", "${cmd:echo leaked}"} {
+		remotes := map[string]string{"org/base@main": "qdrant:\n  url: " + ref + "\n"}
+		_, err := Resolve(writeChild(t, "extends: org/base@main\n"), mapFetcher(remotes))
+		if err == nil || !strings.Contains(err.Error(), "not allowed in extended configs") {
+			t.Errorf("%s in parent: expected rejection, got %v", ref, err)
+		}
+	}
+}
+
+func TestResolveExtendsRejectsExecSteps(t *testing.T) {
+	for _, parent := range []string{
+		"steps:\n  - gatekeeper\n  - exec:./scripts/check\n",
+		"repositories:\n  - org: o\n    repo: r\n    steps: [\"exec:curl example.com\"]\n",
+	} {
+		remotes := map[string]string{"org/base@main": parent}
+		_, err := Resolve(writeChild(t, "extends: org/base@main\n"), mapFetcher(remotes))
+		if err == nil || !strings.Contains(err.Error(), "not allowed in extended configs") {
+			t.Errorf("%q: expected rejection, got %v", parent, err)
+		}
+	}
+
+	// The local file may declare plugin steps.
+	cfg, err := Resolve(writeChild(t, "extends: org/base@main\nsteps:\n  - exec:./scripts/check\n"), mapFetcher(map[string]string{
+		"org/base@main": "steps:\n  - gatekeeper\n",
+	}))
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if len(cfg.Steps) != 1 || cfg.Steps[0] != "exec:./scripts/check" {
+		t.Errorf("Steps = %v", cfg.Steps)
+	}
+}
+
+func TestResolveRejectsUnknownStrategy(t *testing.T) {
+	path := writeChild(t, "merge:\n  bot_users: prepend\n")
+
+	_, err := Resolve(path, nil)
+	if err == nil || !strings.Contains(err.Error(), `unknown merge strategy "prepend" for bot_users`) {
+		t.Fatalf("Expected strategy error, got %v", err)
+	}
+}
+
+func TestMergeConfigsCoversAllFields(t *testing.T) {
+	parent := &Config{
+		Embedding: EmbeddingConfig{Dimensions: 768},
+		BotUsers:  []string{"renovate"},
+		AutoClose: AutoCloseConfig{DryRun: true},
+	}
+	child := &Config{Embedding: EmbeddingConfig{Model: "text-embedding-3-small", Dimensions: 1536}}
+
+	merged := mergeConfigs(parent, child)
+	if merged.Embedding.Dimensions != 1536 || merged.Embedding.Model != "text-embedding-3-small" {
+		t.Errorf("Unexpected merged embedding config: %+v", merged.Embedding)
+	}
+	if !slices.Equal(merged.BotUsers, []string{"renovate"}) {
+		t.Errorf("BotUsers = %v, want inherited list", merged.BotUsers)
+	}
+	if !merged.AutoClose.DryRun {
+		t.Error("Expected a zero-valued child to keep the parent's dry_run")
+	}
+}
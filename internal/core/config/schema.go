@@ -0,0 +1,148 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// fieldRule constrains the value of a config field. Rules are keyed by
+// "<StructName>.<yaml key>" so they apply wherever the struct is used
+// (e.g. both top-level and per-repository defaults); a "[]" suffix applies
+// the rule to the elements of a list or map.
+type fieldRule struct {
+	Min  *float64
+	Max  *float64
+	Enum []string
+}
+
+func bounds(min, max float64) fieldRule { return fieldRule{Min: &min, Max: &max} }
+func atLeast(min float64) fieldRule     { return fieldRule{Min: &min} }
+func oneOf(values ...string) fieldRule  { return fieldRule{Enum: values} }
+
+var fieldRules = map[string]fieldRule{
+	"DefaultsConfig.similarity_threshold":           bounds(0, 1),
+	"DefaultsConfig.max_similar_to_show":            atLeast(0),
+	"DefaultsConfig.duplicate_candidates":           atLeast(0),
+	"DefaultsConfig.edit_semantic_delta":            bounds(0, 2),
+	"TransferConfig.high_confidence":                bounds(0, 1),
+	"TransferConfig.medium_confidence":              bounds(0, 1),
+	"TransferConfig.duplicate_confidence_threshold": bounds(0, 1),
+	"TransferConfig.strategy":                       oneOf("rules-only", "vdb-only", "hybrid"),
+	"TransferRule.priority":                         atLeast(0),
+	"VDBRoutingConfig.confidence_threshold":         bounds(0, 1),
+	"VDBRoutingConfig.min_samples_per_repo":         atLeast(0),
+	"VDBRoutingConfig.max_candidates":               atLeast(0),
+	"AutoCloseConfig.grace_period_hours":            atLeast(0),
+	"EmbeddingConfig.provider":                      oneOf("gemini", "openai"),
+	"EmbeddingConfig.dimensions":                    atLeast(0),
+	"LLMConfig.provider":                            oneOf("gemini", "openai"),
+	"LLMConfig.temperature":                         bounds(0, 2),
+	"LLMConfig.max_tokens_per_run":                  atLeast(0),
+	"LLMConfig.max_tokens_per_day":                  atLeast(0),
+	"ProviderFallback.provider":                     oneOf("gemini", "openai"),
+	"ProviderFallback.dimensions":                   atLeast(0),
+	"CircuitBreakerConfig.failure_threshold":        atLeast(0),
+	"CircuitBreakerConfig.cooldown_seconds":         atLeast(0),
+	"ModelPricing.input_per_million":                atLeast(0),
+	"ModelPricing.output_per_million":               atLeast(0),
+	"RateLimitConfig.requests_per_minute":           atLeast(0),
+	"RateLimitConfig.tokens_per_minute":             atLeast(0),
+	"PluginsConfig.timeout_seconds":                 atLeast(0),
+	"ClassifierConfig.temperature":                  bounds(0, 2),
+	"ClassifierConfig.output[]":                     oneOf("string", "number", "boolean", "array"),
+	"LabelGroup.min_confidence":                     bounds(0, 1),
+	"CommentsConfig.update":                         oneOf("edit", "minimize", "new"),
+	"CommentsConfig.changelog_entries":              atLeast(0),
+	"OwnersConfig.mode":                             oneOf("suggest", "assign"),
+	"OwnersConfig.max_suggestions":                  atLeast(0),
+	"SeverityConfig.min_confidence":                 bounds(0, 1),
+	"NeedsInfoConfig.threshold":                     bounds(0, 1),
+	"NeedsInfoConfig.stale_days":                    atLeast(0),
+	"NearDuplicateConfig.max_distance":              bounds(1, 16),
+}
+
+// yamlFields returns the yaml-visible fields of a struct type by key.
+func yamlFields(t reflect.Type) ([]string, map[string]reflect.StructField) {
+	var keys []string
+	fields := make(map[string]reflect.StructField)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		keys = append(keys, name)
+		fields[name] = f
+	}
+	return keys, fields
+}
+
+// JSONSchema returns a JSON Schema (draft 2020-12) describing simili.yaml,
+// generated from the Config structs. Editors use it for completion and
+// inline validation.
+func JSONSchema() ([]byte, error) {
+	schema := schemaFor(reflect.TypeOf(Config{}), "")
+	schema["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	schema["title"] = "Simili Bot configuration"
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+// schemaFor builds the schema of a type. rule is the fieldRule key of the
+// field holding the value, if any.
+func schemaFor(t reflect.Type, rule string) map[string]interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	s := map[string]interface{}{}
+	switch t.Kind() {
+	case reflect.Struct:
+		keys, fields := yamlFields(t)
+		props := make(map[string]interface{}, len(keys))
+		for _, key := range keys {
+			props[key] = schemaFor(fields[key].Type, t.Name()+"."+key)
+		}
+		s["type"] = "object"
+		s["properties"] = props
+		s["additionalProperties"] = false
+	case reflect.Map:
+		s["type"] = "object"
+		s["additionalProperties"] = schemaFor(t.Elem(), rule+"[]")
+	case reflect.Slice:
+		s["type"] = "array"
+		s["items"] = schemaFor(t.Elem(), rule+"[]")
+	case reflect.String:
+		s["type"] = "string"
+	case reflect.Bool:
+		s["type"] = "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		s["type"] = "integer"
+	case reflect.Float32, reflect.Float64:
+		s["type"] = "number"
+	}
+
+	if r, ok := fieldRules[rule]; ok {
+		if r.Min != nil {
+			s["minimum"] = *r.Min
+		}
+		if r.Max != nil {
+			s["maximum"] = *r.Max
+		}
+		if len(r.Enum) > 0 {
+			s["enum"] = r.Enum
+		}
+	}
+	return s
+}
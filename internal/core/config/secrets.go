@@ -0,0 +1,250 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// AllowCmdEnv must be set to "true" for ${cmd:...} references to run.
+// Commands are never run otherwise, so a shared config cannot execute
+// anything on a machine that did not opt in.
+const AllowCmdEnv = "SIMILI_ALLOW_CMD_REFS"
+
+// cmdTimeout bounds a ${cmd:...} reference.
+const cmdTimeout = 10 * time.Second
+
+// minSecretLength keeps short values like "true" from being masked everywhere.
+const minSecretLength = 6
+
+var (
+	envNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	schemePattern  = regexp.MustCompile(`^([a-z]+):(.*)$`)
+)
+
+// secretKeys are the config keys whose values are always treated as secret.
+// Names are matched exactly, so e.g. max_tokens_per_run is not masked.
+var secretKeys = map[string]bool{
+	"api_key":  true,
+	"token":    true,
+	"secret":   true,
+	"password": true,
+}
+
+// referenceResolver resolves the body of a ${scheme:arg} reference.
+type referenceResolver func(scheme, arg string) (string, error)
+
+// resolveReference is the resolver used when loading configs:
+//
+//	${NAME} or ${env:NAME}  environment variable (empty if unset)
+//	${file:/run/secrets/x}  file contents, without the trailing newline
+//	${cmd:pass show x}      command output; only with SIMILI_ALLOW_CMD_REFS=true
+func resolveReference(scheme, arg string) (string, error) {
+	switch scheme {
+	case "env":
+		return os.Getenv(arg), nil
+	case "file":
+		data, err := os.ReadFile(arg)
+		if err != nil {
+			return "", fmt.Errorf("failed to read secret file: %w", err)
+		}
+		return strings.TrimRight(string(data), "\r\n"), nil
+	case "cmd":
+		if os.Getenv(AllowCmdEnv) != "true" {
+			return "", fmt.Errorf("${cmd:...} references are disabled (set %s=true to allow them)", AllowCmdEnv)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), cmdTimeout)
+		defer cancel()
+		var stderr bytes.Buffer
+		cmd := exec.CommandContext(ctx, "sh", "-c", arg)
+		cmd.Stderr = &stderr
+		out, err := cmd.Output()
+		if err != nil {
+			return "", fmt.Errorf("secret command failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+		}
+		return strings.TrimRight(string(out), "\r\n"), nil
+	}
+	return "", fmt.Errorf("unknown reference type %q (use env, file or cmd)", scheme)
+}
+
+// resolveEnvReference resolves only environment references. It is used for
+// parent configs fetched through extends, so a shared config cannot read
+// files or run commands on the machine that loads it.
+func resolveEnvReference(scheme, arg string) (string, error) {
+	if scheme != "env" {
+		return "", fmt.Errorf("${%s:...} references are not allowed in extended configs (only env)", scheme)
+	}
+	return resolveReference(scheme, arg)
+}
+
+// expandString replaces ${...} references in s. "$${" is an escaped,
+// literal "${"; any other "$" is kept as is. A "${...}" that is neither a
+// variable name nor scheme:arg is left untouched. fromSecret reports whether
+// a file or cmd reference was used.
+func expandString(s string, resolve referenceResolver) (out string, fromSecret bool, err error) {
+	if !strings.Contains(s, "${") {
+		return s, false, nil
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		if strings.HasPrefix(s[i:], "$${") {
+			b.WriteString("${")
+			i += 3
+			continue
+		}
+		if !strings.HasPrefix(s[i:], "${") {
+			b.WriteByte(s[i])
+			i++
+			continue
+		}
+		end := strings.IndexByte(s[i+2:], '}')
+		if end < 0 {
+			b.WriteString(s[i:])
+			break
+		}
+		body := s[i+2 : i+2+end]
+		ref := s[i : i+2+end+1]
+		i += len(ref)
+
+		scheme, arg := "", ""
+		if envNamePattern.MatchString(body) {
+			scheme, arg = "env", body
+		} else if m := schemePattern.FindStringSubmatch(body); m != nil {
+			scheme, arg = m[1], strings.TrimSpace(m[2])
+		} else {
+			b.WriteString(ref)
+			continue
+		}
+		value, err := resolve(scheme, arg)
+		if err != nil {
+			return "", false, fmt.Errorf("%s: %w", ref, err)
+		}
+		if scheme != "env" {
+			fromSecret = true
+		}
+		b.WriteString(value)
+	}
+	return b.String(), fromSecret, nil
+}
+
+// expandReferences resolves references in the scalar values of a YAML node
+// tree in place. Keys are never expanded. Unquoted values lose their tag so
+// that "${BUDGET}" can still decode into a number. Resolved values of
+// file/cmd references and of secret-looking keys are registered for
+// redaction.
+func expandReferences(n *yaml.Node, resolve referenceResolver) error {
+	return expandNode(n, "", resolve)
+}
+
+func expandNode(n *yaml.Node, key string, resolve referenceResolver) error {
+	switch n.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, c := range n.Content {
+			if err := expandNode(c, key, resolve); err != nil {
+				return err
+			}
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			if err := expandNode(n.Content[i+1], n.Content[i].Value, resolve); err != nil {
+				return err
+			}
+		}
+	case yaml.ScalarNode:
+		value, fromSecret, err := expandString(n.Value, resolve)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", n.Line, err)
+		}
+		if value == n.Value {
+			return nil
+		}
+		n.Value = value
+		if n.Style == 0 {
+			n.Tag = ""
+		}
+		if fromSecret || secretKeys[strings.ToLower(key)] {
+			RegisterSecret(value)
+		}
+	}
+	return nil
+}
+
+// secretSet holds resolved secret values for redaction.
+type secretSet struct {
+	mu     sync.RWMutex
+	values []string
+}
+
+var secrets = &secretSet{}
+
+// RegisterSecret marks a value as secret: RedactSecrets and redacting log
+// writers mask it, and inside GitHub Actions it is added to the runner's
+// log masks. The mask command goes to stderr, which the runner also reads,
+// so it never mixes with machine-readable output on stdout.
+func RegisterSecret(value string) {
+	if len(value) < minSecretLength {
+		return
+	}
+	secrets.mu.Lock()
+	for _, v := range secrets.values {
+		if v == value {
+			secrets.mu.Unlock()
+			return
+		}
+	}
+	secrets.values = append(secrets.values, value)
+	// Longest first, so a secret containing another is masked whole.
+	sort.Slice(secrets.values, func(i, j int) bool { return len(secrets.values[i]) > len(secrets.values[j]) })
+	secrets.mu.Unlock()
+
+	if os.Getenv("GITHUB_ACTIONS") == "true" {
+		for _, line := range strings.Split(value, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				fmt.Fprintf(os.Stderr, "::add-mask::%s\n", line)
+			}
+		}
+	}
+}
+
+// RedactSecrets masks every registered secret in s.
+func RedactSecrets(s string) string {
+	secrets.mu.RLock()
+	defer secrets.mu.RUnlock()
+	for _, v := range secrets.values {
+		s = strings.ReplaceAll(s, v, "***")
+	}
+	return s
+}
+
+// redactingWriter masks registered secrets in everything written through it.
+type redactingWriter struct {
+	w io.Writer
+}
+
+// NewRedactingWriter wraps w so registered secrets never reach it, e.g.
+// log.SetOutput(config.NewRedactingWriter(os.Stderr)).
+func NewRedactingWriter(w io.Writer) io.Writer {
+	return &redactingWriter{w: w}
+}
+
+func (r *redactingWriter) Write(p []byte) (int, error) {
+	if _, err := io.WriteString(r.w, RedactSecrets(string(p))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,127 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package config
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseRawReferences(t *testing.T) {
+	dir := t.TempDir()
+	secretPath := filepath.Join(dir, "qdrant_key")
+	if err := os.WriteFile(secretPath, []byte("file-secret-value\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("SIMILI_TEST_URL", "http://qdrant:6334")
+	t.Setenv("SIMILI_TEST_BUDGET", "5000")
+	t.Setenv(AllowCmdEnv, "true")
+
+	data := []byte(`qdrant:
+  url: "${SIMILI_TEST_URL}"
+  api_key: ${file:` + secretPath + `}
+  collection: "${env:SIMILI_TEST_URL}/x"
+llm:
+  api_key: ${cmd:echo cmd-secret-value}
+  max_tokens_per_run: ${SIMILI_TEST_BUDGET}
+classifiers:
+  - name: cost
+    prompt: "Costs $5, literal $${NOT_A_REF} and ${ .Issue.Title }"
+`)
+	cfg, err := parseRaw(data)
+	if err != nil {
+		t.Fatalf("parseRaw() error = %v", err)
+	}
+	if cfg.Qdrant.URL != "http://qdrant:6334" || cfg.Qdrant.Collection != "http://qdrant:6334/x" {
+		t.Errorf("Unexpected env expansion: %+v", cfg.Qdrant)
+	}
+	if cfg.Qdrant.APIKey != "file-secret-value" {
+		t.Errorf("Qdrant.APIKey = %q, want file contents", cfg.Qdrant.APIKey)
+	}
+	if cfg.LLM.APIKey != "cmd-secret-value" {
+		t.Errorf("LLM.APIKey = %q, want command output", cfg.LLM.APIKey)
+	}
+	if cfg.LLM.MaxTokensPerRun != 5000 {
+		t.Errorf("MaxTokensPerRun = %d, want 5000", cfg.LLM.MaxTokensPerRun)
+	}
+	if want := "Costs $5, literal ${NOT_A_REF} and ${ .Issue.Title }"; cfg.Classifiers[0].Prompt != want {
+		t.Errorf("Prompt = %q, want %q", cfg.Classifiers[0].Prompt, want)
+	}
+
+	if got := RedactSecrets("key=file-secret-value cmd=cmd-secret-value"); got != "key=*** cmd=***" {
+		t.Errorf("RedactSecrets() = %q", got)
+	}
+}
+
+func TestCmdReferencesAreOptIn(t *testing.T) {
+	t.Setenv(AllowCmdEnv, "")
+	_, err := parseRaw([]byte("llm:\n  api_key: ${cmd:echo nope}\n"))
+	if err == nil || !strings.Contains(err.Error(), AllowCmdEnv) {
+		t.Fatalf("Expected opt-in error, got %v", err)
+	}
+}
+
+func TestUnknownReferenceType(t *testing.T) {
+	_, err := parseRaw([]byte("llm:\n  api_key: ${vault:secret/x}\n"))
+	if err == nil || !strings.Contains(err.Error(), `line 2: ${vault:secret/x}: unknown reference type "vault"`) {
+		t.Fatalf("Expected unknown reference error, got %v", err)
+	}
+
+	report := ValidateStrict([]byte("llm:\n  api_key: ${vault:secret/x}\n"))
+	if len(report.Issues) != 1 || report.Issues[0].Line != 2 {
+		t.Errorf("Expected one issue on line 2, got %+v", report.Issues)
+	}
+}
+
+func TestSecretKeysMatchExactly(t *testing.T) {
+	t.Setenv("SIMILI_TEST_DAILY_BUDGET", "7500000")
+	t.Setenv("SIMILI_TEST_API_KEY", "sk-env-secret-value")
+	cfg, err := parseRaw([]byte("llm:\n  api_key: ${SIMILI_TEST_API_KEY}\n  max_tokens_per_day: ${SIMILI_TEST_DAILY_BUDGET}\n"))
+	if err != nil {
+		t.Fatalf("parseRaw() error = %v", err)
+	}
+	if cfg.LLM.MaxTokensPerDay != 7500000 {
+		t.Errorf("MaxTokensPerDay = %d, want 7500000", cfg.LLM.MaxTokensPerDay)
+	}
+	if got := RedactSecrets("key=sk-env-secret-value budget=7500000"); got != "key=*** budget=7500000" {
+		t.Errorf("RedactSecrets() = %q", got)
+	}
+}
+
+func TestRegisterSecretKeepsStdoutClean(t *testing.T) {
+	t.Setenv("GITHUB_ACTIONS", "true")
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	RegisterSecret("sk-stdout-check-123")
+	os.Stdout = stdout
+	w.Close()
+
+	var out bytes.Buffer
+	if _, err := out.ReadFrom(r); err != nil {
+		t.Fatal(err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("RegisterSecret wrote to stdout: %q", out.String())
+	}
+}
+
+func TestRedactingWriter(t *testing.T) {
+	RegisterSecret("sk-redact-me-123")
+	var buf bytes.Buffer
+	logger := log.New(NewRedactingWriter(&buf), "", 0)
+	logger.Printf("calling with key sk-redact-me-123")
+	if got := buf.String(); got != "calling with key ***\n" {
+		t.Errorf("log output = %q", got)
+	}
+}
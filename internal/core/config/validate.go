@@ -0,0 +1,320 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ValidationIssue is a problem found in a config file. Line and Column are
+// 1-based and zero when the position is unknown.
+type ValidationIssue struct {
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+	Path    string `json:"path,omitempty"`
+	Message string `json:"message"`
+}
+
+// String formats the issue as "line:col: path: message".
+func (i ValidationIssue) String() string {
+	var b strings.Builder
+	if i.Line > 0 {
+		fmt.Fprintf(&b, "%d:", i.Line)
+		if i.Column > 0 {
+			fmt.Fprintf(&b, "%d:", i.Column)
+		}
+		b.WriteString(" ")
+	}
+	if i.Path != "" {
+		b.WriteString(i.Path + ": ")
+	}
+	b.WriteString(i.Message)
+	return b.String()
+}
+
+// ValidationReport is the result of strict validation of one config file.
+type ValidationReport struct {
+	// Config is the file decoded on its own (no extends, no defaults), as
+	// far as it could be decoded, or nil if it is not valid YAML.
+	Config *Config
+	Issues []ValidationIssue
+
+	positions map[string]*yaml.Node
+}
+
+// Addf records an issue at the position of the value at path, if known.
+func (r *ValidationReport) Addf(path, format string, args ...interface{}) {
+	issue := ValidationIssue{Path: path, Message: fmt.Sprintf(format, args...)}
+	if n := r.positions[path]; n != nil {
+		issue.Line, issue.Column = n.Line, n.Column
+	}
+	r.Issues = append(r.Issues, issue)
+}
+
+// ValidateStrict checks a config file more thoroughly than Validate: unknown
+// keys, values of the wrong type, out-of-range thresholds, unknown enum
+// values, malformed merge strategies and unknown reference types are
+// reported with their line and column. Environment references are resolved
+// as when loading; file and cmd references are not read or run.
+func ValidateStrict(data []byte) *ValidationReport {
+	report := &ValidationReport{positions: make(map[string]*yaml.Node)}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		report.Issues = append(report.Issues, yamlErrorIssue(err))
+		return report
+	}
+	if err := expandReferences(&doc, validationResolver); err != nil {
+		report.Issues = append(report.Issues, yamlErrorIssue(err))
+		return report
+	}
+	if root := documentRoot(&doc); root != nil {
+		report.walk(root, reflect.TypeOf(Config{}), "", "")
+	}
+
+	// The walk covers everything KnownFields catches, but decode strictly
+	// anyway so nothing the decoder rejects slips through.
+	expanded, err := yaml.Marshal(&doc)
+	if err != nil {
+		report.Issues = append(report.Issues, yamlErrorIssue(err))
+		return report
+	}
+	var cfg Config
+	dec := yaml.NewDecoder(bytes.NewReader(expanded))
+	dec.KnownFields(true)
+	if err := dec.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		if len(report.Issues) == 0 {
+			report.Issues = append(report.Issues, yamlErrorIssue(err))
+		}
+		// Unknown fields and type errors still leave the rest decoded, so
+		// the cross-field checks can run on it.
+		var typeErr *yaml.TypeError
+		if !errors.As(err, &typeErr) {
+			return report
+		}
+	}
+	report.Config = &cfg
+	return report
+}
+
+// validationResolver resolves environment references and checks the others
+// without reading files or running commands.
+func validationResolver(scheme, arg string) (string, error) {
+	switch scheme {
+	case "env":
+		return os.Getenv(arg), nil
+	case "file", "cmd":
+		return "", nil
+	}
+	return resolveReference(scheme, arg)
+}
+
+// CheckTransferTargets reports transfer rules in the file (top-level and
+// per-repository) whose target is not listed in repos.
+func (r *ValidationReport) CheckTransferTargets(repos []RepositoryConfig) {
+	if r.Config == nil {
+		return
+	}
+	known := &Config{Repositories: repos}
+	check := func(prefix string, rules []TransferRule) {
+		for i, rule := range rules {
+			if rule.Target == "" {
+				continue
+			}
+			path := fmt.Sprintf("%s.rules[%d].target", prefix, i)
+			org, repo, ok := strings.Cut(rule.Target, "/")
+			if !ok || org == "" || repo == "" {
+				r.Addf(path, "transfer target %q must be owner/repo", rule.Target)
+			} else if known.FindRepository(org, repo) == nil {
+				r.Addf(path, "transfer target %q is not listed in repositories", rule.Target)
+			}
+		}
+	}
+	check("transfer", r.Config.Transfer.Rules)
+	for i, repo := range r.Config.Repositories {
+		if repo.Transfer != nil {
+			check(fmt.Sprintf("repositories[%d].transfer", i), repo.Transfer.Rules)
+		}
+	}
+}
+
+// walk checks node against type t. path is the dotted location used in
+// messages and rule is the fieldRules key for the value.
+func (r *ValidationReport) walk(n *yaml.Node, t reflect.Type, path, rule string) {
+	if n.Kind == yaml.AliasNode {
+		n = n.Alias
+	}
+	r.positions[path] = n
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if isNull(n) {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if n.Kind != yaml.MappingNode {
+			r.Addf(path, "expected a mapping")
+			return
+		}
+		keys, fields := yamlFields(t)
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key, value := n.Content[i], n.Content[i+1]
+			childPath := joinPath(path, key.Value)
+			field, ok := fields[key.Value]
+			if !ok {
+				r.positions[childPath] = key
+				msg := fmt.Sprintf("unknown field %q", key.Value)
+				if s := closestKey(key.Value, keys); s != "" {
+					msg += fmt.Sprintf(" (did you mean %q?)", s)
+				}
+				r.Addf(childPath, "%s", msg)
+				continue
+			}
+			r.walk(value, field.Type, childPath, t.Name()+"."+key.Value)
+		}
+	case reflect.Map:
+		if n.Kind != yaml.MappingNode {
+			r.Addf(path, "expected a mapping")
+			return
+		}
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			r.walk(n.Content[i+1], t.Elem(), joinPath(path, n.Content[i].Value), rule+"[]")
+		}
+	case reflect.Slice:
+		if n.Kind != yaml.SequenceNode {
+			r.Addf(path, "expected a list")
+			return
+		}
+		for i, item := range n.Content {
+			r.walk(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i), rule+"[]")
+		}
+	default:
+		r.checkScalar(n, t, path, rule)
+	}
+}
+
+// checkScalar checks a scalar's type and its fieldRules constraints.
+func (r *ValidationReport) checkScalar(n *yaml.Node, t reflect.Type, path, rule string) {
+	if n.Kind != yaml.ScalarNode {
+		r.Addf(path, "expected a single value")
+		return
+	}
+
+	var number float64
+	isNumber := false
+	switch t.Kind() {
+	case reflect.Bool:
+		if n.Tag != "!!bool" {
+			r.Addf(path, "expected true or false, got %q", n.Value)
+			return
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n.Tag != "!!int" {
+			r.Addf(path, "expected an integer, got %q", n.Value)
+			return
+		}
+		v, _ := strconv.ParseInt(n.Value, 0, 64)
+		number, isNumber = float64(v), true
+	case reflect.Float32, reflect.Float64:
+		if n.Tag != "!!int" && n.Tag != "!!float" {
+			r.Addf(path, "expected a number, got %q", n.Value)
+			return
+		}
+		v, _ := strconv.ParseFloat(n.Value, 64)
+		number, isNumber = v, true
+	}
+
+	if rule == "Config.merge[]" {
+		if _, err := parseListStrategies(map[string]string{strings.TrimPrefix(path, "merge."): n.Value}); err != nil {
+			r.Addf(path, "%v", err)
+		}
+		return
+	}
+
+	fr, ok := fieldRules[rule]
+	if !ok {
+		return
+	}
+	if isNumber {
+		if fr.Min != nil && number < *fr.Min {
+			r.Addf(path, "%s is below the minimum of %s", n.Value, formatFloat(*fr.Min))
+		}
+		if fr.Max != nil && number > *fr.Max {
+			r.Addf(path, "%s is above the maximum of %s", n.Value, formatFloat(*fr.Max))
+		}
+	}
+	if len(fr.Enum) > 0 && n.Value != "" {
+		for _, allowed := range fr.Enum {
+			if n.Value == allowed {
+				return
+			}
+		}
+		r.Addf(path, "%q is not one of %s", n.Value, strings.Join(fr.Enum, ", "))
+	}
+}
+
+// yamlErrorIssue converts a yaml.v3 error into an issue, keeping the line
+// number the decoder reports.
+func yamlErrorIssue(err error) ValidationIssue {
+	msg := strings.TrimPrefix(err.Error(), "yaml: ")
+	msg = strings.TrimPrefix(msg, "unmarshal errors:\n")
+	issue := ValidationIssue{Message: strings.TrimSpace(msg)}
+	if rest, ok := strings.CutPrefix(issue.Message, "line "); ok {
+		if num, tail, ok := strings.Cut(rest, ": "); ok {
+			if line, convErr := strconv.Atoi(num); convErr == nil {
+				issue.Line, issue.Message = line, tail
+			}
+		}
+	}
+	return issue
+}
+
+// closestKey suggests a known key within two edits of an unknown one.
+func closestKey(unknown string, keys []string) string {
+	best, bestDist := "", 3
+	for _, k := range keys {
+		if d := editDistance(unknown, k); d < bestDist {
+			best, bestDist = k, d
+		}
+	}
+	return best
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
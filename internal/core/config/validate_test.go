@@ -0,0 +1,113 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateStrict(t *testing.T) {
+	data := []byte(`qdrant:
+  url: http://localhost:6334
+defaults:
+  similarity_treshold: 0.7
+  max_similar_to_show: five
+transfer:
+  strategy: smart
+  high_confidence: 7
+  rules:
+    - name: docs
+      target: acme/dcos
+repositories:
+  - org: acme
+    repo: docs
+    enabled: true
+    defaults:
+      similarity_threshold: -0.1
+merge:
+  bot_users: prepend
+`)
+
+	report := ValidateStrict(data)
+	report.CheckTransferTargets(report.Config.Repositories)
+
+	want := []ValidationIssue{
+		{Line: 4, Column: 3, Path: "defaults.similarity_treshold", Message: `unknown field "similarity_treshold" (did you mean "similarity_threshold"?)`},
+		{Line: 5, Column: 24, Path: "defaults.max_similar_to_show", Message: `expected an integer, got "five"`},
+		{Line: 7, Column: 13, Path: "transfer.strategy", Message: `"smart" is not one of rules-only, vdb-only, hybrid`},
+		{Line: 8, Column: 20, Path: "transfer.high_confidence", Message: "7 is above the maximum of 1"},
+		{Line: 17, Column: 29, Path: "repositories[0].defaults.similarity_threshold", Message: "-0.1 is below the minimum of 0"},
+		{Line: 19, Column: 14, Path: "merge.bot_users", Message: `unknown merge strategy "prepend" for bot_users (use replace, append or merge-by-key)`},
+		{Line: 11, Column: 15, Path: "transfer.rules[0].target", Message: `transfer target "acme/dcos" is not listed in repositories`},
+	}
+	if len(report.Issues) != len(want) {
+		t.Fatalf("got %d issues, want %d:\n%v", len(report.Issues), len(want), report.Issues)
+	}
+	for i, issue := range report.Issues {
+		if issue != want[i] {
+			t.Errorf("issue %d = %+v, want %+v", i, issue, want[i])
+		}
+	}
+}
+
+func TestValidateStrictSyntaxError(t *testing.T) {
+	report := ValidateStrict([]byte("defaults: [\n"))
+	if report.Config != nil || len(report.Issues) != 1 || report.Issues[0].Line == 0 {
+		t.Fatalf("Expected one positioned syntax issue, got %+v", report.Issues)
+	}
+}
+
+func TestValidateStrictExamples(t *testing.T) {
+	paths, err := filepath.Glob("../../../DOCS/examples/*/simili.yaml")
+	if err != nil || len(paths) == 0 {
+		t.Fatalf("No example configs found: %v", err)
+	}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		report := ValidateStrict(data)
+		report.CheckTransferTargets(report.Config.Repositories)
+		if len(report.Issues) > 0 {
+			t.Errorf("%s: unexpected issues %v", path, report.Issues)
+		}
+	}
+}
+
+func TestValidationIssueString(t *testing.T) {
+	issue := ValidationIssue{Line: 3, Column: 5, Path: "transfer.strategy", Message: "bad"}
+	if got := issue.String(); got != "3:5: transfer.strategy: bad" {
+		t.Errorf("String() = %q", got)
+	}
+	if got := (ValidationIssue{Message: "bad"}).String(); got != "bad" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestJSONSchemaIsUpToDate(t *testing.T) {
+	schema, err := JSONSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"similarity_threshold"`, `"maximum": 1`, `"hybrid"`, `"additionalProperties": false`} {
+		if !strings.Contains(string(schema), want) {
+			t.Errorf("schema is missing %s", want)
+		}
+	}
+
+	committed, err := os.ReadFile("../../../DOCS/simili.schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(bytes.TrimSpace(committed), bytes.TrimSpace(schema)) {
+		t.Error("DOCS/simili.schema.json is stale; regenerate with: simili config schema > DOCS/simili.schema.json")
+	}
+}
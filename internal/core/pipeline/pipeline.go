@@ -1,7 +1,7 @@
 // Author: Kaviru Hapuarachchi
 // GitHub: https://github.com/kavirubc
 // Created: 2026-02-02
-// Last Modified: 2026-02-04
+// Last Modified: 2026-10-18
 
 // Package pipeline provides the core pipeline engine for Simili-Bot.
 // It defines the Step interface and Context structure used by all pipeline steps.
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/similigh/simili-bot/internal/core/config"
+	"github.com/similigh/simili-bot/internal/integrations/ai"
 )
 
 // ErrSkipPipeline indicates that the pipeline should stop gracefully.
@@ -42,6 +43,9 @@ type Issue struct {
 	Labels                   []string
 	AddedLabel               string // The single label just added (on "labeled" events)
 	Author                   string
+	AuthorAssociation        string // The author's association with the repository, e.g. "MEMBER" or "NONE"
+	Assignees                []string
+	ClosedBy                 string // Who closed the issue, when known
 	URL                      string
 	CreatedAt                time.Time // When the issue was created
 	EventType                string    // "issues" or "issue_comment"
@@ -63,6 +67,9 @@ type Result struct {
 	Indexed         bool
 	SuggestedLabels []string
 	LabelsApplied   []string
+	LabelsRemoved   []string
+	SuggestedOwners []OwnerSuggestion
+	AssigneesAdded  []string
 	Errors          []string
 
 	// Quality assessment
@@ -78,17 +85,36 @@ type Result struct {
 	DuplicateOf         int     `json:"duplicate_of"`
 	DuplicateConfidence float64 `json:"duplicate_confidence"`
 	DuplicateReason     string  `json:"duplicate_reason,omitempty"`
+
+	// Severity estimation
+	Priority          string `json:"priority,omitempty"` // "P0" (most urgent) to "P3"
+	SeverityCategory  string `json:"severity_category,omitempty"`
+	SeverityReasoning string `json:"severity_reasoning,omitempty"`
+
+	// LLM and embedding usage (tokens, cost, latency) for this run
+	Usage ai.Usage `json:"usage"`
+}
+
+// OwnerSuggestion is a person or team suggested to look at the issue.
+type OwnerSuggestion struct {
+	Owner   string // "@user", "@org/team" or an email address from CODEOWNERS
+	Score   float64
+	Reasons []string
 }
 
 // SimilarIssue represents an issue found to be similar.
 type SimilarIssue struct {
-	Number     int
-	Title      string
-	Body       string // Full text content from vector DB (may include title prefix)
-	URL        string
-	Similarity float64
-	State      string
-	Type       string // "issue" or "pr"
+	Number         int
+	Title          string
+	Body           string // Full text content from vector DB (may include title prefix)
+	URL            string
+	Similarity     float64
+	State          string
+	Type           string // "issue" or "pr"
+	Assignees      []string
+	ClosedBy       string
+	SameStackTrace bool   // Shares a stack trace fingerprint with the issue (see fingerprint_match)
+	BodySimHash    uint64 // SimHash of the indexed body, 0 if unknown (see text.SimHash)
 }
 
 // Context carries data through the pipeline steps.
@@ -116,12 +142,30 @@ type Context struct {
 }
 
 // NewContext creates a new pipeline context for an issue.
+// Model usage made with the returned Ctx is recorded in Result.Usage and
+// limited by llm.max_tokens_per_run.
 func NewContext(ctx context.Context, issue *Issue, cfg *config.Config) *Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	result := &Result{IssueNumber: issue.Number}
+
+	var pricing map[string]ai.ModelPrice
+	maxTokens := 0
+	if cfg != nil {
+		maxTokens = cfg.LLM.MaxTokensPerRun
+		pricing = make(map[string]ai.ModelPrice, len(cfg.LLM.Pricing))
+		for model, p := range cfg.LLM.Pricing {
+			pricing[model] = ai.ModelPrice{InputPerMillion: p.InputPerMillion, OutputPerMillion: p.OutputPerMillion}
+		}
+	}
+	tracker := ai.NewUsageTracker(&result.Usage, pricing, maxTokens)
+
 	return &Context{
-		Ctx:      ctx,
+		Ctx:      ai.WithUsageTracker(ctx, tracker),
 		Issue:    issue,
 		Config:   cfg,
-		Result:   &Result{IssueNumber: issue.Number},
+		Result:   result,
 		Metadata: make(map[string]interface{}),
 	}
 }
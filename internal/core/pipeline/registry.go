@@ -1,15 +1,17 @@
 // Author: Kaviru Hapuarachchi
 // GitHub: https://github.com/Kavirubc
 // Created: 2026-02-02
-// Last Modified: 2026-02-02
+// Last Modified: 2026-10-18
 
 // Package pipeline provides step registration and preset workflow building.
 package pipeline
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 
+	"github.com/similigh/simili-bot/internal/core/state"
 	"github.com/similigh/simili-bot/internal/integrations/ai"
 	"github.com/similigh/simili-bot/internal/integrations/github"
 	"github.com/similigh/simili-bot/internal/integrations/qdrant"
@@ -18,20 +20,26 @@ import (
 // Registry holds registered step factories.
 // Step factories create Step instances, allowing for dependency injection.
 type Registry struct {
-	mu        sync.RWMutex
-	factories map[string]StepFactory
+	mu              sync.RWMutex
+	factories       map[string]StepFactory
+	prefixFactories map[string]ParamStepFactory
 }
 
 // StepFactory is a function that creates a Step.
 // It receives dependencies (like clients, config) as parameters.
 type StepFactory func(deps *Dependencies) (Step, error)
 
+// ParamStepFactory creates a Step from a parameterised step name such as
+// "exec:./my-step". It receives the text after the "prefix:" separator.
+type ParamStepFactory func(arg string, deps *Dependencies) (Step, error)
+
 // Dependencies holds the dependencies that can be injected into steps.
 type Dependencies struct {
 	Embedder    *ai.Embedder
 	LLMClient   *ai.LLMClient
 	VectorStore qdrant.VectorStore
 	GitHub      *github.Client
+	Feedback    state.FeedbackStore // Records decisions for feedback tracking; nil = disabled
 	DryRun      bool
 }
 
@@ -71,7 +79,8 @@ func (d *Dependencies) Close() error {
 // NewRegistry creates a new step registry.
 func NewRegistry() *Registry {
 	return &Registry{
-		factories: make(map[string]StepFactory),
+		factories:       make(map[string]StepFactory),
+		prefixFactories: make(map[string]ParamStepFactory),
 	}
 }
 
@@ -90,15 +99,42 @@ func (r *Registry) Get(name string) (StepFactory, bool) {
 	return factory, ok
 }
 
+// RegisterPrefix adds a factory for parameterised step names of the form
+// "prefix:arg" (e.g. "exec:./my-step").
+func (r *Registry) RegisterPrefix(prefix string, factory ParamStepFactory) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.prefixFactories[prefix] = factory
+}
+
+// Build creates a single step by name. Exact registrations take precedence
+// over prefix registrations.
+func (r *Registry) Build(name string, deps *Dependencies) (Step, error) {
+	if factory, ok := r.Get(name); ok {
+		return factory(deps)
+	}
+
+	prefix, arg, found := strings.Cut(name, ":")
+	if found {
+		r.mu.RLock()
+		factory, ok := r.prefixFactories[prefix]
+		r.mu.RUnlock()
+		if ok {
+			return factory(arg, deps)
+		}
+	}
+
+	return nil, fmt.Errorf("unknown step: %s", name)
+}
+
 // BuildFromNames creates a pipeline from a list of step names.
 func (r *Registry) BuildFromNames(names []string, deps *Dependencies) (*Pipeline, error) {
 	var steps []Step
 	for _, name := range names {
-		factory, ok := r.Get(name)
-		if !ok {
+		if !r.Has(name) {
 			return nil, fmt.Errorf("unknown step: %s", name)
 		}
-		step, err := factory(deps)
+		step, err := r.Build(name, deps)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create step '%s': %w", name, err)
 		}
@@ -107,6 +143,26 @@ func (r *Registry) BuildFromNames(names []string, deps *Dependencies) (*Pipeline
 	return New(steps...), nil
 }
 
+// Has reports whether name is a registered step or matches a registered prefix.
+func (r *Registry) Has(name string) bool {
+	if _, ok := r.Get(name); ok {
+		return true
+	}
+	return r.hasPrefix(name)
+}
+
+// hasPrefix reports whether name matches a registered prefix factory.
+func (r *Registry) hasPrefix(name string) bool {
+	prefix, _, found := strings.Cut(name, ":")
+	if !found {
+		return false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.prefixFactories[prefix]
+	return ok
+}
+
 // Presets defines the built-in workflow presets.
 var Presets = map[string][]string{
 	// issue-triage: Standard issue processing workflow
@@ -114,12 +170,18 @@ var Presets = map[string][]string{
 		"gatekeeper",
 		"command_handler",
 		"vectordb_prep",
+		"edit_filter",
 		"llm_router",
 		"transfer_check",
+		"fingerprint_match",
 		"similarity_search",
 		"duplicate_detector",
 		"quality_checker",
+		"template_checker",
 		"triage",
+		"needs_info",
+		"severity_estimator",
+		"owner_suggester",
 		"response_builder",
 		"action_executor",
 		"pending_action_scheduler",
@@ -130,6 +192,8 @@ var Presets = map[string][]string{
 	"similarity-only": {
 		"gatekeeper",
 		"vectordb_prep",
+		"edit_filter",
+		"fingerprint_match",
 		"similarity_search",
 		"response_builder",
 		"action_executor",
@@ -0,0 +1,347 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package state
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// FeedbackDir is the directory for feedback records.
+const FeedbackDir = "feedback"
+
+// SignalKind identifies how a maintainer reacted to a bot decision.
+type SignalKind string
+
+const (
+	SignalReaction     SignalKind = "reaction"      // Reaction on the triage comment; Detail is the content
+	SignalUndo         SignalKind = "undo"          // /undo comment after a transfer
+	SignalReopened     SignalKind = "reopened"      // Issue reopened after the bot closed it
+	SignalLabelRemoved SignalKind = "label_removed" // Bot-applied label removed by a human; Detail is the label
+)
+
+// FeedbackSignal is one piece of human feedback on a decision.
+type FeedbackSignal struct {
+	Kind   SignalKind `json:"kind"`
+	Actor  string     `json:"actor,omitempty"`
+	Detail string     `json:"detail,omitempty"`
+	At     time.Time  `json:"at,omitempty"`
+}
+
+// Negative reports whether the signal says the decision was wrong.
+func (s FeedbackSignal) Negative() bool {
+	if s.Kind == SignalReaction {
+		return s.Detail == "-1" || s.Detail == "confused"
+	}
+	return true
+}
+
+// Positive reports whether the signal endorses the decision.
+func (s FeedbackSignal) Positive() bool {
+	if s.Kind != SignalReaction {
+		return false
+	}
+	switch s.Detail {
+	case "+1", "heart", "hooray", "rocket":
+		return true
+	}
+	return false
+}
+
+// Decision is an action the bot took on an issue.
+type Decision struct {
+	Step       string           `json:"step"`             // Step that made the decision, e.g. "duplicate_detector"
+	Action     string           `json:"action"`           // "duplicate", "transfer", "label" or "close"
+	Target     string           `json:"target,omitempty"` // Original issue, target repository or label
+	Labels     []string         `json:"labels,omitempty"` // Labels applied as part of the decision
+	Confidence float64          `json:"confidence,omitempty"`
+	Threshold  float64          `json:"threshold,omitempty"`
+	DecidedAt  time.Time        `json:"decided_at"`
+	Signals    []FeedbackSignal `json:"signals,omitempty"`
+}
+
+// Negative reports whether any signal on the decision is negative.
+func (d *Decision) Negative() bool {
+	for _, s := range d.Signals {
+		if s.Negative() {
+			return true
+		}
+	}
+	return false
+}
+
+// Positive reports whether the decision has positive and no negative signals.
+func (d *Decision) Positive() bool {
+	if d.Negative() {
+		return false
+	}
+	for _, s := range d.Signals {
+		if s.Positive() {
+			return true
+		}
+	}
+	return false
+}
+
+// AddSignal attaches a signal unless the same one is already recorded.
+func (d *Decision) AddSignal(signal FeedbackSignal) bool {
+	for _, s := range d.Signals {
+		if s.Kind == signal.Kind && s.Actor == signal.Actor && s.Detail == signal.Detail && s.At.Equal(signal.At) {
+			return false
+		}
+	}
+	d.Signals = append(d.Signals, signal)
+	return true
+}
+
+// FeedbackRecord holds the decisions made on one issue and the feedback on them.
+type FeedbackRecord struct {
+	Org         string     `json:"org"`
+	Repo        string     `json:"repo"`
+	IssueNumber int        `json:"issue_number"`
+	Decisions   []Decision `json:"decisions"`
+}
+
+// FeedbackStore persists feedback records.
+type FeedbackStore interface {
+	// GetFeedback retrieves the record for an issue.
+	// Returns nil, nil if no record exists.
+	GetFeedback(ctx context.Context, org, repo string, issueNumber int) (*FeedbackRecord, error)
+
+	// SaveFeedback creates or replaces the record for an issue.
+	SaveFeedback(ctx context.Context, record *FeedbackRecord) error
+
+	// ListFeedback lists the records of a repository.
+	ListFeedback(ctx context.Context, org, repo string) ([]*FeedbackRecord, error)
+}
+
+// RecordDecisions appends decisions to the record of an issue. Decisions
+// already recorded with the same step, action and target (e.g. when an edited
+// or reopened issue is triaged again) are skipped, so each counts once.
+func RecordDecisions(ctx context.Context, store FeedbackStore, org, repo string, issueNumber int, decisions []Decision) error {
+	if store == nil || len(decisions) == 0 {
+		return nil
+	}
+	record, err := store.GetFeedback(ctx, org, repo, issueNumber)
+	if err != nil {
+		return err
+	}
+	if record == nil {
+		record = &FeedbackRecord{Org: org, Repo: repo, IssueNumber: issueNumber}
+	}
+	added := 0
+	for _, d := range decisions {
+		if record.hasDecision(d) {
+			continue
+		}
+		record.Decisions = append(record.Decisions, d)
+		added++
+	}
+	if added == 0 {
+		return nil
+	}
+	return store.SaveFeedback(ctx, record)
+}
+
+// hasDecision reports whether the record already holds a decision with the
+// same step, action and target as d.
+func (r *FeedbackRecord) hasDecision(d Decision) bool {
+	for _, existing := range r.Decisions {
+		if existing.Step == d.Step && existing.Action == d.Action && strings.EqualFold(existing.Target, d.Target) {
+			return true
+		}
+	}
+	return false
+}
+
+// feedbackPath returns the path for a feedback record file.
+func feedbackPath(org, repo string, issueNumber int) string {
+	return fmt.Sprintf("%s/%s/%s/%d.json", FeedbackDir, org, repo, issueNumber)
+}
+
+func marshalFeedback(record *FeedbackRecord) ([]byte, error) {
+	return json.MarshalIndent(record, "", "  ")
+}
+
+func unmarshalFeedback(data []byte) (*FeedbackRecord, error) {
+	var record FeedbackRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// GetFeedback retrieves the feedback record for an issue.
+func (m *GitHubStateManager) GetFeedback(ctx context.Context, org, repo string, issueNumber int) (*FeedbackRecord, error) {
+	data, err := m.getFileContent(ctx, feedbackPath(org, repo, issueNumber))
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return unmarshalFeedback(data)
+}
+
+// SaveFeedback stores the feedback record for an issue.
+func (m *GitHubStateManager) SaveFeedback(ctx context.Context, record *FeedbackRecord) error {
+	data, err := marshalFeedback(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal feedback: %w", err)
+	}
+	return m.putFileContent(ctx, feedbackPath(record.Org, record.Repo, record.IssueNumber), data,
+		fmt.Sprintf("Record feedback for issue #%d", record.IssueNumber))
+}
+
+// ListFeedback lists the feedback records of a repository.
+func (m *GitHubStateManager) ListFeedback(ctx context.Context, org, repo string) ([]*FeedbackRecord, error) {
+	files, err := m.listFilesRecursive(ctx, fmt.Sprintf("%s/%s/%s", FeedbackDir, org, repo))
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var records []*FeedbackRecord
+	for _, file := range files {
+		if !strings.HasSuffix(file, ".json") {
+			continue
+		}
+		data, err := m.getFileContent(ctx, file)
+		if err != nil {
+			continue // Skip files we can't read
+		}
+		record, err := unmarshalFeedback(data)
+		if err != nil {
+			continue
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// LocalFeedbackStore keeps feedback records in a local directory, using the
+// same layout as the state branch.
+type LocalFeedbackStore struct {
+	dir string
+}
+
+// NewLocalFeedbackStore creates a feedback store rooted at dir.
+func NewLocalFeedbackStore(dir string) *LocalFeedbackStore {
+	return &LocalFeedbackStore{dir: dir}
+}
+
+// GetFeedback retrieves the feedback record for an issue.
+func (s *LocalFeedbackStore) GetFeedback(ctx context.Context, org, repo string, issueNumber int) (*FeedbackRecord, error) {
+	data, err := os.ReadFile(filepath.Join(s.dir, filepath.FromSlash(feedbackPath(org, repo, issueNumber))))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return unmarshalFeedback(data)
+}
+
+// SaveFeedback stores the feedback record for an issue.
+func (s *LocalFeedbackStore) SaveFeedback(ctx context.Context, record *FeedbackRecord) error {
+	path := filepath.Join(s.dir, filepath.FromSlash(feedbackPath(record.Org, record.Repo, record.IssueNumber)))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create feedback dir: %w", err)
+	}
+	data, err := marshalFeedback(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal feedback: %w", err)
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// ListFeedback lists the feedback records of a repository.
+func (s *LocalFeedbackStore) ListFeedback(ctx context.Context, org, repo string) ([]*FeedbackRecord, error) {
+	matches, err := filepath.Glob(filepath.Join(s.dir, FeedbackDir, org, repo, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	var records []*FeedbackRecord
+	for _, path := range matches {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		record, err := unmarshalFeedback(data)
+		if err != nil {
+			continue
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// FeedbackSummary aggregates decisions of one step and action at one threshold.
+type FeedbackSummary struct {
+	Step              string  `json:"step"`
+	Action            string  `json:"action"`
+	Threshold         float64 `json:"threshold"`
+	Decisions         int     `json:"decisions"`
+	WithFeedback      int     `json:"with_feedback"`
+	Negative          int     `json:"negative"`
+	Positive          int     `json:"positive"`
+	FalsePositiveRate float64 `json:"false_positive_rate"` // Negative / Decisions
+}
+
+// SummarizeFeedback groups decisions by step, action and threshold.
+// Summaries are sorted by step, action, then threshold.
+func SummarizeFeedback(records []*FeedbackRecord) []FeedbackSummary {
+	type key struct {
+		step, action string
+		threshold    float64
+	}
+	groups := make(map[key]*FeedbackSummary)
+	for _, r := range records {
+		for i := range r.Decisions {
+			d := &r.Decisions[i]
+			k := key{d.Step, d.Action, d.Threshold}
+			s := groups[k]
+			if s == nil {
+				s = &FeedbackSummary{Step: d.Step, Action: d.Action, Threshold: d.Threshold}
+				groups[k] = s
+			}
+			s.Decisions++
+			if len(d.Signals) > 0 {
+				s.WithFeedback++
+			}
+			if d.Negative() {
+				s.Negative++
+			} else if d.Positive() {
+				s.Positive++
+			}
+		}
+	}
+
+	summaries := make([]FeedbackSummary, 0, len(groups))
+	for _, s := range groups {
+		s.FalsePositiveRate = float64(s.Negative) / float64(s.Decisions)
+		summaries = append(summaries, *s)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		a, b := summaries[i], summaries[j]
+		if a.Step != b.Step {
+			return a.Step < b.Step
+		}
+		if a.Action != b.Action {
+			return a.Action < b.Action
+		}
+		return a.Threshold < b.Threshold
+	})
+	return summaries
+}
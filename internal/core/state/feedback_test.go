@@ -0,0 +1,165 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package state
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLocalFeedbackStoreRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	store := NewLocalFeedbackStore(t.TempDir())
+
+	record, err := store.GetFeedback(ctx, "org", "app", 7)
+	if err != nil || record != nil {
+		t.Fatalf("GetFeedback() on empty store = %v, %v; want nil, nil", record, err)
+	}
+
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	first := []Decision{{Step: "duplicate_detector", Action: "duplicate", Target: "#3", Threshold: 0.85, DecidedAt: now}}
+	second := []Decision{{Step: "auto_closer", Action: "close", DecidedAt: now.Add(time.Hour)}}
+	if err := RecordDecisions(ctx, store, "org", "app", 7, first); err != nil {
+		t.Fatal(err)
+	}
+	if err := RecordDecisions(ctx, store, "org", "app", 7, second); err != nil {
+		t.Fatal(err)
+	}
+	if err := RecordDecisions(ctx, store, "org", "app", 8, first); err != nil {
+		t.Fatal(err)
+	}
+
+	record, err = store.GetFeedback(ctx, "org", "app", 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(record.Decisions) != 2 || record.Decisions[1].Action != "close" {
+		t.Errorf("expected both decisions appended, got %+v", record.Decisions)
+	}
+
+	records, err := store.ListFeedback(ctx, "org", "app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 {
+		t.Errorf("ListFeedback() returned %d records, want 2", len(records))
+	}
+	if other, _ := store.ListFeedback(ctx, "org", "other"); len(other) != 0 {
+		t.Errorf("expected no records for another repo, got %d", len(other))
+	}
+}
+
+func TestRecordDecisionsSkipsRepeats(t *testing.T) {
+	ctx := context.Background()
+	store := NewLocalFeedbackStore(t.TempDir())
+
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	run := func(at time.Time) []Decision {
+		return []Decision{
+			{Step: "duplicate_detector", Action: "duplicate", Target: "#3", DecidedAt: at},
+			{Step: "triage", Action: "label", Target: "bug", DecidedAt: at},
+		}
+	}
+	if err := RecordDecisions(ctx, store, "org", "app", 7, run(now)); err != nil {
+		t.Fatal(err)
+	}
+	// A re-run after an edit repeats the same decisions and adds a new label.
+	rerun := append(run(now.Add(time.Hour)), Decision{Step: "triage", Action: "label", Target: "ui", DecidedAt: now.Add(time.Hour)})
+	if err := RecordDecisions(ctx, store, "org", "app", 7, rerun); err != nil {
+		t.Fatal(err)
+	}
+
+	record, err := store.GetFeedback(ctx, "org", "app", 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(record.Decisions) != 3 {
+		t.Fatalf("expected 3 distinct decisions, got %+v", record.Decisions)
+	}
+	if !record.Decisions[0].DecidedAt.Equal(now) {
+		t.Errorf("repeated decision should keep its first time, got %v", record.Decisions[0].DecidedAt)
+	}
+}
+
+func TestDecisionAddSignalDeduplicates(t *testing.T) {
+	var d Decision
+	signal := FeedbackSignal{Kind: SignalReaction, Actor: "alice", Detail: "-1"}
+	if !d.AddSignal(signal) {
+		t.Error("first signal should be added")
+	}
+	if d.AddSignal(signal) {
+		t.Error("duplicate signal should be ignored")
+	}
+	if !d.AddSignal(FeedbackSignal{Kind: SignalReaction, Actor: "bob", Detail: "-1"}) {
+		t.Error("signal from another actor should be added")
+	}
+}
+
+func TestSignalPolarity(t *testing.T) {
+	tests := []struct {
+		signal   FeedbackSignal
+		negative bool
+		positive bool
+	}{
+		{FeedbackSignal{Kind: SignalReaction, Detail: "-1"}, true, false},
+		{FeedbackSignal{Kind: SignalReaction, Detail: "confused"}, true, false},
+		{FeedbackSignal{Kind: SignalReaction, Detail: "+1"}, false, true},
+		{FeedbackSignal{Kind: SignalReaction, Detail: "eyes"}, false, false},
+		{FeedbackSignal{Kind: SignalUndo}, true, false},
+		{FeedbackSignal{Kind: SignalReopened}, true, false},
+		{FeedbackSignal{Kind: SignalLabelRemoved, Detail: "bug"}, true, false},
+	}
+	for _, tt := range tests {
+		if got := tt.signal.Negative(); got != tt.negative {
+			t.Errorf("%+v Negative() = %v, want %v", tt.signal, got, tt.negative)
+		}
+		if got := tt.signal.Positive(); got != tt.positive {
+			t.Errorf("%+v Positive() = %v, want %v", tt.signal, got, tt.positive)
+		}
+	}
+}
+
+func TestSummarizeFeedback(t *testing.T) {
+	negative := []FeedbackSignal{{Kind: SignalReopened}}
+	positive := []FeedbackSignal{{Kind: SignalReaction, Detail: "+1"}}
+	mixed := []FeedbackSignal{{Kind: SignalReaction, Detail: "+1"}, {Kind: SignalReaction, Detail: "-1"}}
+
+	records := []*FeedbackRecord{
+		{Decisions: []Decision{
+			{Step: "duplicate_detector", Action: "duplicate", Threshold: 0.85, Signals: negative},
+			{Step: "triage", Action: "label", Target: "bug"},
+		}},
+		{Decisions: []Decision{
+			{Step: "duplicate_detector", Action: "duplicate", Threshold: 0.85, Signals: positive},
+			{Step: "duplicate_detector", Action: "duplicate", Threshold: 0.85},
+			{Step: "duplicate_detector", Action: "duplicate", Threshold: 0.85, Signals: mixed},
+			{Step: "duplicate_detector", Action: "duplicate", Threshold: 0.9},
+		}},
+	}
+
+	got := SummarizeFeedback(records)
+	if len(got) != 3 {
+		t.Fatalf("got %d summaries, want 3: %+v", len(got), got)
+	}
+
+	dup := got[0]
+	if dup.Step != "duplicate_detector" || dup.Threshold != 0.85 {
+		t.Fatalf("unexpected first summary %+v", dup)
+	}
+	if dup.Decisions != 4 || dup.WithFeedback != 3 || dup.Negative != 2 || dup.Positive != 1 {
+		t.Errorf("duplicate summary = %+v", dup)
+	}
+	if dup.FalsePositiveRate != 0.5 {
+		t.Errorf("FalsePositiveRate = %v, want 0.5", dup.FalsePositiveRate)
+	}
+	if got[1].Threshold != 0.9 || got[1].Decisions != 1 {
+		t.Errorf("second summary = %+v", got[1])
+	}
+	if got[2].Step != "triage" || got[2].Negative != 0 {
+		t.Errorf("third summary = %+v", got[2])
+	}
+}
@@ -0,0 +1,90 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package state
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// UsageDir is the directory for daily LLM token usage.
+const UsageDir = "usage"
+
+// DailyUsage is the LLM token usage of a repository on one UTC day.
+type DailyUsage struct {
+	Org    string `json:"org"`
+	Repo   string `json:"repo"`
+	Day    string `json:"day"` // YYYY-MM-DD
+	Tokens int    `json:"tokens"`
+}
+
+// UsageStore persists daily LLM token usage, so llm.max_tokens_per_day holds
+// across processes.
+type UsageStore interface {
+	// GetDailyUsage returns the tokens used on day, or 0 if none are recorded.
+	GetDailyUsage(ctx context.Context, org, repo, day string) (int, error)
+
+	// AddDailyUsage adds tokens to the usage of day.
+	AddDailyUsage(ctx context.Context, org, repo, day string, tokens int) error
+}
+
+// RepoUsage binds a usage store to one repository.
+type RepoUsage struct {
+	store     UsageStore
+	org, repo string
+}
+
+// NewRepoUsage returns the usage of org/repo in store.
+func NewRepoUsage(store UsageStore, org, repo string) *RepoUsage {
+	return &RepoUsage{store: store, org: org, repo: repo}
+}
+
+// GetDailyUsage returns the tokens used on day.
+func (u *RepoUsage) GetDailyUsage(ctx context.Context, day string) (int, error) {
+	return u.store.GetDailyUsage(ctx, u.org, u.repo, day)
+}
+
+// AddDailyUsage adds tokens to the usage of day.
+func (u *RepoUsage) AddDailyUsage(ctx context.Context, day string, tokens int) error {
+	return u.store.AddDailyUsage(ctx, u.org, u.repo, day, tokens)
+}
+
+// usagePath returns the path for a daily usage file.
+func usagePath(org, repo, day string) string {
+	return fmt.Sprintf("%s/%s/%s/%s.json", UsageDir, org, repo, day)
+}
+
+// GetDailyUsage returns the tokens used on day.
+func (m *GitHubStateManager) GetDailyUsage(ctx context.Context, org, repo, day string) (int, error) {
+	data, err := m.getFileContent(ctx, usagePath(org, repo, day))
+	if err != nil {
+		if isNotFoundError(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	var usage DailyUsage
+	if err := json.Unmarshal(data, &usage); err != nil {
+		return 0, fmt.Errorf("failed to parse daily usage: %w", err)
+	}
+	return usage.Tokens, nil
+}
+
+// AddDailyUsage adds tokens to the usage of day. Concurrent runs may
+// overwrite each other's additions, so the total is a lower bound.
+func (m *GitHubStateManager) AddDailyUsage(ctx context.Context, org, repo, day string, tokens int) error {
+	used, err := m.GetDailyUsage(ctx, org, repo, day)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(&DailyUsage{Org: org, Repo: repo, Day: day, Tokens: used + tokens}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal daily usage: %w", err)
+	}
+	return m.putFileContent(ctx, usagePath(org, repo, day), data,
+		fmt.Sprintf("Record LLM token usage for %s/%s on %s", org, repo, day))
+}
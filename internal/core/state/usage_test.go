@@ -0,0 +1,90 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package state
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// contentsServer fakes the GitHub contents API of one state branch.
+func contentsServer(t *testing.T) (*httptest.Server, map[string][]byte) {
+	t.Helper()
+	var mu sync.Mutex
+	files := make(map[string][]byte)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		path := strings.TrimPrefix(r.URL.Path, "/repos/o/state/contents/")
+		switch r.Method {
+		case http.MethodGet:
+			data, ok := files[path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]string{
+				"content":  base64.StdEncoding.EncodeToString(data),
+				"encoding": "base64",
+				"sha":      "sha-" + path,
+			})
+		case http.MethodPut:
+			var body struct {
+				Content string `json:"content"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			data, _ := base64.StdEncoding.DecodeString(body.Content)
+			files[path] = data
+			w.WriteHeader(http.StatusCreated)
+		}
+	}))
+	return srv, files
+}
+
+// redirectTransport sends every request to the test server.
+type redirectTransport struct{ target *url.URL }
+
+func (rt redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.URL.Scheme = rt.target.Scheme
+	req.URL.Host = rt.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestGitHubDailyUsage(t *testing.T) {
+	srv, files := contentsServer(t)
+	defer srv.Close()
+	target, _ := url.Parse(srv.URL)
+
+	m := NewGitHubStateManager("token", "o", "state")
+	m.httpClient = &http.Client{Transport: redirectTransport{target: target}}
+	usage := NewRepoUsage(m, "acme", "app")
+	ctx := context.Background()
+
+	if got, err := usage.GetDailyUsage(ctx, "2026-10-18"); err != nil || got != 0 {
+		t.Fatalf("GetDailyUsage() before any usage = %d, %v; want 0, nil", got, err)
+	}
+	for _, tokens := range []int{1200, 800} {
+		if err := usage.AddDailyUsage(ctx, "2026-10-18", tokens); err != nil {
+			t.Fatalf("AddDailyUsage() error = %v", err)
+		}
+	}
+	if got, _ := usage.GetDailyUsage(ctx, "2026-10-18"); got != 2000 {
+		t.Errorf("GetDailyUsage() = %d, want 2000", got)
+	}
+	if got, _ := usage.GetDailyUsage(ctx, "2026-10-19"); got != 0 {
+		t.Errorf("GetDailyUsage() of another day = %d, want 0", got)
+	}
+	if _, ok := files["usage/acme/app/2026-10-18.json"]; !ok {
+		t.Errorf("expected usage/acme/app/2026-10-18.json on the state branch, got %v", files)
+	}
+}
@@ -0,0 +1,262 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package ai
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Environment variables selecting a cassette directory for every client
+// created with NewLLMClient or NewEmbedder.
+const (
+	EnvReplayDir = "SIMILI_AI_REPLAY"
+	EnvRecordDir = "SIMILI_AI_RECORD"
+)
+
+// ErrCassetteMiss is returned in replay mode when no recording matches a request.
+var ErrCassetteMiss = errors.New("no recorded response")
+
+// CassetteMode selects whether a cassette records or replays calls.
+type CassetteMode int
+
+const (
+	// CassetteRecord calls the provider and saves every response.
+	CassetteRecord CassetteMode = iota
+	// CassetteReplay serves responses from recordings without calling a provider.
+	CassetteReplay
+)
+
+// providerReplay identifies clients that only serve recorded responses.
+const providerReplay Provider = "replay"
+
+// Cassette stores LLM and embedding responses as JSON files in a directory,
+// one file per request, keyed by a hash of the prompt.
+type Cassette struct {
+	dir  string
+	mode CassetteMode
+}
+
+// cassetteEntry is the on-disk form of one recorded call.
+type cassetteEntry struct {
+	Kind             string    `json:"kind"`                // "llm" or "embedding"
+	Synthetic        bool      `json:"synthetic,omitempty"` // Written by hand, not recorded from a provider
+	Provider         string    `json:"provider,omitempty"`
+	Model            string    `json:"model,omitempty"`
+	Format           string    `json:"format,omitempty"`
+	Temperature      float32   `json:"temperature,omitempty"`
+	Prompt           string    `json:"prompt"`
+	Response         string    `json:"response,omitempty"`
+	Embedding        []float32 `json:"embedding,omitempty"`
+	PromptTokens     int       `json:"prompt_tokens,omitempty"`
+	CompletionTokens int       `json:"completion_tokens,omitempty"`
+}
+
+// OpenCassette opens a cassette directory, creating it when recording.
+func OpenCassette(dir string, mode CassetteMode) (*Cassette, error) {
+	dir = strings.TrimSpace(dir)
+	if dir == "" {
+		return nil, fmt.Errorf("cassette directory is required")
+	}
+	if mode == CassetteRecord {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create cassette directory: %w", err)
+		}
+	} else if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("cassette directory %s not found", dir)
+	}
+	return &Cassette{dir: dir, mode: mode}, nil
+}
+
+// CassetteFromEnv opens the cassette selected by SIMILI_AI_REPLAY or
+// SIMILI_AI_RECORD. It returns nil when neither is set.
+func CassetteFromEnv() (*Cassette, error) {
+	replay := strings.TrimSpace(os.Getenv(EnvReplayDir))
+	record := strings.TrimSpace(os.Getenv(EnvRecordDir))
+	switch {
+	case replay != "" && record != "":
+		return nil, fmt.Errorf("%s and %s cannot both be set", EnvReplayDir, EnvRecordDir)
+	case replay != "":
+		return OpenCassette(replay, CassetteReplay)
+	case record != "":
+		return OpenCassette(record, CassetteRecord)
+	}
+	return nil, nil
+}
+
+func (c *Cassette) replaying() bool {
+	return c != nil && c.mode == CassetteReplay
+}
+
+func (c *Cassette) recording() bool {
+	return c != nil && c.mode == CassetteRecord
+}
+
+// llmKey identifies an LLM request. The model is not part of the key so a
+// recording replays under any configured provider.
+func llmKey(prompt string, temperature float32, format *responseFormat) string {
+	name := ""
+	if format != nil {
+		name = format.Name
+		if name == "" {
+			name = "json"
+		}
+	}
+	return cassetteKey("llm", name, strconv.FormatFloat(float64(temperature), 'f', -1, 32), prompt)
+}
+
+func embeddingKey(text string) string {
+	return cassetteKey("embedding", text)
+}
+
+func cassetteKey(parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+func (c *Cassette) path(kind, key string) string {
+	return filepath.Join(c.dir, kind+"-"+key[:16]+".json")
+}
+
+func (c *Cassette) load(kind, key string) (*cassetteEntry, error) {
+	data, err := os.ReadFile(c.path(kind, key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w for %s request %s in %s", ErrCassetteMiss, kind, key[:16], c.dir)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cassette: %w", err)
+	}
+	var entry cassetteEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("failed to parse cassette %s: %w", c.path(kind, key), err)
+	}
+	return &entry, nil
+}
+
+// save writes entry atomically so concurrent workers never read partial files.
+// Failures are logged: a recording problem must not fail the run.
+func (c *Cassette) save(key string, entry *cassetteEntry) {
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		log.Printf("[ai] Failed to encode cassette entry: %v", err)
+		return
+	}
+	tmp, err := os.CreateTemp(c.dir, ".cassette-*")
+	if err != nil {
+		log.Printf("[ai] Failed to record cassette entry: %v", err)
+		return
+	}
+	_, writeErr := tmp.Write(append(data, '\n'))
+	closeErr := tmp.Close()
+	if writeErr == nil {
+		writeErr = closeErr
+	}
+	if writeErr == nil {
+		writeErr = os.Rename(tmp.Name(), c.path(entry.Kind, key))
+	}
+	if writeErr != nil {
+		_ = os.Remove(tmp.Name())
+		log.Printf("[ai] Failed to record cassette entry: %v", writeErr)
+	}
+}
+
+func (c *Cassette) replayText(prompt string, temperature float32, format *responseFormat) (*cassetteEntry, error) {
+	return c.load("llm", llmKey(prompt, temperature, format))
+}
+
+func (c *Cassette) recordText(provider Provider, model, prompt string, temperature float32, format *responseFormat, text string, usage tokenUsage) {
+	entry := &cassetteEntry{
+		Kind:             "llm",
+		Provider:         string(provider),
+		Model:            model,
+		Temperature:      temperature,
+		Prompt:           prompt,
+		Response:         text,
+		PromptTokens:     usage.PromptTokens,
+		CompletionTokens: usage.CompletionTokens,
+	}
+	if format != nil {
+		entry.Format = format.Name
+	}
+	c.save(llmKey(prompt, temperature, format), entry)
+}
+
+func (c *Cassette) replayEmbedding(text string) (*cassetteEntry, error) {
+	return c.load("embedding", embeddingKey(text))
+}
+
+func (c *Cassette) recordEmbedding(provider Provider, model, text string, embedding []float32, tokens int) {
+	c.save(embeddingKey(text), &cassetteEntry{
+		Kind:         "embedding",
+		Provider:     string(provider),
+		Model:        model,
+		Prompt:       text,
+		Embedding:    embedding,
+		PromptTokens: tokens,
+	})
+}
+
+// embeddingDimensions returns the size of the first recorded embedding, or 0.
+func (c *Cassette) embeddingDimensions() int {
+	matches, _ := filepath.Glob(filepath.Join(c.dir, "embedding-*.json"))
+	for _, m := range matches {
+		data, err := os.ReadFile(m)
+		if err != nil {
+			continue
+		}
+		var entry cassetteEntry
+		if json.Unmarshal(data, &entry) == nil && len(entry.Embedding) > 0 {
+			return len(entry.Embedding)
+		}
+	}
+	return 0
+}
+
+// SetCassette records or replays every call made by this client.
+func (l *LLMClient) SetCassette(c *Cassette) {
+	l.cassette = c
+}
+
+// SetCassette records or replays every call made by this embedder.
+func (e *Embedder) SetCassette(c *Cassette) {
+	e.cassette = c
+}
+
+// NewReplayLLMClient returns a client that only serves responses recorded in
+// c. No API key is needed; model is reported by Model() when set.
+func NewReplayLLMClient(c *Cassette, model string) *LLMClient {
+	if strings.TrimSpace(model) == "" {
+		model = string(providerReplay)
+	}
+	return &LLMClient{
+		provider: providerReplay,
+		model:    strings.TrimSpace(model),
+		cassette: &Cassette{dir: c.dir, mode: CassetteReplay},
+	}
+}
+
+// NewReplayEmbedder returns an embedder that only serves embeddings recorded
+// in c. Its dimensions are taken from the recordings.
+func NewReplayEmbedder(c *Cassette, model string) *Embedder {
+	if strings.TrimSpace(model) == "" {
+		model = string(providerReplay)
+	}
+	e := &Embedder{
+		provider: providerReplay,
+		model:    strings.TrimSpace(model),
+		cassette: &Cassette{dir: c.dir, mode: CassetteReplay},
+	}
+	e.dimensions.Store(int32(e.cassette.embeddingDimensions()))
+	return e
+}
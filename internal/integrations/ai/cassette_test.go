@@ -0,0 +1,93 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package ai
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestCassette_RecordThenReplay(t *testing.T) {
+	dir := t.TempDir()
+	rec, err := OpenCassette(dir, CassetteRecord)
+	if err != nil {
+		t.Fatalf("OpenCassette: %v", err)
+	}
+
+	chatSrv, _ := statusServer([]int{200}, func(int) []byte { return chatOKBody(`{"answer":"yes"}`) })
+	defer chatSrv.Close()
+	embSrv, _ := statusServer([]int{200}, func(int) []byte { return embeddingOKBody() })
+	defer embSrv.Close()
+
+	l := newTestLLMClient(chatSrv.URL)
+	l.SetCassette(rec)
+	if _, err := l.generateText(context.Background(), "question", 0.2, true); err != nil {
+		t.Fatalf("record generate: %v", err)
+	}
+	e := newTestEmbedder(embSrv.URL)
+	e.SetCassette(rec)
+	if _, err := e.Embed(context.Background(), "some text"); err != nil {
+		t.Fatalf("record embed: %v", err)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 2 {
+		t.Fatalf("recorded %d files, want 2", len(files))
+	}
+
+	// Replay needs neither a server nor an API key.
+	replay, err := OpenCassette(dir, CassetteReplay)
+	if err != nil {
+		t.Fatalf("OpenCassette: %v", err)
+	}
+	rl := NewReplayLLMClient(replay, "")
+	text, err := rl.generateText(context.Background(), "question", 0.2, true)
+	if err != nil || text != `{"answer":"yes"}` {
+		t.Fatalf("replay generate = (%q, %v)", text, err)
+	}
+	re := NewReplayEmbedder(replay, "")
+	if re.Dimensions() != 3 {
+		t.Fatalf("replay dimensions = %d, want 3", re.Dimensions())
+	}
+	emb, err := re.Embed(context.Background(), "some text")
+	if err != nil || len(emb) != 3 {
+		t.Fatalf("replay embed = (%v, %v)", emb, err)
+	}
+
+	// Any change to the prompt or its parameters is a different request.
+	if _, err := rl.generateText(context.Background(), "question", 0.5, true); !errors.Is(err, ErrCassetteMiss) {
+		t.Fatalf("err = %v, want ErrCassetteMiss", err)
+	}
+	if _, err := re.Embed(context.Background(), "other text"); !errors.Is(err, ErrCassetteMiss) {
+		t.Fatalf("err = %v, want ErrCassetteMiss", err)
+	}
+}
+
+func TestCassetteFromEnv(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Setenv(EnvReplayDir, "")
+	t.Setenv(EnvRecordDir, "")
+	if c, err := CassetteFromEnv(); c != nil || err != nil {
+		t.Fatalf("got (%v, %v), want no cassette", c, err)
+	}
+
+	t.Setenv(EnvReplayDir, dir)
+	l, err := NewLLMClient("")
+	if err != nil {
+		t.Fatalf("NewLLMClient in replay mode: %v", err)
+	}
+	if l.Provider() != "replay" {
+		t.Fatalf("provider = %q, want replay", l.Provider())
+	}
+
+	t.Setenv(EnvRecordDir, dir)
+	if _, err := CassetteFromEnv(); err == nil {
+		t.Fatal("expected error when both replay and record are set")
+	}
+}
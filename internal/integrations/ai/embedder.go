@@ -1,7 +1,7 @@
 // Author: Kaviru Hapuarachchi
 // GitHub: https://github.com/Kavirubc
 // Created: 2026-02-02
-// Last Modified: 2026-03-05
+// Last Modified: 2026-10-18
 
 // Package ai provides AI integration for embeddings and LLM.
 package ai
@@ -9,12 +9,14 @@ package ai
 import (
 	"context"
 	"fmt"
+	"log"
 	"net/http"
 	"strings"
 	"sync/atomic"
 	"time"
 
 	"github.com/google/generative-ai-go/genai"
+	"github.com/similigh/simili-bot/internal/utils/ratelimit"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/api/option"
 )
@@ -31,16 +33,38 @@ type Embedder struct {
 	model       string
 	baseURL     string // empty = production; override in tests
 	dimensions  atomic.Int32
+	requestDims int // Sent to OpenAI as "dimensions"; 0 = the model's native size
 	retryConfig RetryConfig
+	fallbacks   []*Embedder     // Dimension-compatible providers tried in order on failure
+	breaker     *circuitBreaker // nil = always closed
+	rateLimits  *ratelimit.Registry
+	cassette    *Cassette // Records or replays calls; nil = off
 }
 
 // NewEmbedder creates a new embedder.
 func NewEmbedder(apiKey, model string) (*Embedder, error) {
+	cassette, err := CassetteFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	if cassette.replaying() {
+		return NewReplayEmbedder(cassette, model), nil
+	}
+
 	provider, resolvedKey, err := ResolveProvider(apiKey)
 	if err != nil {
 		return nil, err
 	}
+	e, err := newEmbedderForProvider(provider, resolvedKey, model)
+	if err != nil {
+		return nil, err
+	}
+	e.cassette = cassette
+	return e, nil
+}
 
+// newEmbedderForProvider creates an embedder for an already resolved provider.
+func newEmbedderForProvider(provider Provider, resolvedKey, model string) (*Embedder, error) {
 	e := &Embedder{
 		provider:    provider,
 		apiKey:      resolvedKey,
@@ -84,12 +108,18 @@ func NewEmbedder(apiKey, model string) (*Embedder, error) {
 	return e, nil
 }
 
-// Close closes underlying provider clients.
+// Close closes underlying provider clients, including fallbacks.
 func (e *Embedder) Close() error {
+	var firstErr error
 	if e.gemini != nil {
-		return e.gemini.Close()
+		firstErr = e.gemini.Close()
+	}
+	for _, fb := range e.fallbacks {
+		if err := fb.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
 	}
-	return nil
+	return firstErr
 }
 
 // Provider returns the resolved provider.
@@ -109,11 +139,64 @@ func (e *Embedder) Embed(ctx context.Context, text string) ([]float32, error) {
 		return nil, fmt.Errorf("text cannot be empty")
 	}
 
+	if e.cassette.replaying() {
+		entry, err := e.cassette.replayEmbedding(text)
+		if err != nil {
+			return nil, err
+		}
+		usageTrackerFrom(ctx).addEmbedding(entry.Model, entry.PromptTokens, 0)
+		return entry.Embedding, nil
+	}
+
+	// Try this provider, then each fallback, skipping any with an open circuit.
+	var lastErr error
+	for _, c := range append([]*Embedder{e}, e.fallbacks...) {
+		if !c.breaker.allow() {
+			continue
+		}
+
+		var tokens int
+		start := time.Now()
+		embedding, err := c.embedWithProvider(ctx, text, &tokens)
+		if err == nil && c != e && len(embedding) != e.Dimensions() {
+			err = fmt.Errorf("fallback %s/%s returned %d dimensions, expected %d", c.provider, c.model, len(embedding), e.Dimensions())
+			c.breaker.failure()
+			lastErr = err
+			continue
+		}
+		if err == nil {
+			c.breaker.success()
+			usageTrackerFrom(ctx).addEmbedding(c.model, tokens, time.Since(start))
+			if e.cassette.recording() {
+				e.cassette.recordEmbedding(c.provider, c.model, text, embedding, tokens)
+			}
+			return embedding, nil
+		}
+		if !isProviderFailure(ctx, err) {
+			return nil, err
+		}
+
+		c.breaker.failure()
+		lastErr = err
+		if len(e.fallbacks) > 0 {
+			log.Printf("[ai] Embedding provider %s/%s failed, trying next provider: %v", c.provider, c.model, err)
+		}
+	}
+
+	if lastErr == nil {
+		return nil, ErrAllProvidersUnavailable
+	}
+	return nil, lastErr
+}
+
+// embedWithProvider makes one call (with retries) to this embedder's provider.
+func (e *Embedder) embedWithProvider(ctx context.Context, text string, tokens *int) ([]float32, error) {
 	switch e.provider {
 	case ProviderGemini:
+		// The Gemini embedding API does not report token counts.
 		return e.embedGemini(ctx, text)
 	case ProviderOpenAI:
-		return e.embedOpenAI(ctx, text)
+		return e.embedOpenAI(ctx, text, tokens)
 	default:
 		return nil, fmt.Errorf("unsupported provider: %s", e.provider)
 	}
@@ -121,9 +204,13 @@ func (e *Embedder) Embed(ctx context.Context, text string) ([]float32, error) {
 
 func (e *Embedder) embedGemini(ctx context.Context, text string) ([]float32, error) {
 	return withRetry(ctx, e.retryConfig, "Embed", func() ([]float32, error) {
+		if err := e.limiter().Wait(ctx, estimateTokens(text)); err != nil {
+			return nil, err
+		}
 		em := e.gemini.EmbeddingModel(e.model)
 		res, err := em.EmbedContent(ctx, genai.Text(text))
 		if err != nil {
+			e.limiter().PauseFor(cappedRetryAfter(err, e.retryConfig.MaxDelay))
 			return nil, fmt.Errorf("failed to generate embedding: %w", err)
 		}
 
@@ -135,25 +222,38 @@ func (e *Embedder) embedGemini(ctx context.Context, text string) ([]float32, err
 	})
 }
 
-func (e *Embedder) embedOpenAI(ctx context.Context, text string) ([]float32, error) {
+func (e *Embedder) embedOpenAI(ctx context.Context, text string, tokens *int) ([]float32, error) {
 	return withRetry(ctx, e.retryConfig, "Embed", func() ([]float32, error) {
 		req := struct {
-			Model string `json:"model"`
-			Input string `json:"input"`
+			Model      string `json:"model"`
+			Input      string `json:"input"`
+			Dimensions int    `json:"dimensions,omitempty"`
 		}{
-			Model: e.model,
-			Input: text,
+			Model:      e.model,
+			Input:      text,
+			Dimensions: e.requestDims,
 		}
 
 		var resp struct {
 			Data []struct {
 				Embedding []float64 `json:"embedding"`
 			} `json:"data"`
+			Usage struct {
+				PromptTokens int `json:"prompt_tokens"`
+			} `json:"usage"`
 		}
 
+		estimate := estimateTokens(text)
+		if err := e.limiter().Wait(ctx, estimate); err != nil {
+			return nil, err
+		}
 		if err := callOpenAIJSON(ctx, e.openAI, e.apiKey, e.baseURL, "/v1/embeddings", req, &resp); err != nil {
+			e.limiter().PauseFor(cappedRetryAfter(err, e.retryConfig.MaxDelay))
 			return nil, fmt.Errorf("failed to generate embedding: %w", err)
 		}
+		if resp.Usage.PromptTokens > 0 {
+			e.limiter().Charge(resp.Usage.PromptTokens - estimate)
+		}
 
 		if len(resp.Data) == 0 || len(resp.Data[0].Embedding) == 0 {
 			return nil, fmt.Errorf("empty embedding returned")
@@ -166,6 +266,9 @@ func (e *Embedder) embedOpenAI(ctx context.Context, text string) ([]float32, err
 
 		// Keep the dimensions aligned with provider output if model mapping is unknown.
 		e.dimensions.Store(int32(len(embedding)))
+		if tokens != nil {
+			*tokens = resp.Usage.PromptTokens
+		}
 		return embedding, nil
 	})
 }
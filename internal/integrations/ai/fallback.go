@@ -0,0 +1,223 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Circuit breaker defaults used when fallbacks are configured.
+const (
+	defaultBreakerThreshold = 3
+	defaultBreakerCooldown  = time.Minute
+)
+
+// ErrAllProvidersUnavailable is returned when every provider in a fallback
+// chain has an open circuit breaker.
+var ErrAllProvidersUnavailable = errors.New("all AI providers are unavailable (circuit open)")
+
+// ProviderSpec describes a fallback provider. An empty APIKey falls back to
+// the provider's environment variable; an empty Provider is inferred from the key.
+type ProviderSpec struct {
+	Provider   string
+	APIKey     string
+	Model      string
+	Dimensions int // Embedding fallbacks only; 0 = inferred from the model
+}
+
+// circuitBreaker stops calls to a provider after threshold consecutive
+// failures. After cooldown a trial call is let through; a success closes the
+// circuit, another failure re-opens it.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	openedAt  time.Time
+	now       func() time.Time
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	if threshold <= 0 {
+		threshold = defaultBreakerThreshold
+	}
+	if cooldown <= 0 {
+		cooldown = defaultBreakerCooldown
+	}
+	return &circuitBreaker{threshold: threshold, cooldown: cooldown, now: time.Now}
+}
+
+// allow reports whether a call may be attempted.
+func (b *circuitBreaker) allow() bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.failures < b.threshold {
+		return true
+	}
+	return b.now().Sub(b.openedAt) >= b.cooldown
+}
+
+func (b *circuitBreaker) success() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	b.failures = 0
+	b.mu.Unlock()
+}
+
+func (b *circuitBreaker) failure() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openedAt = b.now()
+	}
+}
+
+// isProviderFailure reports whether err indicates the provider itself is
+// unhealthy (as opposed to a bad request or a cancelled context), so the
+// call should count against its breaker and move on to the next provider.
+func isProviderFailure(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	if isRetryableError(err) {
+		return true
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
+
+// resolveSpec fills in the provider and API key of a fallback spec.
+func resolveSpec(spec ProviderSpec) (Provider, string, error) {
+	key := strings.TrimSpace(spec.APIKey)
+	provider := Provider(strings.ToLower(strings.TrimSpace(spec.Provider)))
+
+	if provider == "" {
+		if key == "" {
+			return "", "", fmt.Errorf("fallback requires a provider or an api_key")
+		}
+		provider = inferProviderFromKey(key)
+	}
+
+	if key == "" {
+		switch provider {
+		case ProviderGemini:
+			key = strings.TrimSpace(os.Getenv("GEMINI_API_KEY"))
+		case ProviderOpenAI:
+			key = strings.TrimSpace(os.Getenv("OPENAI_API_KEY"))
+		}
+	}
+
+	switch provider {
+	case ProviderGemini, ProviderOpenAI:
+	default:
+		return "", "", fmt.Errorf("unsupported fallback provider: %s", provider)
+	}
+	if key == "" {
+		return "", "", fmt.Errorf("no API key for fallback provider %s", provider)
+	}
+	return provider, key, nil
+}
+
+// SetCircuitBreaker configures the breaker of this client and its fallbacks.
+func (l *LLMClient) SetCircuitBreaker(threshold int, cooldown time.Duration) {
+	l.breaker = newCircuitBreaker(threshold, cooldown)
+	for _, fb := range l.fallbacks {
+		fb.breaker = newCircuitBreaker(threshold, cooldown)
+	}
+}
+
+// AddFallbacks appends providers that are tried, in order, when the current
+// provider fails or its circuit is open. Replaying clients ignore fallbacks.
+func (l *LLMClient) AddFallbacks(specs []ProviderSpec) error {
+	if l.cassette.replaying() {
+		return nil
+	}
+	for i, spec := range specs {
+		provider, key, err := resolveSpec(spec)
+		if err != nil {
+			return fmt.Errorf("llm fallback %d: %w", i+1, err)
+		}
+		fb, err := newLLMClientForProvider(provider, key, strings.TrimSpace(spec.Model))
+		if err != nil {
+			return fmt.Errorf("llm fallback %d: %w", i+1, err)
+		}
+		fb.retryConfig = l.retryConfig
+		fb.breaker = newCircuitBreaker(defaultBreakerThreshold, defaultBreakerCooldown)
+		fb.rateLimits = l.rateLimits
+		l.fallbacks = append(l.fallbacks, fb)
+	}
+	if len(l.fallbacks) > 0 && l.breaker == nil {
+		l.breaker = newCircuitBreaker(defaultBreakerThreshold, defaultBreakerCooldown)
+	}
+	return nil
+}
+
+// SetCircuitBreaker configures the breaker of this embedder and its fallbacks.
+func (e *Embedder) SetCircuitBreaker(threshold int, cooldown time.Duration) {
+	e.breaker = newCircuitBreaker(threshold, cooldown)
+	for _, fb := range e.fallbacks {
+		fb.breaker = newCircuitBreaker(threshold, cooldown)
+	}
+}
+
+// AddFallbacks appends embedding providers that are tried, in order, when
+// the current provider fails. A fallback must use the primary's provider and
+// model, typically with another API key: vectors from a different model live
+// in a different space even when their sizes match, and would be stored next
+// to the primary's vectors in the index. A fallback without a model uses the
+// primary's. Replaying embedders ignore fallbacks.
+func (e *Embedder) AddFallbacks(specs []ProviderSpec) error {
+	if e.cassette.replaying() {
+		return nil
+	}
+	for i, spec := range specs {
+		provider, key, err := resolveSpec(spec)
+		if err != nil {
+			return fmt.Errorf("embedding fallback %d: %w", i+1, err)
+		}
+		model := strings.TrimSpace(spec.Model)
+		if model == "" {
+			model = e.model
+		}
+		if provider != e.provider || model != e.model {
+			return fmt.Errorf("embedding fallback %d: %s/%s differs from the primary %s/%s (fallbacks must use the same model, e.g. with another API key)",
+				i+1, provider, model, e.provider, e.model)
+		}
+		if spec.Dimensions > 0 && spec.Dimensions != e.Dimensions() {
+			return fmt.Errorf("embedding fallback %d: %d dimensions requested, primary produces %d", i+1, spec.Dimensions, e.Dimensions())
+		}
+		fb, err := newEmbedderForProvider(provider, key, model)
+		if err != nil {
+			return fmt.Errorf("embedding fallback %d: %w", i+1, err)
+		}
+		fb.requestDims = e.requestDims
+		fb.dimensions.Store(int32(e.Dimensions()))
+		fb.retryConfig = e.retryConfig
+		fb.breaker = newCircuitBreaker(defaultBreakerThreshold, defaultBreakerCooldown)
+		fb.rateLimits = e.rateLimits
+		e.fallbacks = append(e.fallbacks, fb)
+	}
+	if len(e.fallbacks) > 0 && e.breaker == nil {
+		e.breaker = newCircuitBreaker(defaultBreakerThreshold, defaultBreakerCooldown)
+	}
+	return nil
+}
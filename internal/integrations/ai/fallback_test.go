@@ -0,0 +1,199 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package ai
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func errorBody(int) []byte {
+	return []byte(`{"error":{"message":"boom"}}`)
+}
+
+func TestCircuitBreaker_OpensAndHalfOpens(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	b := newCircuitBreaker(2, time.Minute)
+	b.now = func() time.Time { return now }
+
+	b.failure()
+	if !b.allow() {
+		t.Fatal("breaker should stay closed below the threshold")
+	}
+	b.failure()
+	if b.allow() {
+		t.Fatal("breaker should open at the threshold")
+	}
+
+	now = now.Add(time.Minute)
+	if !b.allow() {
+		t.Fatal("breaker should allow a trial call after the cooldown")
+	}
+	b.success()
+	if !b.allow() {
+		t.Fatal("success should close the breaker")
+	}
+
+	var nilBreaker *circuitBreaker
+	if !nilBreaker.allow() {
+		t.Fatal("nil breaker should always allow")
+	}
+}
+
+func TestGenerate_FallsBackOnProviderFailure(t *testing.T) {
+	primary, primaryCalls := statusServer([]int{500}, errorBody)
+	defer primary.Close()
+	secondary, secondaryCalls := statusServer([]int{200}, func(int) []byte { return chatOKBody("from fallback") })
+	defer secondary.Close()
+
+	l := newTestLLMClient(primary.URL)
+	fb := newTestLLMClient(secondary.URL)
+	fb.model = "fallback-model"
+	l.fallbacks = []*LLMClient{fb}
+	l.SetCircuitBreaker(1, time.Hour)
+
+	text, err := l.generateText(context.Background(), "ping", 0, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if text != "from fallback" {
+		t.Fatalf("text = %q, want fallback response", text)
+	}
+	primaryBefore := primaryCalls.Load()
+
+	// The primary circuit is now open, so the next call goes straight to the fallback.
+	if _, err := l.generateText(context.Background(), "ping", 0, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := primaryCalls.Load(); got != primaryBefore {
+		t.Fatalf("primary called %d more times with an open circuit", got-primaryBefore)
+	}
+	if got := secondaryCalls.Load(); got != 2 {
+		t.Fatalf("fallback calls = %d, want 2", got)
+	}
+}
+
+func TestGenerate_NoFallbackOnBadRequest(t *testing.T) {
+	primary, _ := statusServer([]int{400}, errorBody)
+	defer primary.Close()
+	secondary, secondaryCalls := statusServer([]int{200}, func(int) []byte { return chatOKBody("unused") })
+	defer secondary.Close()
+
+	l := newTestLLMClient(primary.URL)
+	l.fallbacks = []*LLMClient{newTestLLMClient(secondary.URL)}
+
+	if _, err := l.generateText(context.Background(), "ping", 0, false); err == nil {
+		t.Fatal("expected the bad request error")
+	}
+	if got := secondaryCalls.Load(); got != 0 {
+		t.Fatalf("fallback called %d times for a non-retryable error", got)
+	}
+}
+
+func TestGenerate_AllCircuitsOpen(t *testing.T) {
+	primary, _ := statusServer([]int{500}, errorBody)
+	defer primary.Close()
+
+	l := newTestLLMClient(primary.URL)
+	l.SetCircuitBreaker(1, time.Hour)
+
+	if _, err := l.generateText(context.Background(), "ping", 0, false); err == nil {
+		t.Fatal("expected provider error")
+	}
+	_, err := l.generateText(context.Background(), "ping", 0, false)
+	if !errors.Is(err, ErrAllProvidersUnavailable) {
+		t.Fatalf("err = %v, want ErrAllProvidersUnavailable", err)
+	}
+}
+
+func TestEmbed_FallsBackOnProviderFailure(t *testing.T) {
+	primary, _ := statusServer([]int{503}, errorBody)
+	defer primary.Close()
+	secondary, _ := statusServer([]int{200}, func(int) []byte { return embeddingOKBody() })
+	defer secondary.Close()
+
+	e := newTestEmbedder(primary.URL)
+	e.dimensions.Store(3)
+	fb := newTestEmbedder(secondary.URL)
+	fb.dimensions.Store(3)
+	e.fallbacks = []*Embedder{fb}
+
+	emb, err := e.Embed(context.Background(), "hello")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(emb) != 3 {
+		t.Fatalf("len(emb) = %d, want 3", len(emb))
+	}
+}
+
+func TestEmbed_RejectsFallbackWithWrongDimensions(t *testing.T) {
+	primary, _ := statusServer([]int{503}, errorBody)
+	defer primary.Close()
+	secondary, _ := statusServer([]int{200}, func(int) []byte { return embeddingOKBody() })
+	defer secondary.Close()
+
+	e := newTestEmbedder(primary.URL) // 1536 dimensions; the fallback returns 3
+	e.fallbacks = []*Embedder{newTestEmbedder(secondary.URL)}
+
+	_, err := e.Embed(context.Background(), "hello")
+	if err == nil || !strings.Contains(err.Error(), "dimensions") {
+		t.Fatalf("err = %v, want dimension mismatch", err)
+	}
+}
+
+func TestEmbedderAddFallbacks_RejectsOtherModels(t *testing.T) {
+	e := newTestEmbedder("http://unused") // openai/text-embedding-3-small, 1536 dimensions
+	for _, spec := range []ProviderSpec{
+		// Same size, different vector space.
+		{Provider: "openai", APIKey: "sk-test", Model: "text-embedding-3-large", Dimensions: 1536},
+		{Provider: "gemini", APIKey: "test-key", Model: "gemini-embedding-001"},
+		{Provider: "openai", APIKey: "sk-test", Dimensions: 3072},
+	} {
+		if err := e.AddFallbacks([]ProviderSpec{spec}); err == nil {
+			t.Errorf("%+v: expected the fallback to be rejected", spec)
+		}
+	}
+	if len(e.fallbacks) != 0 {
+		t.Fatal("rejected fallbacks should not be added")
+	}
+}
+
+func TestEmbedderAddFallbacks_SameModelOtherKey(t *testing.T) {
+	e := newTestEmbedder("http://unused")
+	e.requestDims = 512
+	e.dimensions.Store(512)
+	if err := e.AddFallbacks([]ProviderSpec{{Provider: "openai", APIKey: "sk-other"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	fb := e.fallbacks[0]
+	if fb.model != e.model || fb.apiKey != "sk-other" {
+		t.Errorf("fallback = %s with key %s, want %s with the other key", fb.model, fb.apiKey, e.model)
+	}
+	if fb.requestDims != 512 || fb.Dimensions() != 512 {
+		t.Errorf("fallback dimensions = %d (requested %d), want the primary's 512", fb.Dimensions(), fb.requestDims)
+	}
+}
+
+func TestResolveSpec(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "sk-env")
+	t.Setenv("GEMINI_API_KEY", "")
+
+	provider, key, err := resolveSpec(ProviderSpec{Provider: "OpenAI"})
+	if err != nil || provider != ProviderOpenAI || key != "sk-env" {
+		t.Fatalf("got (%s, %s, %v), want openai key from env", provider, key, err)
+	}
+	if _, _, err := resolveSpec(ProviderSpec{Provider: "gemini"}); err == nil {
+		t.Fatal("expected error for missing gemini key")
+	}
+	if _, _, err := resolveSpec(ProviderSpec{Provider: "anthropic", APIKey: "x"}); err == nil {
+		t.Fatal("expected error for unsupported provider")
+	}
+}
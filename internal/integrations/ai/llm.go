@@ -1,19 +1,23 @@
 // Author: Kaviru Hapuarachchi
 // GitHub: https://github.com/Kavirubc
 // Created: 2026-02-02
-// Last Modified: 2026-03-06
+// Last Modified: 2026-10-18
 
 package ai
 
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
 	"net/http"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/generative-ai-go/genai"
+	"github.com/similigh/simili-bot/internal/utils/ratelimit"
 	"google.golang.org/api/option"
 )
 
@@ -21,7 +25,7 @@ import (
 type RelatedIssueRef struct {
 	Number       int    `json:"number"`
 	Title        string `json:"title,omitempty"`
-	Relationship string `json:"relationship"` // "duplicate" | "related" | "distinct"
+	Relationship string `json:"relationship" enum:"duplicate,related,distinct"`
 }
 
 // LLMClient provides LLM-based analysis using Gemini or OpenAI.
@@ -33,6 +37,16 @@ type LLMClient struct {
 	model       string
 	baseURL     string // empty = production; override in tests
 	retryConfig RetryConfig
+	prompts     *PromptSet // nil = built-in templates
+	daily       *dailyBudget
+	fallbacks   []*LLMClient    // Tried in order when this provider fails
+	breaker     *circuitBreaker // nil = always closed
+	rateLimits  *ratelimit.Registry
+	cassette    *Cassette // Records or replays calls; nil = off
+
+	// noJSONSchema is set once the OpenAI-compatible model rejects strict
+	// json_schema output; later calls ask for json_object instead.
+	noJSONSchema atomic.Bool
 }
 
 // IssueInput represents the issue data needed for analysis.
@@ -41,6 +55,34 @@ type IssueInput struct {
 	Body   string
 	Author string
 	Labels []string
+
+	// AllowedLabels restricts triage label suggestions to the repository's
+	// labels. Empty means unrestricted.
+	AllowedLabels []LabelOption
+
+	// LabelGroups are label dimensions (e.g. type, priority) from which
+	// triage picks one label each.
+	LabelGroups []LabelGroupOption
+}
+
+// LabelOption is a label the LLM may suggest.
+type LabelOption struct {
+	Name        string
+	Description string
+}
+
+// LabelGroupOption is a label dimension offered to triage.
+type LabelGroupOption struct {
+	Name     string
+	Labels   []string
+	Required bool // The LLM must pick a label from this group
+}
+
+// LabelGroupPick is the label triage chose for one label group.
+type LabelGroupPick struct {
+	Group      string  `json:"group"`
+	Label      string  `json:"label"`
+	Confidence float64 `json:"confidence"`
 }
 
 // SimilarIssueInput represents a similar issue found.
@@ -49,17 +91,22 @@ type SimilarIssueInput struct {
 	Title      string
 	Body       string // Full text content from vector DB
 	URL        string
-	Similarity float64
+	Similarity float64 // 0 when the issue was found only by its stack trace
 	State      string
+	// SameStackTrace marks issues that share a stack trace with the current issue.
+	SameStackTrace bool
 }
 
 // TriageResult holds the result of issue triage analysis.
 type TriageResult struct {
-	Quality         string   `json:"quality"` // "good", "needs-improvement", "poor"
+	Quality         string   `json:"quality" enum:"good,needs-improvement,poor"`
 	SuggestedLabels []string `json:"suggested_labels"`
 	Reasoning       string   `json:"reasoning"`
 	IsDuplicate     bool     `json:"is_duplicate"`
 	DuplicateReason string   `json:"duplicate_reason"`
+
+	// LabelGroups holds one pick per requested label group.
+	LabelGroups []LabelGroupPick `json:"label_groups"`
 }
 
 // RouteIssueInput represents input for repository routing.
@@ -91,15 +138,36 @@ type RepositoryRanking struct {
 	Reasoning  string  `json:"reasoning"`
 }
 
+// routeResponse is the JSON shape returned by the routing prompt.
+type routeResponse struct {
+	Rankings []RepositoryRanking `json:"rankings"`
+}
+
 // QualityResult holds issue quality assessment.
 type QualityResult struct {
-	Score       float64  `json:"score"`       // 0.0 (poor) to 1.0 (excellent)
-	Assessment  string   `json:"assessment"`  // "excellent"|"good"|"needs-improvement"|"poor"
+	Score       float64  `json:"score"` // 0.0 (poor) to 1.0 (excellent)
+	Assessment  string   `json:"assessment" enum:"excellent,good,needs-improvement,poor"`
 	Issues      []string `json:"issues"`      // Missing elements
 	Suggestions []string `json:"suggestions"` // How to improve
 	Reasoning   string   `json:"reasoning"`
 }
 
+// SeverityInput holds what a severity estimate is based on.
+type SeverityInput struct {
+	Issue             *IssueInput
+	AuthorAssociation string   // The reporter's association with the repository, e.g. "MEMBER" or "NONE"
+	StackTraces       []string // Stack traces found in the issue
+}
+
+// SeverityResult holds an issue's estimated priority.
+type SeverityResult struct {
+	Priority   string   `json:"priority" enum:"P0,P1,P2,P3"`
+	Category   string   `json:"category" enum:"crash,data-loss,security,regression,performance,functional,cosmetic"`
+	Confidence float64  `json:"confidence"`
+	Signals    []string `json:"signals"` // Evidence the estimate rests on
+	Reasoning  string   `json:"reasoning"`
+}
+
 // DuplicateCheckInput represents input for duplicate detection.
 type DuplicateCheckInput struct {
 	CurrentIssue  *IssueInput
@@ -109,28 +177,46 @@ type DuplicateCheckInput struct {
 // DuplicateResult holds duplicate detection analysis.
 type DuplicateResult struct {
 	IsDuplicate   bool              `json:"is_duplicate"`
-	DuplicateOf   int               `json:"duplicate_of"`    // Issue number
-	Confidence    float64           `json:"confidence"`      // 0.0-1.0
+	DuplicateOf   int               `json:"duplicate_of"` // Issue number
+	Confidence    float64           `json:"confidence"`   // 0.0-1.0
 	Reasoning     string            `json:"reasoning"`
 	RelatedIssues []RelatedIssueRef `json:"related_issues"` // LLM classification of all candidates
 }
 
 // NewLLMClient creates a new LLM client.
 func NewLLMClient(apiKey string, model ...string) (*LLMClient, error) {
+	selectedModel := ""
+	if len(model) > 0 {
+		selectedModel = strings.TrimSpace(model[0])
+	}
+
+	cassette, err := CassetteFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	if cassette.replaying() {
+		return NewReplayLLMClient(cassette, selectedModel), nil
+	}
+
 	provider, resolvedKey, err := ResolveProvider(apiKey)
 	if err != nil {
 		return nil, err
 	}
+	client, err := newLLMClientForProvider(provider, resolvedKey, selectedModel)
+	if err != nil {
+		return nil, err
+	}
+	client.cassette = cassette
+	return client, nil
+}
 
+// newLLMClientForProvider creates a client for an already resolved provider.
+func newLLMClientForProvider(provider Provider, resolvedKey, selectedModel string) (*LLMClient, error) {
 	client := &LLMClient{
 		provider:    provider,
 		apiKey:      resolvedKey,
 		retryConfig: DefaultRetryConfig(),
 	}
-	selectedModel := ""
-	if len(model) > 0 {
-		selectedModel = strings.TrimSpace(model[0])
-	}
 
 	switch provider {
 	case ProviderGemini:
@@ -159,14 +245,50 @@ func NewLLMClient(apiKey string, model ...string) (*LLMClient, error) {
 	return client, nil
 }
 
-// Close closes underlying provider clients.
+// Close closes underlying provider clients, including fallbacks.
 func (l *LLMClient) Close() error {
+	var firstErr error
+	flushCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := l.daily.flush(flushCtx); err != nil {
+		firstErr = fmt.Errorf("failed to store daily token usage: %w", err)
+	}
 	if l.gemini != nil {
-		return l.gemini.Close()
+		if err := l.gemini.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
 	}
+	for _, fb := range l.fallbacks {
+		if err := fb.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// SetPrompts replaces the prompt templates used by every operation.
+func (l *LLMClient) SetPrompts(prompts *PromptSet) {
+	l.prompts = prompts
+}
+
+// LoadPrompts loads a prompt set with LoadPromptSet and uses it for every
+// operation.
+func (l *LLMClient) LoadPrompts(dir string, overrides map[string]string) error {
+	prompts, err := LoadPromptSet(dir, overrides)
+	if err != nil {
+		return fmt.Errorf("failed to load prompt templates: %w", err)
+	}
+	l.SetPrompts(prompts)
 	return nil
 }
 
+// SetDailyTokenBudget caps prompt+completion tokens per UTC day across all
+// calls made by this client (0 = unlimited). A non-nil store shares the
+// budget with other processes; the client's usage is written back on Close.
+func (l *LLMClient) SetDailyTokenBudget(limit int, store DailyUsageStore) {
+	l.daily = &dailyBudget{limit: limit, now: time.Now, store: store}
+}
+
 // Provider returns the resolved provider.
 func (l *LLMClient) Provider() string {
 	return string(l.provider)
@@ -180,19 +302,29 @@ func (l *LLMClient) Model() string {
 // AnalyzeIssue performs triage analysis on an issue.
 // It retries on transient errors (429/5xx) with exponential backoff.
 func (l *LLMClient) AnalyzeIssue(ctx context.Context, issue *IssueInput) (*TriageResult, error) {
-	prompt := buildTriagePromptJSON(issue)
+	prompt, err := buildTriagePromptJSON(l.prompts, issue)
+	if err != nil {
+		return nil, err
+	}
 
-	responseText, err := l.generateText(ctx, prompt, 0.3, true)
+	var result TriageResult
+	err = l.generateValidated(ctx, "triage", prompt, 0.3, &result, func() []string {
+		return validateTriage(&result, issue.LabelGroups)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to analyze issue: %w", err)
 	}
 
-	// Parse JSON response into TriageResult
-	result, err := parseTriageResponseJSON(responseText)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse LLM response: %w", err)
+	result.Quality = strings.ToLower(result.Quality)
+	labels := make([]string, 0, len(result.SuggestedLabels))
+	for _, label := range result.SuggestedLabels {
+		labels = append(labels, strings.TrimSpace(label))
 	}
-	return result, nil
+	result.SuggestedLabels = labels
+	for i := range result.LabelGroups {
+		result.LabelGroups[i].Label = strings.TrimSpace(result.LabelGroups[i].Label)
+	}
+	return &result, nil
 }
 
 // GenerateResponse creates a comment for similar issues.
@@ -202,7 +334,10 @@ func (l *LLMClient) GenerateResponse(ctx context.Context, similar []SimilarIssue
 		return "", nil
 	}
 
-	prompt := buildResponsePrompt(similar)
+	prompt, err := buildResponsePrompt(l.prompts, similar)
+	if err != nil {
+		return "", err
+	}
 
 	responseText, err := l.generateText(ctx, prompt, 0.5, false)
 	if err != nil {
@@ -219,18 +354,17 @@ func (l *LLMClient) RouteIssue(ctx context.Context, input *RouteIssueInput) (*Ro
 		return &RouterResult{Rankings: []RepositoryRanking{}, BestMatch: nil}, nil
 	}
 
-	prompt := buildRouteIssuePrompt(input)
-
-	responseText, err := l.generateText(ctx, prompt, 0.3, true)
+	prompt, err := buildRouteIssuePrompt(l.prompts, input)
 	if err != nil {
-		return nil, fmt.Errorf("failed to route issue: %w", err)
+		return nil, err
 	}
 
-	var result struct {
-		Rankings []RepositoryRanking `json:"rankings"`
-	}
-	if err := unmarshalJSONResponse(responseText, &result); err != nil {
-		return nil, fmt.Errorf("failed to parse routing response: %w", err)
+	var result routeResponse
+	err = l.generateValidated(ctx, "route_issue", prompt, 0.3, &result, func() []string {
+		return validateRouting(&result, input.Repositories)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to route issue: %w", err)
 	}
 
 	// Ensure non-nil slices
@@ -255,16 +389,17 @@ func (l *LLMClient) RouteIssue(ctx context.Context, input *RouteIssueInput) (*Ro
 // AssessQuality evaluates issue completeness and clarity.
 // It retries on transient errors (429/5xx) with exponential backoff.
 func (l *LLMClient) AssessQuality(ctx context.Context, issue *IssueInput) (*QualityResult, error) {
-	prompt := buildQualityAssessmentPrompt(issue)
-
-	responseText, err := l.generateText(ctx, prompt, 0.3, true)
+	prompt, err := buildQualityAssessmentPrompt(l.prompts, issue)
 	if err != nil {
-		return nil, fmt.Errorf("failed to assess quality: %w", err)
+		return nil, err
 	}
 
 	var result QualityResult
-	if err := unmarshalJSONResponse(responseText, &result); err != nil {
-		return nil, fmt.Errorf("failed to parse quality response: %w", err)
+	err = l.generateValidated(ctx, "quality_assessment", prompt, 0.3, &result, func() []string {
+		return validateQuality(&result)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to assess quality: %w", err)
 	}
 
 	// Ensure non-nil slices
@@ -275,13 +410,32 @@ func (l *LLMClient) AssessQuality(ctx context.Context, issue *IssueInput) (*Qual
 		result.Suggestions = []string{}
 	}
 
-	// Normalize assessment
-	switch strings.ToLower(result.Assessment) {
-	case "excellent", "good", "needs-improvement", "poor":
-		result.Assessment = strings.ToLower(result.Assessment)
-	default:
-		result.Assessment = "good"
+	// Normalize assessment (validated above)
+	result.Assessment = strings.ToLower(result.Assessment)
+
+	return &result, nil
+}
+
+// EstimateSeverity classifies how urgent an issue is.
+func (l *LLMClient) EstimateSeverity(ctx context.Context, input *SeverityInput) (*SeverityResult, error) {
+	prompt, err := buildSeverityPrompt(l.prompts, input)
+	if err != nil {
+		return nil, err
+	}
+
+	var result SeverityResult
+	err = l.generateValidated(ctx, "severity", prompt, 0.2, &result, func() []string {
+		return validateSeverity(&result)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate severity: %w", err)
+	}
+
+	if result.Signals == nil {
+		result.Signals = []string{}
 	}
+	result.Priority = strings.ToUpper(result.Priority)
+	result.Category = strings.ToLower(result.Category)
 
 	return &result, nil
 }
@@ -296,7 +450,10 @@ type ExplainTransferInput struct {
 
 // ExplainTransfer generates a brief explanation of why an issue should be transferred.
 func (l *LLMClient) ExplainTransfer(ctx context.Context, input *ExplainTransferInput) (string, error) {
-	prompt := buildExplainTransferPrompt(input)
+	prompt, err := buildExplainTransferPrompt(l.prompts, input)
+	if err != nil {
+		return "", err
+	}
 	text, err := l.generateText(ctx, prompt, 0.3, false)
 	if err != nil {
 		return "", fmt.Errorf("failed to explain transfer: %w", err)
@@ -311,16 +468,17 @@ func (l *LLMClient) DetectDuplicate(ctx context.Context, input *DuplicateCheckIn
 		return &DuplicateResult{IsDuplicate: false, RelatedIssues: []RelatedIssueRef{}}, nil
 	}
 
-	prompt := buildDuplicateDetectionPrompt(input)
-
-	responseText, err := l.generateText(ctx, prompt, 0.3, true)
+	prompt, err := buildDuplicateDetectionPrompt(l.prompts, input)
 	if err != nil {
-		return nil, fmt.Errorf("failed to detect duplicate: %w", err)
+		return nil, err
 	}
 
 	var result DuplicateResult
-	if err := unmarshalJSONResponse(responseText, &result); err != nil {
-		return nil, fmt.Errorf("failed to parse duplicate response: %w", err)
+	err = l.generateValidated(ctx, "duplicate_detection", prompt, 0.3, &result, func() []string {
+		return validateDuplicate(&result, input.SimilarIssues)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect duplicate: %w", err)
 	}
 
 	// Ensure non-nil RelatedIssues
@@ -331,27 +489,123 @@ func (l *LLMClient) DetectDuplicate(ctx context.Context, input *DuplicateCheckIn
 	return &result, nil
 }
 
+// GenerateJSON runs an arbitrary prompt in JSON mode and decodes the response
+// into out. It is used by declarative steps that supply their own prompt.
+// It retries on transient errors (429/5xx) with exponential backoff.
+func (l *LLMClient) GenerateJSON(ctx context.Context, prompt string, temperature float32, out interface{}) error {
+	responseText, err := l.generateText(ctx, prompt, temperature, true)
+	if err != nil {
+		return fmt.Errorf("failed to generate JSON: %w", err)
+	}
+	if err := unmarshalJSONResponse(responseText, out); err != nil {
+		return fmt.Errorf("failed to parse JSON response: %w", err)
+	}
+	return nil
+}
+
 func (l *LLMClient) generateText(ctx context.Context, prompt string, temperature float32, jsonMode bool) (string, error) {
+	var format *responseFormat
+	if jsonMode {
+		format = &responseFormat{}
+	}
+	return l.generate(ctx, prompt, temperature, format)
+}
+
+// tokenUsage holds the token counts reported by a provider for one call.
+type tokenUsage struct {
+	PromptTokens     int
+	CompletionTokens int
+}
+
+// generate calls the provider. A nil format requests free text; a format
+// without a schema requests plain JSON mode. Token budgets are enforced
+// before the call and usage is recorded on the run's tracker afterwards.
+func (l *LLMClient) generate(ctx context.Context, prompt string, temperature float32, format *responseFormat) (string, error) {
+	tracker := usageTrackerFrom(ctx)
+	if err := tracker.checkBudget(); err != nil {
+		return "", err
+	}
+	if err := l.daily.check(ctx); err != nil {
+		tracker.markBudgetExceeded()
+		return "", fmt.Errorf("daily limit reached: %w", err)
+	}
+
+	if l.cassette.replaying() {
+		entry, err := l.cassette.replayText(prompt, temperature, format)
+		if err != nil {
+			return "", err
+		}
+		tracker.addLLM(entry.Model, entry.PromptTokens, entry.CompletionTokens, 0)
+		l.daily.add(entry.PromptTokens + entry.CompletionTokens)
+		return entry.Response, nil
+	}
+
+	// Try this provider, then each fallback, skipping any with an open circuit.
+	var lastErr error
+	for _, c := range append([]*LLMClient{l}, l.fallbacks...) {
+		if !c.breaker.allow() {
+			continue
+		}
+
+		var usage tokenUsage
+		start := time.Now()
+		text, err := c.generateWithProvider(ctx, prompt, temperature, format, &usage)
+		if err == nil {
+			c.breaker.success()
+			tracker.addLLM(c.model, usage.PromptTokens, usage.CompletionTokens, time.Since(start))
+			l.daily.add(usage.PromptTokens + usage.CompletionTokens)
+			if l.cassette.recording() {
+				l.cassette.recordText(c.provider, c.model, prompt, temperature, format, text, usage)
+			}
+			return text, nil
+		}
+		if !isProviderFailure(ctx, err) {
+			return "", err
+		}
+
+		c.breaker.failure()
+		lastErr = err
+		if len(l.fallbacks) > 0 {
+			log.Printf("[ai] LLM provider %s/%s failed, trying next provider: %v", c.provider, c.model, err)
+		}
+	}
+
+	if lastErr == nil {
+		return "", ErrAllProvidersUnavailable
+	}
+	return "", lastErr
+}
+
+// generateWithProvider makes one call (with retries) to this client's provider.
+func (l *LLMClient) generateWithProvider(ctx context.Context, prompt string, temperature float32, format *responseFormat, usage *tokenUsage) (string, error) {
 	switch l.provider {
 	case ProviderGemini:
-		return l.generateGeminiText(ctx, prompt, temperature, jsonMode)
+		return l.generateGeminiText(ctx, prompt, temperature, format, usage)
 	case ProviderOpenAI:
-		return l.generateOpenAIText(ctx, prompt, temperature, jsonMode)
+		return l.generateOpenAIText(ctx, prompt, temperature, format, usage)
 	default:
 		return "", fmt.Errorf("unsupported provider: %s", l.provider)
 	}
 }
 
-func (l *LLMClient) generateGeminiText(ctx context.Context, prompt string, temperature float32, jsonMode bool) (string, error) {
+func (l *LLMClient) generateGeminiText(ctx context.Context, prompt string, temperature float32, format *responseFormat, usage *tokenUsage) (string, error) {
 	return withRetry(ctx, l.retryConfig, "GenerateText", func() (string, error) {
 		model := l.gemini.GenerativeModel(l.model)
 		model.SetTemperature(temperature)
-		if jsonMode {
+		if format != nil {
 			model.ResponseMIMEType = "application/json"
+			if format.Schema != nil {
+				model.ResponseSchema = format.Schema.toGemini()
+			}
 		}
 
+		estimate := estimateTokens(prompt)
+		if err := l.limiter().Wait(ctx, estimate); err != nil {
+			return "", err
+		}
 		resp, err := model.GenerateContent(ctx, genai.Text(prompt))
 		if err != nil {
+			l.limiter().PauseFor(cappedRetryAfter(err, l.retryConfig.MaxDelay))
 			return "", err
 		}
 
@@ -368,17 +622,30 @@ func (l *LLMClient) generateGeminiText(ctx context.Context, prompt string, tempe
 		if strings.TrimSpace(responseText) == "" {
 			return "", fmt.Errorf("empty response from LLM")
 		}
+		if resp.UsageMetadata != nil && resp.UsageMetadata.TotalTokenCount > 0 {
+			l.limiter().Charge(int(resp.UsageMetadata.TotalTokenCount) - estimate)
+		}
+		if usage != nil && resp.UsageMetadata != nil {
+			usage.PromptTokens = int(resp.UsageMetadata.PromptTokenCount)
+			usage.CompletionTokens = int(resp.UsageMetadata.CandidatesTokenCount)
+		}
 		return responseText, nil
 	})
 }
 
-func (l *LLMClient) generateOpenAIText(ctx context.Context, prompt string, temperature float32, jsonMode bool) (string, error) {
+func (l *LLMClient) generateOpenAIText(ctx context.Context, prompt string, temperature float32, format *responseFormat, usage *tokenUsage) (string, error) {
 	type openAIMessage struct {
 		Role    string `json:"role"`
 		Content string `json:"content"`
 	}
+	type openAIJSONSchema struct {
+		Name   string                 `json:"name"`
+		Strict bool                   `json:"strict"`
+		Schema map[string]interface{} `json:"schema"`
+	}
 	type openAIResponseFormat struct {
-		Type string `json:"type"`
+		Type       string            `json:"type"`
+		JSONSchema *openAIJSONSchema `json:"json_schema,omitempty"`
 	}
 	type openAIRequest struct {
 		Model          string                `json:"model"`
@@ -392,6 +659,10 @@ func (l *LLMClient) generateOpenAIText(ctx context.Context, prompt string, tempe
 				Content interface{} `json:"content"`
 			} `json:"message"`
 		} `json:"choices"`
+		Usage struct {
+			PromptTokens     int `json:"prompt_tokens"`
+			CompletionTokens int `json:"completion_tokens"`
+		} `json:"usage"`
 	}
 
 	return withRetry(ctx, l.retryConfig, "GenerateText", func() (string, error) {
@@ -401,14 +672,36 @@ func (l *LLMClient) generateOpenAIText(ctx context.Context, prompt string, tempe
 			Messages:    []openAIMessage{{Role: "user", Content: prompt}},
 			Temperature: &temp,
 		}
-		if jsonMode {
+		if format != nil && format.Schema != nil && !l.noJSONSchema.Load() {
+			req.ResponseFormat = &openAIResponseFormat{
+				Type:       "json_schema",
+				JSONSchema: &openAIJSONSchema{Name: format.Name, Strict: true, Schema: format.Schema.toOpenAI()},
+			}
+		} else if format != nil {
 			req.ResponseFormat = &openAIResponseFormat{Type: "json_object"}
 		}
 
+		estimate := estimateTokens(prompt)
+		if err := l.limiter().Wait(ctx, estimate); err != nil {
+			return "", err
+		}
 		var resp openAIResponse
-		if err := callOpenAIJSON(ctx, l.openAI, l.apiKey, l.baseURL, "/v1/chat/completions", req, &resp); err != nil {
+		err := callOpenAIJSON(ctx, l.openAI, l.apiKey, l.baseURL, "/v1/chat/completions", req, &resp)
+		if err != nil && req.ResponseFormat != nil && req.ResponseFormat.Type == "json_schema" && isJSONSchemaUnsupported(err) {
+			// Older and self-hosted models reject strict schemas; the
+			// response is still validated after parsing.
+			log.Printf("[ai] Model %s does not support json_schema output, falling back to json_object", l.model)
+			l.noJSONSchema.Store(true)
+			req.ResponseFormat = &openAIResponseFormat{Type: "json_object"}
+			err = callOpenAIJSON(ctx, l.openAI, l.apiKey, l.baseURL, "/v1/chat/completions", req, &resp)
+		}
+		if err != nil {
+			l.limiter().PauseFor(cappedRetryAfter(err, l.retryConfig.MaxDelay))
 			return "", err
 		}
+		if total := resp.Usage.PromptTokens + resp.Usage.CompletionTokens; total > 0 {
+			l.limiter().Charge(total - estimate)
+		}
 
 		if len(resp.Choices) == 0 {
 			return "", fmt.Errorf("empty response from LLM")
@@ -418,11 +711,26 @@ func (l *LLMClient) generateOpenAIText(ctx context.Context, prompt string, tempe
 		if responseText == "" {
 			return "", fmt.Errorf("empty response from LLM")
 		}
+		if usage != nil {
+			usage.PromptTokens = resp.Usage.PromptTokens
+			usage.CompletionTokens = resp.Usage.CompletionTokens
+		}
 
 		return responseText, nil
 	})
 }
 
+// isJSONSchemaUnsupported reports whether err is an OpenAI-compatible API
+// rejecting the json_schema response format.
+func isJSONSchemaUnsupported(err error) bool {
+	var oaiErr *openAIStatusError
+	if !errors.As(err, &oaiErr) || oaiErr.Code != http.StatusBadRequest {
+		return false
+	}
+	msg := strings.ToLower(oaiErr.Message)
+	return strings.Contains(msg, "response_format") || strings.Contains(msg, "json_schema")
+}
+
 func extractOpenAIContent(content interface{}) string {
 	switch v := content.(type) {
 	case string:
@@ -489,70 +797,3 @@ func extractJSONObject(text string) string {
 	}
 	return ""
 }
-
-// parseTriageResponseJSON parses the JSON LLM response into a TriageResult.
-func parseTriageResponseJSON(response string) (*TriageResult, error) {
-	var result TriageResult
-	if err := unmarshalJSONResponse(response, &result); err != nil {
-		// If JSON parsing fails, fall back to legacy string parsing
-		return parseTriageResponseLegacy(response), nil
-	}
-
-	// Validate and normalize quality
-	switch strings.ToLower(result.Quality) {
-	case "good", "needs-improvement", "poor":
-		result.Quality = strings.ToLower(result.Quality)
-	default:
-		result.Quality = "good" // Default
-	}
-
-	// Ensure non-nil slices
-	if result.SuggestedLabels == nil {
-		result.SuggestedLabels = []string{}
-	}
-
-	return &result, nil
-}
-
-// parseTriageResponseLegacy is a fallback parser for non-JSON responses.
-// Deprecated: Use JSON structured output instead.
-func parseTriageResponseLegacy(response string) *TriageResult {
-	result := &TriageResult{
-		Quality:         "good", // Default
-		SuggestedLabels: []string{},
-		Reasoning:       response,
-	}
-
-	lower := strings.ToLower(response)
-
-	// Parse quality
-	if strings.Contains(lower, "poor quality") || strings.Contains(lower, "quality: poor") {
-		result.Quality = "poor"
-	} else if strings.Contains(lower, "needs improvement") || strings.Contains(lower, "quality: needs-improvement") {
-		result.Quality = "needs-improvement"
-	}
-
-	// Parse labels (look for common patterns)
-	labels := []string{}
-	if strings.Contains(lower, "bug") {
-		labels = append(labels, "bug")
-	}
-	if strings.Contains(lower, "feature") || strings.Contains(lower, "enhancement") {
-		labels = append(labels, "enhancement")
-	}
-	if strings.Contains(lower, "documentation") || strings.Contains(lower, "docs") {
-		labels = append(labels, "documentation")
-	}
-	if strings.Contains(lower, "question") {
-		labels = append(labels, "question")
-	}
-	result.SuggestedLabels = labels
-
-	// Parse duplicate status
-	if strings.Contains(lower, "duplicate") || strings.Contains(lower, "similar to") {
-		result.IsDuplicate = true
-		result.DuplicateReason = "LLM detected potential duplicate"
-	}
-
-	return result
-}
@@ -110,7 +110,7 @@ func TestEmbedOpenAI_RetryOn429(t *testing.T) {
 	defer srv.Close()
 
 	e := newTestEmbedder(srv.URL)
-	emb, err := e.embedOpenAI(context.Background(), "hello")
+	emb, err := e.embedOpenAI(context.Background(), "hello", nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -135,7 +135,7 @@ func TestEmbedOpenAI_RetryOn500(t *testing.T) {
 	defer srv.Close()
 
 	e := newTestEmbedder(srv.URL)
-	_, err := e.embedOpenAI(context.Background(), "hello")
+	_, err := e.embedOpenAI(context.Background(), "hello", nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -152,7 +152,7 @@ func TestEmbedOpenAI_NoRetryOn400(t *testing.T) {
 	defer srv.Close()
 
 	e := newTestEmbedder(srv.URL)
-	_, err := e.embedOpenAI(context.Background(), "hello")
+	_, err := e.embedOpenAI(context.Background(), "hello", nil)
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -169,7 +169,7 @@ func TestEmbedOpenAI_ExhaustsRetries(t *testing.T) {
 	defer srv.Close()
 
 	e := newTestEmbedder(srv.URL)
-	_, err := e.embedOpenAI(context.Background(), "hello")
+	_, err := e.embedOpenAI(context.Background(), "hello", nil)
 	if err == nil {
 		t.Fatal("expected error after exhausted retries")
 	}
@@ -194,7 +194,7 @@ func TestGenerateOpenAIText_RetryOn429(t *testing.T) {
 	defer srv.Close()
 
 	l := newTestLLMClient(srv.URL)
-	text, err := l.generateOpenAIText(context.Background(), "ping", 0.0, false)
+	text, err := l.generateOpenAIText(context.Background(), "ping", 0.0, nil, nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -219,7 +219,7 @@ func TestGenerateOpenAIText_RetryOn500(t *testing.T) {
 	defer srv.Close()
 
 	l := newTestLLMClient(srv.URL)
-	_, err := l.generateOpenAIText(context.Background(), "ping", 0.0, false)
+	_, err := l.generateOpenAIText(context.Background(), "ping", 0.0, nil, nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -236,7 +236,7 @@ func TestGenerateOpenAIText_NoRetryOn400(t *testing.T) {
 	defer srv.Close()
 
 	l := newTestLLMClient(srv.URL)
-	_, err := l.generateOpenAIText(context.Background(), "ping", 0.0, false)
+	_, err := l.generateOpenAIText(context.Background(), "ping", 0.0, nil, nil)
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -253,7 +253,7 @@ func TestGenerateOpenAIText_ExhaustsRetries(t *testing.T) {
 	defer srv.Close()
 
 	l := newTestLLMClient(srv.URL)
-	_, err := l.generateOpenAIText(context.Background(), "ping", 0.0, false)
+	_, err := l.generateOpenAIText(context.Background(), "ping", 0.0, nil, nil)
 	if err == nil {
 		t.Fatal("expected error after exhausted retries")
 	}
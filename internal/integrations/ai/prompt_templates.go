@@ -0,0 +1,212 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package ai
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// Prompt template names. Overrides in config use these keys.
+const (
+	PromptTriage             = "triage"
+	PromptResponse           = "response"
+	PromptRouteIssue         = "route_issue"
+	PromptQualityAssessment  = "quality_assessment"
+	PromptExplainTransfer    = "explain_transfer"
+	PromptDuplicateDetection = "duplicate_detection"
+	PromptSeverity           = "severity"
+)
+
+//go:embed prompts/*.tmpl
+var defaultPromptFS embed.FS
+
+// promptContracts lists the JSON fields each parser expects. An override must
+// still ask the model for every one of them.
+var promptContracts = map[string][]string{
+	PromptTriage:             {"quality", "suggested_labels", "reasoning", "is_duplicate", "duplicate_reason"},
+	PromptResponse:           nil,
+	PromptRouteIssue:         {"rankings", "org", "repo", "confidence", "reasoning"},
+	PromptQualityAssessment:  {"score", "assessment", "issues", "suggestions", "reasoning"},
+	PromptExplainTransfer:    nil,
+	PromptDuplicateDetection: {"is_duplicate", "duplicate_of", "confidence", "reasoning", "related_issues"},
+	PromptSeverity:           {"priority", "category", "confidence", "signals", "reasoning"},
+}
+
+// promptFuncs are the helpers available inside prompt templates.
+var promptFuncs = template.FuncMap{
+	"truncate": truncate,
+	"indent":   indentText,
+	"join":     strings.Join,
+	"inc":      func(i int) int { return i + 1 },
+	"percent":  func(f float64) string { return fmt.Sprintf("%.0f", f*100) },
+}
+
+// PromptSet holds the parsed prompt templates used by LLMClient.
+type PromptSet struct {
+	templates map[string]*template.Template
+}
+
+var defaultPromptSet = mustLoadDefaultPrompts()
+
+// DefaultPromptSet returns the built-in prompt templates.
+func DefaultPromptSet() *PromptSet {
+	return defaultPromptSet
+}
+
+// LoadPromptSet builds a prompt set from the built-in templates, replaced by
+// "<name>.tmpl" files found in dir and then by inline overrides. Every
+// template is parsed, rendered against sample data and checked against the
+// JSON contract of its parser, so mistakes surface at load time rather than
+// on the first issue.
+func LoadPromptSet(dir string, overrides map[string]string) (*PromptSet, error) {
+	sources := make(map[string]string, len(promptContracts))
+
+	if dir != "" {
+		for name := range promptContracts {
+			data, err := os.ReadFile(filepath.Join(dir, name+".tmpl"))
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to read prompt %q: %w", name, err)
+			}
+			sources[name] = string(data)
+		}
+	}
+	for name, text := range overrides {
+		sources[name] = text
+	}
+
+	set := &PromptSet{templates: make(map[string]*template.Template, len(promptContracts))}
+	for name, tmpl := range defaultPromptSet.templates {
+		set.templates[name] = tmpl
+	}
+
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if _, ok := promptContracts[name]; !ok {
+			return nil, fmt.Errorf("unknown prompt %q (valid: %s)", name, strings.Join(promptNames(), ", "))
+		}
+		tmpl, err := parsePromptTemplate(name, sources[name])
+		if err != nil {
+			return nil, err
+		}
+		if err := validatePromptTemplate(name, tmpl); err != nil {
+			return nil, err
+		}
+		set.templates[name] = tmpl
+	}
+	return set, nil
+}
+
+// render executes the named template. A nil set renders the defaults.
+func (p *PromptSet) render(name string, data interface{}) (string, error) {
+	if p == nil {
+		p = defaultPromptSet
+	}
+	tmpl, ok := p.templates[name]
+	if !ok {
+		return "", fmt.Errorf("prompt %q not found", name)
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("failed to render prompt %q: %w", name, err)
+	}
+	return strings.TrimSpace(b.String()), nil
+}
+
+func mustLoadDefaultPrompts() *PromptSet {
+	set := &PromptSet{templates: make(map[string]*template.Template, len(promptContracts))}
+	for name := range promptContracts {
+		data, err := defaultPromptFS.ReadFile("prompts/" + name + ".tmpl")
+		if err != nil {
+			panic(fmt.Sprintf("missing built-in prompt %q: %v", name, err))
+		}
+		tmpl, err := parsePromptTemplate(name, string(data))
+		if err != nil {
+			panic(err)
+		}
+		set.templates[name] = tmpl
+	}
+	return set
+}
+
+func parsePromptTemplate(name, text string) (*template.Template, error) {
+	tmpl, err := template.New(name).Funcs(promptFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid prompt %q: %w", name, err)
+	}
+	return tmpl, nil
+}
+
+// validatePromptTemplate renders a template against sample data and checks
+// that every field of its JSON contract is still requested.
+func validatePromptTemplate(name string, tmpl *template.Template) error {
+	var b strings.Builder
+	if err := tmpl.Execute(&b, samplePromptData(name)); err != nil {
+		return fmt.Errorf("invalid prompt %q: %w", name, err)
+	}
+	rendered := b.String()
+
+	var missing []string
+	for _, field := range promptContracts[name] {
+		if !strings.Contains(rendered, `"`+field+`"`) {
+			missing = append(missing, field)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("prompt %q must request JSON fields: %s", name, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// samplePromptData returns representative data for load-time validation.
+func samplePromptData(name string) interface{} {
+	issue := &IssueInput{Title: "Sample issue", Body: "Sample body", Author: "octocat", Labels: []string{"bug"},
+		AllowedLabels: []LabelOption{{Name: "bug", Description: "Something isn't working"}},
+		LabelGroups:   []LabelGroupOption{{Name: "type", Labels: []string{"bug"}, Required: true}}}
+	similar := []SimilarIssueInput{{Number: 1, Title: "Similar issue", Body: "Similar body", URL: "https://github.com/org/repo/issues/1", Similarity: 0.9, State: "open"}}
+
+	switch name {
+	case PromptResponse:
+		return &responsePromptData{Similar: similar}
+	case PromptRouteIssue:
+		return newRoutePromptData(&RouteIssueInput{
+			Issue:        issue,
+			CurrentRepo:  "org/repo",
+			Repositories: []RepositoryCandidate{{Org: "org", Repo: "repo", Description: "Sample", Definition: "Sample docs"}},
+		})
+	case PromptExplainTransfer:
+		return &ExplainTransferInput{IssueTitle: issue.Title, IssueBody: issue.Body, TargetRepo: "org/repo", SimilarIssues: similar}
+	case PromptDuplicateDetection:
+		return &DuplicateCheckInput{CurrentIssue: issue, SimilarIssues: similar}
+	case PromptSeverity:
+		return &SeverityInput{Issue: issue, AuthorAssociation: "MEMBER", StackTraces: []string{"panic: runtime error"}}
+	default:
+		return &issuePromptData{Issue: issue}
+	}
+}
+
+func promptNames() []string {
+	names := make([]string, 0, len(promptContracts))
+	for name := range promptContracts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,100 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package ai
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDefaultPromptsSatisfyContracts(t *testing.T) {
+	for _, name := range promptNames() {
+		tmpl, ok := DefaultPromptSet().templates[name]
+		if !ok {
+			t.Fatalf("missing built-in prompt %q", name)
+		}
+		if err := validatePromptTemplate(name, tmpl); err != nil {
+			t.Errorf("built-in prompt %q: %v", name, err)
+		}
+	}
+}
+
+func TestLoadPromptSet_InlineOverride(t *testing.T) {
+	set, err := LoadPromptSet("", map[string]string{
+		PromptQualityAssessment: `Évaluez l'issue "{{.Issue.Title}}". Répondez en JSON avec "score", "assessment", "issues", "suggestions", "reasoning".`,
+	})
+	if err != nil {
+		t.Fatalf("LoadPromptSet: %v", err)
+	}
+
+	prompt, err := buildQualityAssessmentPrompt(set, &IssueInput{Title: "Crash"})
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	if !strings.HasPrefix(prompt, `Évaluez l'issue "Crash"`) {
+		t.Errorf("expected overridden prompt, got %q", prompt)
+	}
+
+	// Prompts without an override keep the built-in text.
+	triage, _ := buildTriagePromptJSON(set, &IssueInput{Title: "Crash"})
+	def, _ := buildTriagePromptJSON(nil, &IssueInput{Title: "Crash"})
+	if triage != def {
+		t.Error("expected triage prompt to fall back to the default")
+	}
+}
+
+func TestLoadPromptSet_Dir(t *testing.T) {
+	dir := t.TempDir()
+	text := "Explain the move of {{.IssueTitle}} to {{.TargetRepo}}."
+	if err := os.WriteFile(filepath.Join(dir, PromptExplainTransfer+".tmpl"), []byte(text), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	set, err := LoadPromptSet(dir, nil)
+	if err != nil {
+		t.Fatalf("LoadPromptSet: %v", err)
+	}
+	prompt, err := buildExplainTransferPrompt(set, &ExplainTransferInput{IssueTitle: "Bug", TargetRepo: "org/api"})
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	if prompt != "Explain the move of Bug to org/api." {
+		t.Errorf("unexpected prompt %q", prompt)
+	}
+
+	// Inline overrides take precedence over files.
+	set, err = LoadPromptSet(dir, map[string]string{PromptExplainTransfer: "inline"})
+	if err != nil {
+		t.Fatalf("LoadPromptSet: %v", err)
+	}
+	if prompt, _ := buildExplainTransferPrompt(set, &ExplainTransferInput{}); prompt != "inline" {
+		t.Errorf("expected inline override, got %q", prompt)
+	}
+}
+
+func TestLoadPromptSet_Invalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides map[string]string
+		wantErr   string
+	}{
+		{"unknown prompt", map[string]string{"summarize": "hi"}, `unknown prompt "summarize"`},
+		{"syntax error", map[string]string{PromptTriage: "{{.Issue.Title"}, `invalid prompt "triage"`},
+		{"unknown field", map[string]string{PromptResponse: "{{.Issue.Title}}"}, `invalid prompt "response"`},
+		{"broken contract", map[string]string{PromptDuplicateDetection: `Return {"is_duplicate": true}`}, "duplicate_of, confidence, reasoning, related_issues"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadPromptSet("", tt.overrides)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
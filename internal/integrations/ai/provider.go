@@ -10,6 +10,8 @@ import (
 	"os"
 	"strings"
 	"time"
+
+	"github.com/similigh/simili-bot/internal/utils/ratelimit"
 )
 
 // Provider identifies the active AI provider.
@@ -95,7 +97,11 @@ func callOpenAIJSON(ctx context.Context, httpClient *http.Client, apiKey, baseUR
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return &openAIStatusError{Code: resp.StatusCode, Message: extractOpenAIErrorMessage(respBody)}
+		return &openAIStatusError{
+			Code:       resp.StatusCode,
+			Message:    extractOpenAIErrorMessage(respBody),
+			RetryAfter: ratelimit.RetryAfter(resp.Header, time.Now()),
+		}
 	}
 
 	if out == nil {
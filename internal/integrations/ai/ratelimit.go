@@ -0,0 +1,44 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package ai
+
+import (
+	"github.com/similigh/simili-bot/internal/utils/ratelimit"
+)
+
+// SetRateLimits makes this client and its fallbacks wait for the shared
+// limiter of their provider before every request.
+func (l *LLMClient) SetRateLimits(limits *ratelimit.Registry) {
+	l.rateLimits = limits
+	for _, fb := range l.fallbacks {
+		fb.rateLimits = limits
+	}
+}
+
+// limiter returns the shared limiter of this client's provider, or nil.
+func (l *LLMClient) limiter() *ratelimit.Limiter {
+	return l.rateLimits.Get(string(l.provider))
+}
+
+// SetRateLimits makes this embedder and its fallbacks wait for the shared
+// limiter of their provider before every request.
+func (e *Embedder) SetRateLimits(limits *ratelimit.Registry) {
+	e.rateLimits = limits
+	for _, fb := range e.fallbacks {
+		fb.rateLimits = limits
+	}
+}
+
+// limiter returns the shared limiter of this embedder's provider, or nil.
+func (e *Embedder) limiter() *ratelimit.Limiter {
+	return e.rateLimits.Get(string(e.provider))
+}
+
+// estimateTokens roughly approximates the token count of text (~4 bytes per
+// token) so the token bucket can be charged before the real count is known.
+func estimateTokens(text string) int {
+	return len(text)/4 + 1
+}
@@ -0,0 +1,54 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package ai
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/similigh/simili-bot/internal/utils/ratelimit"
+)
+
+func TestGenerateOpenAIText_RetryAfterPausesSharedLimiter(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "7")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"error":{"message":"slow down"}}`))
+	}))
+	defer srv.Close()
+
+	limits := ratelimit.NewRegistry(map[string]ratelimit.Limit{"openai": {RequestsPerMinute: 1000}})
+	l := newTestLLMClient(srv.URL)
+	l.retryConfig = RetryConfig{MaxRetries: 0}
+	l.SetRateLimits(limits)
+
+	_, err := l.generateOpenAIText(context.Background(), "ping", 0, nil, nil)
+	if got := retryAfter(err); got != 7*time.Second {
+		t.Fatalf("retryAfter = %v, want 7s (err: %v)", got, err)
+	}
+
+	// Other clients of the same provider now wait for the pause to end.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := limits.Get("openai").Wait(ctx, 0); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Wait err = %v, want deadline exceeded", err)
+	}
+}
+
+func TestSetRateLimits_AppliesToFallbacks(t *testing.T) {
+	limits := ratelimit.NewRegistry(map[string]ratelimit.Limit{"openai": {RequestsPerMinute: 10}})
+	e := newTestEmbedder("http://unused")
+	e.fallbacks = []*Embedder{newTestEmbedder("http://unused")}
+	e.SetRateLimits(limits)
+
+	if e.limiter() == nil || e.fallbacks[0].limiter() != e.limiter() {
+		t.Fatal("fallback should share the provider limiter")
+	}
+}
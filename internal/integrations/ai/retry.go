@@ -1,7 +1,7 @@
 // Author: Kaviru Hapuarachchi
 // GitHub: https://github.com/Kavirubc
 // Created: 2026-02-15
-// Last Modified: 2026-03-05
+// Last Modified: 2026-10-18
 
 // Package ai provides provider-neutral AI integration for embeddings and LLM.
 package ai
@@ -14,6 +14,7 @@ import (
 	"math/rand"
 	"time"
 
+	"github.com/similigh/simili-bot/internal/utils/ratelimit"
 	"google.golang.org/api/googleapi"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -41,8 +42,9 @@ func DefaultRetryConfig() RetryConfig {
 // openAIStatusError carries the HTTP status code from an OpenAI API response.
 // It is checked by isRetryableError so that 429/5xx responses are retried.
 type openAIStatusError struct {
-	Code    int
-	Message string
+	Code       int
+	Message    string
+	RetryAfter time.Duration // Back-off requested by the server, if any
 }
 
 func (e *openAIStatusError) Error() string {
@@ -88,6 +90,30 @@ func isRetryableError(err error) bool {
 	return false
 }
 
+// retryAfter returns the back-off the provider requested with err through a
+// Retry-After or rate limit reset header, or 0 if none.
+func retryAfter(err error) time.Duration {
+	var oaiErr *openAIStatusError
+	if errors.As(err, &oaiErr) {
+		return oaiErr.RetryAfter
+	}
+	var gerr *googleapi.Error
+	if errors.As(err, &gerr) {
+		return ratelimit.RetryAfter(gerr.Header, time.Now())
+	}
+	return 0
+}
+
+// cappedRetryAfter is retryAfter bounded by max, so a server cannot stall a
+// run for hours with an oversized Retry-After.
+func cappedRetryAfter(err error, max time.Duration) time.Duration {
+	ra := retryAfter(err)
+	if max > 0 && ra > max {
+		return max
+	}
+	return ra
+}
+
 // withRetry executes fn with exponential backoff. It retries only on
 // transient errors (429 / 5xx). Non-retryable errors are returned
 // immediately so callers see them without unnecessary delay.
@@ -118,6 +144,11 @@ func withRetry[T any](ctx context.Context, cfg RetryConfig, operation string, fn
 		if delay > cfg.MaxDelay {
 			delay = cfg.MaxDelay
 		}
+		// Never retry sooner than the provider asked us to, but never wait
+		// longer than the maximum backoff either.
+		if ra := cappedRetryAfter(err, cfg.MaxDelay); ra > delay {
+			delay = ra
+		}
 
 		// Wait or bail if context is cancelled.
 		select {
@@ -1,7 +1,7 @@
 // Author: Kaviru Hapuarachchi
 // GitHub: https://github.com/Kavirubc
 // Created: 2026-02-15
-// Last Modified: 2026-02-17
+// Last Modified: 2026-10-18
 
 package ai
 
@@ -9,6 +9,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"testing"
 	"time"
 
@@ -148,3 +149,26 @@ func TestWithRetry_ContextCancelled(t *testing.T) {
 		t.Fatal("expected error on context cancel")
 	}
 }
+
+func TestWithRetry_CapsRetryAfter(t *testing.T) {
+	cfg := RetryConfig{MaxRetries: 1, BaseDelay: 1 * time.Millisecond, MaxDelay: 20 * time.Millisecond, JitterRatio: 0}
+
+	header := http.Header{}
+	header.Set("Retry-After", "3600")
+	calls := 0
+	start := time.Now()
+	_, err := withRetry(context.Background(), cfg, "test", func() (string, error) {
+		calls++
+		return "", &googleapi.Error{Code: 429, Message: "Rate limited", Header: header}
+	})
+
+	if err == nil {
+		t.Fatal("expected error after exhausted retries")
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 calls, got %d", calls)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Retry-After was not capped: waited %v", elapsed)
+	}
+}
@@ -0,0 +1,279 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package ai
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+// jsonSchema is a provider-neutral subset of JSON Schema used to request
+// structured output. It is generated from the Go result structs.
+type jsonSchema struct {
+	Type       string // "object" | "array" | "string" | "number" | "integer" | "boolean"
+	Properties map[string]*jsonSchema
+	Order      []string // Property order, as declared in the struct
+	Items      *jsonSchema
+	Enum       []string
+}
+
+// responseFormat selects JSON output for a generation call. Schema is
+// optional; without it the provider's plain JSON mode is used.
+type responseFormat struct {
+	Name   string
+	Schema *jsonSchema
+}
+
+// schemaFor builds a schema from a struct value using its json tags. String
+// fields may declare allowed values with an `enum:"a,b,c"` tag.
+func schemaFor(v interface{}) *jsonSchema {
+	return schemaForType(reflect.TypeOf(v))
+}
+
+func schemaForType(t reflect.Type) *jsonSchema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		s := &jsonSchema{Type: "object", Properties: map[string]*jsonSchema{}}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name := strings.Split(field.Tag.Get("json"), ",")[0]
+			if name == "-" || name == "" {
+				continue
+			}
+			prop := schemaForType(field.Type)
+			if enum := field.Tag.Get("enum"); enum != "" {
+				prop.Enum = strings.Split(enum, ",")
+			}
+			s.Properties[name] = prop
+			s.Order = append(s.Order, name)
+		}
+		return s
+	case reflect.Slice, reflect.Array:
+		return &jsonSchema{Type: "array", Items: schemaForType(t.Elem())}
+	case reflect.Bool:
+		return &jsonSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &jsonSchema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &jsonSchema{Type: "number"}
+	default:
+		return &jsonSchema{Type: "string"}
+	}
+}
+
+// toOpenAI renders the schema for OpenAI's strict json_schema response
+// format, which requires every property and forbids extra ones.
+func (s *jsonSchema) toOpenAI() map[string]interface{} {
+	out := map[string]interface{}{"type": s.Type}
+	if len(s.Enum) > 0 {
+		out["enum"] = s.Enum
+	}
+	if s.Items != nil {
+		out["items"] = s.Items.toOpenAI()
+	}
+	if s.Type == "object" {
+		props := make(map[string]interface{}, len(s.Properties))
+		for name, prop := range s.Properties {
+			props[name] = prop.toOpenAI()
+		}
+		out["properties"] = props
+		out["required"] = append([]string{}, s.Order...)
+		out["additionalProperties"] = false
+	}
+	return out
+}
+
+// toGemini renders the schema as a Gemini ResponseSchema.
+func (s *jsonSchema) toGemini() *genai.Schema {
+	out := &genai.Schema{Enum: s.Enum}
+	switch s.Type {
+	case "object":
+		out.Type = genai.TypeObject
+		out.Properties = make(map[string]*genai.Schema, len(s.Properties))
+		for name, prop := range s.Properties {
+			out.Properties[name] = prop.toGemini()
+		}
+		out.Required = append([]string{}, s.Order...)
+	case "array":
+		out.Type = genai.TypeArray
+		out.Items = s.Items.toGemini()
+	case "integer":
+		out.Type = genai.TypeInteger
+	case "number":
+		out.Type = genai.TypeNumber
+	case "boolean":
+		out.Type = genai.TypeBoolean
+	default:
+		out.Type = genai.TypeString
+	}
+	return out
+}
+
+// generateValidated requests JSON that matches the schema of out, decodes it
+// and runs validate. If the response cannot be parsed or fails validation,
+// the model is asked once more with the problems appended to the prompt.
+func (l *LLMClient) generateValidated(ctx context.Context, name, prompt string, temperature float32, out interface{}, validate func() []string) error {
+	format := &responseFormat{Name: name, Schema: schemaFor(out)}
+	target := reflect.ValueOf(out).Elem()
+
+	var problems []string
+	for attempt := 0; attempt < 2; attempt++ {
+		p := prompt
+		if len(problems) > 0 {
+			p = buildReaskPrompt(prompt, problems)
+		}
+
+		responseText, err := l.generate(ctx, p, temperature, format)
+		if err != nil {
+			return err
+		}
+
+		target.Set(reflect.Zero(target.Type()))
+		if err := unmarshalJSONResponse(responseText, out); err != nil {
+			problems = []string{err.Error()}
+			continue
+		}
+		if problems = validate(); len(problems) == 0 {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid %s response: %s", name, strings.Join(problems, "; "))
+}
+
+// buildReaskPrompt repeats the original prompt with the validation errors of
+// the previous answer.
+func buildReaskPrompt(prompt string, problems []string) string {
+	var b strings.Builder
+	b.WriteString(prompt)
+	b.WriteString("\n\nYour previous response was rejected for these reasons:\n")
+	for _, p := range problems {
+		fmt.Fprintf(&b, "- %s\n", p)
+	}
+	b.WriteString("\nRespond again with corrected JSON only.")
+	return b.String()
+}
+
+// checkUnitRange reports a problem if v is outside [0, 1].
+func checkUnitRange(field string, v float64) []string {
+	if v < 0 || v > 1 {
+		return []string{fmt.Sprintf("%s must be between 0 and 1, got %g", field, v)}
+	}
+	return nil
+}
+
+// checkEnum reports a problem if v is not one of the allowed values.
+func checkEnum(field, v string, allowed ...string) []string {
+	for _, a := range allowed {
+		if strings.EqualFold(v, a) {
+			return nil
+		}
+	}
+	return []string{fmt.Sprintf("%s must be one of %s, got %q", field, strings.Join(allowed, ", "), v)}
+}
+
+// validateTriage checks a triage response, including one pick per requested
+// label group (required groups must not be left out).
+func validateTriage(r *TriageResult, groups []LabelGroupOption) []string {
+	problems := checkEnum("quality", r.Quality, "good", "needs-improvement", "poor")
+	for _, label := range r.SuggestedLabels {
+		if strings.TrimSpace(label) == "" {
+			problems = append(problems, "suggested_labels must not contain empty labels")
+			break
+		}
+	}
+
+	picked := make(map[string]bool, len(r.LabelGroups))
+	for _, pick := range r.LabelGroups {
+		known := false
+		for _, g := range groups {
+			if strings.EqualFold(g.Name, pick.Group) {
+				known = true
+				break
+			}
+		}
+		switch {
+		case !known:
+			problems = append(problems, fmt.Sprintf("label_groups names unknown group %q", pick.Group))
+		case picked[strings.ToLower(pick.Group)]:
+			problems = append(problems, fmt.Sprintf("label_groups must pick one label for group %q, got several", pick.Group))
+		}
+		picked[strings.ToLower(pick.Group)] = true
+		problems = append(problems, checkUnitRange("label_groups confidence", pick.Confidence)...)
+	}
+	for _, g := range groups {
+		if g.Required && !picked[strings.ToLower(g.Name)] {
+			problems = append(problems, fmt.Sprintf("label_groups must include a pick for required group %q", g.Name))
+		}
+	}
+	return problems
+}
+
+// validateQuality checks a quality assessment response.
+func validateQuality(r *QualityResult) []string {
+	problems := checkUnitRange("score", r.Score)
+	problems = append(problems, checkEnum("assessment", r.Assessment, "excellent", "good", "needs-improvement", "poor")...)
+	return problems
+}
+
+// validateSeverity checks a severity estimate.
+func validateSeverity(r *SeverityResult) []string {
+	problems := checkEnum("priority", r.Priority, "P0", "P1", "P2", "P3")
+	problems = append(problems, checkEnum("category", r.Category, "crash", "data-loss", "security", "regression", "performance", "functional", "cosmetic")...)
+	problems = append(problems, checkUnitRange("confidence", r.Confidence)...)
+	return problems
+}
+
+// validateRouting checks that every ranking names a candidate repository and
+// has a confidence in range.
+func validateRouting(r *routeResponse, candidates []RepositoryCandidate) []string {
+	known := make(map[string]bool, len(candidates))
+	for _, c := range candidates {
+		known[strings.ToLower(c.Org+"/"+c.Repo)] = true
+	}
+
+	var problems []string
+	for _, ranking := range r.Rankings {
+		repo := ranking.Org + "/" + ranking.Repo
+		if !known[strings.ToLower(repo)] {
+			problems = append(problems, fmt.Sprintf("ranking for %q is not one of the available repositories", repo))
+		}
+		problems = append(problems, checkUnitRange(fmt.Sprintf("confidence for %s", repo), ranking.Confidence)...)
+	}
+	return problems
+}
+
+// validateDuplicate checks that referenced issue numbers are among the
+// candidates and that confidence is in range.
+func validateDuplicate(r *DuplicateResult, candidates []SimilarIssueInput) []string {
+	known := make(map[int]bool, len(candidates))
+	for _, c := range candidates {
+		known[c.Number] = true
+	}
+
+	problems := checkUnitRange("confidence", r.Confidence)
+	if r.IsDuplicate && !known[r.DuplicateOf] {
+		problems = append(problems, fmt.Sprintf("duplicate_of must be one of the candidate issue numbers, got %d", r.DuplicateOf))
+	}
+	for _, ref := range r.RelatedIssues {
+		if !known[ref.Number] {
+			problems = append(problems, fmt.Sprintf("related_issues contains #%d, which is not a candidate", ref.Number))
+		}
+		problems = append(problems, checkEnum(fmt.Sprintf("relationship for #%d", ref.Number), ref.Relationship, "duplicate", "related", "distinct")...)
+	}
+	return problems
+}
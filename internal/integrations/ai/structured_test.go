@@ -0,0 +1,235 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+func TestSchemaFor_OpenAI(t *testing.T) {
+	schema := schemaFor(&DuplicateResult{}).toOpenAI()
+
+	if schema["type"] != "object" || schema["additionalProperties"] != false {
+		t.Fatalf("expected strict object schema, got %v", schema)
+	}
+	wantRequired := []string{"is_duplicate", "duplicate_of", "confidence", "reasoning", "related_issues"}
+	if !reflect.DeepEqual(schema["required"], wantRequired) {
+		t.Errorf("required = %v, want %v", schema["required"], wantRequired)
+	}
+
+	props := schema["properties"].(map[string]interface{})
+	if got := props["duplicate_of"].(map[string]interface{})["type"]; got != "integer" {
+		t.Errorf("duplicate_of type = %v, want integer", got)
+	}
+	if got := props["confidence"].(map[string]interface{})["type"]; got != "number" {
+		t.Errorf("confidence type = %v, want number", got)
+	}
+
+	items := props["related_issues"].(map[string]interface{})["items"].(map[string]interface{})
+	relationship := items["properties"].(map[string]interface{})["relationship"].(map[string]interface{})
+	if !reflect.DeepEqual(relationship["enum"], []string{"duplicate", "related", "distinct"}) {
+		t.Errorf("relationship enum = %v", relationship["enum"])
+	}
+}
+
+func TestSchemaFor_Gemini(t *testing.T) {
+	schema := schemaFor(&TriageResult{}).toGemini()
+
+	if schema.Type != genai.TypeObject {
+		t.Fatalf("expected object schema, got %v", schema.Type)
+	}
+	if got := schema.Properties["suggested_labels"]; got.Type != genai.TypeArray || got.Items.Type != genai.TypeString {
+		t.Errorf("suggested_labels should be an array of strings, got %+v", got)
+	}
+	if got := schema.Properties["quality"].Enum; !reflect.DeepEqual(got, []string{"good", "needs-improvement", "poor"}) {
+		t.Errorf("quality enum = %v", got)
+	}
+	if len(schema.Required) != 6 {
+		t.Errorf("expected 6 required fields, got %v", schema.Required)
+	}
+}
+
+func TestValidateDuplicate(t *testing.T) {
+	candidates := []SimilarIssueInput{{Number: 10}, {Number: 11}}
+	tests := []struct {
+		name    string
+		result  DuplicateResult
+		wantErr string
+	}{
+		{"valid", DuplicateResult{IsDuplicate: true, DuplicateOf: 10, Confidence: 0.9, RelatedIssues: []RelatedIssueRef{{Number: 11, Relationship: "related"}}}, ""},
+		{"not a duplicate ignores duplicate_of", DuplicateResult{DuplicateOf: 0, Confidence: 0.2}, ""},
+		{"confidence out of range", DuplicateResult{Confidence: 1.5}, "confidence must be between 0 and 1"},
+		{"unknown duplicate_of", DuplicateResult{IsDuplicate: true, DuplicateOf: 99, Confidence: 0.9}, "duplicate_of must be one of the candidate"},
+		{"unknown related issue", DuplicateResult{RelatedIssues: []RelatedIssueRef{{Number: 42, Relationship: "related"}}}, "#42, which is not a candidate"},
+		{"bad relationship", DuplicateResult{RelatedIssues: []RelatedIssueRef{{Number: 10, Relationship: "similar"}}}, "relationship for #10"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems := strings.Join(validateDuplicate(&tt.result, candidates), "; ")
+			if tt.wantErr == "" && problems != "" {
+				t.Errorf("expected no problems, got %q", problems)
+			}
+			if tt.wantErr != "" && !strings.Contains(problems, tt.wantErr) {
+				t.Errorf("expected problem containing %q, got %q", tt.wantErr, problems)
+			}
+		})
+	}
+}
+
+func TestValidateRoutingAndQuality(t *testing.T) {
+	candidates := []RepositoryCandidate{{Org: "org", Repo: "api"}}
+
+	if p := validateRouting(&routeResponse{Rankings: []RepositoryRanking{{Org: "Org", Repo: "API", Confidence: 0.8}}}, candidates); len(p) != 0 {
+		t.Errorf("expected valid routing, got %v", p)
+	}
+	if p := validateRouting(&routeResponse{Rankings: []RepositoryRanking{{Org: "org", Repo: "web", Confidence: 0.8}}}, candidates); len(p) != 1 {
+		t.Errorf("expected unknown repository problem, got %v", p)
+	}
+	if p := validateQuality(&QualityResult{Score: -0.1, Assessment: "great"}); len(p) != 2 {
+		t.Errorf("expected score and assessment problems, got %v", p)
+	}
+}
+
+func TestValidateSeverity(t *testing.T) {
+	if p := validateSeverity(&SeverityResult{Priority: "p1", Category: "Regression", Confidence: 0.7}); len(p) != 0 {
+		t.Errorf("expected valid severity, got %v", p)
+	}
+	if p := validateSeverity(&SeverityResult{Priority: "P5", Category: "urgent", Confidence: 2}); len(p) != 3 {
+		t.Errorf("expected priority, category and confidence problems, got %v", p)
+	}
+}
+
+func TestValidateTriageLabelGroups(t *testing.T) {
+	groups := []LabelGroupOption{{Name: "type", Labels: []string{"type/bug"}, Required: true}, {Name: "priority", Labels: []string{"p1"}}}
+
+	valid := &TriageResult{Quality: "good", LabelGroups: []LabelGroupPick{{Group: "Type", Label: "type/bug", Confidence: 0.9}}}
+	if p := validateTriage(valid, groups); len(p) != 0 {
+		t.Errorf("expected valid triage, got %v", p)
+	}
+
+	invalid := &TriageResult{Quality: "good", LabelGroups: []LabelGroupPick{
+		{Group: "priority", Label: "p1", Confidence: 1.5},
+		{Group: "priority", Label: "p2", Confidence: 0.5},
+		{Group: "area", Label: "api", Confidence: 0.5},
+	}}
+	if p := validateTriage(invalid, groups); len(p) != 4 {
+		t.Errorf("expected confidence, duplicate pick, unknown group and missing required group problems, got %v", p)
+	}
+}
+
+// recordingChatServer serves the given chat contents in order and records
+// every request body.
+func recordingChatServer(contents ...string) (*httptest.Server, *[]map[string]interface{}) {
+	var mu sync.Mutex
+	var requests []map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		n := len(requests)
+		requests = append(requests, body)
+		mu.Unlock()
+		if n >= len(contents) {
+			n = len(contents) - 1
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(chatOKBody(contents[n]))
+	}))
+	return srv, &requests
+}
+
+func TestDetectDuplicate_ReasksOnInvalidResponse(t *testing.T) {
+	srv, requests := recordingChatServer(
+		`{"is_duplicate": true, "duplicate_of": 99, "confidence": 0.9, "reasoning": "x", "related_issues": []}`,
+		`{"is_duplicate": true, "duplicate_of": 10, "confidence": 0.9, "reasoning": "x", "related_issues": []}`,
+	)
+	defer srv.Close()
+
+	l := newTestLLMClient(srv.URL)
+	result, err := l.DetectDuplicate(context.Background(), &DuplicateCheckInput{
+		CurrentIssue:  &IssueInput{Title: "Crash"},
+		SimilarIssues: []SimilarIssueInput{{Number: 10, Title: "Crash on start"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.DuplicateOf != 10 {
+		t.Errorf("expected duplicate_of 10 after re-ask, got %d", result.DuplicateOf)
+	}
+
+	if len(*requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(*requests))
+	}
+	format := (*requests)[0]["response_format"].(map[string]interface{})
+	if format["type"] != "json_schema" {
+		t.Errorf("expected json_schema response format, got %v", format["type"])
+	}
+	reask := (*requests)[1]["messages"].([]interface{})[0].(map[string]interface{})["content"].(string)
+	if !strings.Contains(reask, "duplicate_of must be one of the candidate issue numbers, got 99") {
+		t.Errorf("expected re-ask prompt to include the validation error, got %q", reask)
+	}
+}
+
+func TestAssessQuality_FailsAfterSecondInvalidResponse(t *testing.T) {
+	srv, requests := recordingChatServer(`{"score": 7, "assessment": "good", "issues": [], "suggestions": [], "reasoning": ""}`)
+	defer srv.Close()
+
+	l := newTestLLMClient(srv.URL)
+	_, err := l.AssessQuality(context.Background(), &IssueInput{Title: "Crash"})
+	if err == nil || !strings.Contains(err.Error(), "score must be between 0 and 1") {
+		t.Fatalf("expected range error, got %v", err)
+	}
+	if len(*requests) != 2 {
+		t.Errorf("expected exactly one re-ask, got %d requests", len(*requests))
+	}
+}
+
+func TestGenerateOpenAIText_FallsBackToJSONObject(t *testing.T) {
+	var mu sync.Mutex
+	var formats []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			ResponseFormat struct {
+				Type string `json:"type"`
+			} `json:"response_format"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		formats = append(formats, body.ResponseFormat.Type)
+		mu.Unlock()
+		if body.ResponseFormat.Type == "json_schema" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error": {"message": "Invalid parameter: 'response_format' of type 'json_schema' is not supported with this model."}}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(chatOKBody(`{"score": 0.8, "assessment": "good", "issues": [], "suggestions": [], "reasoning": ""}`))
+	}))
+	defer srv.Close()
+
+	l := newTestLLMClient(srv.URL)
+	for i := 0; i < 2; i++ {
+		if _, err := l.AssessQuality(context.Background(), &IssueInput{Title: "Crash"}); err != nil {
+			t.Fatalf("call %d: unexpected error: %v", i, err)
+		}
+	}
+
+	// The first call falls back; the second goes straight to json_object.
+	want := []string{"json_schema", "json_object", "json_object"}
+	if !reflect.DeepEqual(formats, want) {
+		t.Errorf("response formats = %v, want %v", formats, want)
+	}
+}
@@ -0,0 +1,212 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package ai
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+)
+
+// ErrTokenBudgetExceeded is returned instead of calling the LLM once a
+// per-run or per-day token budget is used up.
+var ErrTokenBudgetExceeded = errors.New("LLM token budget exceeded")
+
+// Usage aggregates model usage for one pipeline run.
+type Usage struct {
+	LLMCalls         int     `json:"llm_calls"`
+	PromptTokens     int     `json:"prompt_tokens"`
+	CompletionTokens int     `json:"completion_tokens"`
+	EmbeddingCalls   int     `json:"embedding_calls"`
+	EmbeddingTokens  int     `json:"embedding_tokens"`
+	CostUSD          float64 `json:"cost_usd"`
+	LatencyMs        int64   `json:"latency_ms"` // Total time spent waiting on model calls
+	BudgetExceeded   bool    `json:"budget_exceeded,omitempty"`
+}
+
+// ModelPrice is the price of a model in USD per million tokens.
+type ModelPrice struct {
+	InputPerMillion  float64
+	OutputPerMillion float64
+}
+
+// UsageTracker records usage into a Usage value. It is attached to the
+// context passed to LLMClient and Embedder, so concurrent runs sharing one
+// client are accounted separately.
+type UsageTracker struct {
+	mu        sync.Mutex
+	usage     *Usage
+	pricing   map[string]ModelPrice
+	maxTokens int // 0 = unlimited
+}
+
+type usageTrackerKey struct{}
+
+// NewUsageTracker creates a tracker that writes into usage. maxTokens caps
+// prompt+completion tokens for the run (0 = unlimited).
+func NewUsageTracker(usage *Usage, pricing map[string]ModelPrice, maxTokens int) *UsageTracker {
+	return &UsageTracker{usage: usage, pricing: pricing, maxTokens: maxTokens}
+}
+
+// WithUsageTracker returns a context that carries the tracker.
+func WithUsageTracker(ctx context.Context, t *UsageTracker) context.Context {
+	return context.WithValue(ctx, usageTrackerKey{}, t)
+}
+
+// usageTrackerFrom returns the tracker carried by ctx, or nil.
+func usageTrackerFrom(ctx context.Context) *UsageTracker {
+	if ctx == nil {
+		return nil
+	}
+	t, _ := ctx.Value(usageTrackerKey{}).(*UsageTracker)
+	return t
+}
+
+// checkBudget reports ErrTokenBudgetExceeded once the run budget is used up.
+func (t *UsageTracker) checkBudget() error {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.maxTokens > 0 && t.usage.PromptTokens+t.usage.CompletionTokens >= t.maxTokens {
+		t.usage.BudgetExceeded = true
+		return ErrTokenBudgetExceeded
+	}
+	return nil
+}
+
+// markBudgetExceeded flags the run when another budget (e.g. daily) blocked a call.
+func (t *UsageTracker) markBudgetExceeded() {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	t.usage.BudgetExceeded = true
+	t.mu.Unlock()
+}
+
+// addLLM records one generation call.
+func (t *UsageTracker) addLLM(model string, promptTokens, completionTokens int, latency time.Duration) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.usage.LLMCalls++
+	t.usage.PromptTokens += promptTokens
+	t.usage.CompletionTokens += completionTokens
+	t.usage.LatencyMs += latency.Milliseconds()
+	if price, ok := t.pricing[model]; ok {
+		t.usage.CostUSD += (float64(promptTokens)*price.InputPerMillion + float64(completionTokens)*price.OutputPerMillion) / 1e6
+	}
+}
+
+// addEmbedding records one embedding call.
+func (t *UsageTracker) addEmbedding(model string, tokens int, latency time.Duration) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.usage.EmbeddingCalls++
+	t.usage.EmbeddingTokens += tokens
+	t.usage.LatencyMs += latency.Milliseconds()
+	if price, ok := t.pricing[model]; ok {
+		t.usage.CostUSD += float64(tokens) * price.InputPerMillion / 1e6
+	}
+}
+
+// DailyUsageStore persists token usage per UTC day (YYYY-MM-DD), so a daily
+// budget holds across processes. Each GitHub Action run is a new process.
+type DailyUsageStore interface {
+	GetDailyUsage(ctx context.Context, day string) (int, error)
+	AddDailyUsage(ctx context.Context, day string, tokens int) error
+}
+
+// dailyBudget caps LLM tokens per UTC day. Without a store, usage is counted
+// for the lifetime of the client only. With one, the day's stored usage is
+// loaded before the first call and this client's usage is added back by
+// flush.
+type dailyBudget struct {
+	mu      sync.Mutex
+	limit   int
+	day     string
+	used    int
+	now     func() time.Time
+	store   DailyUsageStore
+	loaded  bool // Stored usage for day has been read
+	pending int  // Tokens used since the last flush
+}
+
+func (b *dailyBudget) roll() {
+	today := b.now().UTC().Format("2006-01-02")
+	if b.day != today {
+		b.day = today
+		b.used = 0
+		b.loaded = false
+		b.pending = 0
+	}
+}
+
+// load reads the day's stored usage once. A failed read is logged and
+// treated as no usage, so an unreachable store never blocks triage.
+func (b *dailyBudget) load(ctx context.Context) {
+	if b.store == nil || b.loaded {
+		return
+	}
+	b.loaded = true
+	stored, err := b.store.GetDailyUsage(ctx, b.day)
+	if err != nil {
+		log.Printf("[ai] Failed to read daily token usage: %v (non-blocking)", err)
+		return
+	}
+	b.used += stored
+}
+
+func (b *dailyBudget) check(ctx context.Context) error {
+	if b == nil || b.limit <= 0 {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.roll()
+	b.load(ctx)
+	if b.used >= b.limit {
+		return ErrTokenBudgetExceeded
+	}
+	return nil
+}
+
+func (b *dailyBudget) add(tokens int) {
+	if b == nil || b.limit <= 0 {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.roll()
+	b.used += tokens
+	b.pending += tokens
+}
+
+// flush adds the tokens used since the last flush to the store.
+func (b *dailyBudget) flush(ctx context.Context) error {
+	if b == nil || b.store == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.pending == 0 {
+		return nil
+	}
+	if err := b.store.AddDailyUsage(ctx, b.day, b.pending); err != nil {
+		return err
+	}
+	b.pending = 0
+	return nil
+}
@@ -0,0 +1,160 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package ai
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// usageServer serves chat completions and embeddings that report token usage.
+func usageServer() (*httptest.Server, *atomic.Int32) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/embeddings" {
+			_, _ = w.Write([]byte(`{"data":[{"embedding":[0.1,0.2]}],"usage":{"prompt_tokens":40}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"choices":[{"message":{"content":"pong"}}],"usage":{"prompt_tokens":1000,"completion_tokens":200}}`))
+	}))
+	return srv, &calls
+}
+
+func TestUsageTracker_RecordsTokensAndCost(t *testing.T) {
+	srv, _ := usageServer()
+	defer srv.Close()
+
+	var usage Usage
+	pricing := map[string]ModelPrice{
+		"gpt-4o-mini":            {InputPerMillion: 0.15, OutputPerMillion: 0.60},
+		"text-embedding-3-small": {InputPerMillion: 0.02},
+	}
+	ctx := WithUsageTracker(context.Background(), NewUsageTracker(&usage, pricing, 0))
+
+	l := newTestLLMClient(srv.URL)
+	if _, err := l.generateText(ctx, "ping", 0, false); err != nil {
+		t.Fatalf("generateText: %v", err)
+	}
+	e := newTestEmbedder(srv.URL)
+	if _, err := e.Embed(ctx, "hello"); err != nil {
+		t.Fatalf("Embed: %v", err)
+	}
+
+	if usage.LLMCalls != 1 || usage.PromptTokens != 1000 || usage.CompletionTokens != 200 {
+		t.Errorf("unexpected LLM usage: %+v", usage)
+	}
+	if usage.EmbeddingCalls != 1 || usage.EmbeddingTokens != 40 {
+		t.Errorf("unexpected embedding usage: %+v", usage)
+	}
+	wantCost := (1000*0.15 + 200*0.60 + 40*0.02) / 1e6
+	if math.Abs(usage.CostUSD-wantCost) > 1e-12 {
+		t.Errorf("CostUSD = %g, want %g", usage.CostUSD, wantCost)
+	}
+}
+
+func TestUsageTracker_RunBudget(t *testing.T) {
+	srv, calls := usageServer()
+	defer srv.Close()
+
+	var usage Usage
+	ctx := WithUsageTracker(context.Background(), NewUsageTracker(&usage, nil, 1000))
+	l := newTestLLMClient(srv.URL)
+
+	if _, err := l.generateText(ctx, "ping", 0, false); err != nil {
+		t.Fatalf("first call should succeed: %v", err)
+	}
+	if _, err := l.generateText(ctx, "ping", 0, false); !errors.Is(err, ErrTokenBudgetExceeded) {
+		t.Fatalf("expected ErrTokenBudgetExceeded, got %v", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("expected the provider to be called once, got %d", got)
+	}
+	if !usage.BudgetExceeded {
+		t.Error("expected BudgetExceeded to be set")
+	}
+}
+
+func TestDailyBudget_ResetsAtMidnightUTC(t *testing.T) {
+	srv, _ := usageServer()
+	defer srv.Close()
+
+	now := time.Date(2026, 10, 18, 23, 0, 0, 0, time.UTC)
+	l := newTestLLMClient(srv.URL)
+	l.SetDailyTokenBudget(1200, nil)
+	l.daily.now = func() time.Time { return now }
+
+	if _, err := l.generateText(context.Background(), "ping", 0, false); err != nil {
+		t.Fatalf("first call should succeed: %v", err)
+	}
+	if _, err := l.generateText(context.Background(), "ping", 0, false); !errors.Is(err, ErrTokenBudgetExceeded) {
+		t.Fatalf("expected daily budget error, got %v", err)
+	}
+
+	now = now.Add(2 * time.Hour)
+	if _, err := l.generateText(context.Background(), "ping", 0, false); err != nil {
+		t.Fatalf("budget should reset on a new day: %v", err)
+	}
+}
+
+// memoryUsageStore is a DailyUsageStore kept in memory.
+type memoryUsageStore map[string]int
+
+func (m memoryUsageStore) GetDailyUsage(_ context.Context, day string) (int, error) {
+	return m[day], nil
+}
+
+func (m memoryUsageStore) AddDailyUsage(_ context.Context, day string, tokens int) error {
+	m[day] += tokens
+	return nil
+}
+
+func TestDailyBudget_SharedAcrossClients(t *testing.T) {
+	srv, calls := usageServer()
+	defer srv.Close()
+
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	store := memoryUsageStore{}
+
+	// Each client stands for one Action run: a new process.
+	first := newTestLLMClient(srv.URL)
+	first.SetDailyTokenBudget(2000, store)
+	first.daily.now = func() time.Time { return now }
+	if _, err := first.generateText(context.Background(), "ping", 0, false); err != nil {
+		t.Fatalf("first run should succeed: %v", err)
+	}
+	if err := first.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if got := store["2026-10-18"]; got != 1200 {
+		t.Fatalf("stored usage after the first run = %d, want 1200", got)
+	}
+
+	second := newTestLLMClient(srv.URL)
+	second.SetDailyTokenBudget(2000, store)
+	second.daily.now = func() time.Time { return now }
+	if _, err := second.generateText(context.Background(), "ping", 0, false); err != nil {
+		t.Fatalf("second run should succeed: %v", err)
+	}
+	if _, err := second.generateText(context.Background(), "ping", 0, false); !errors.Is(err, ErrTokenBudgetExceeded) {
+		t.Fatalf("expected daily budget error once stored and new usage reach the limit, got %v", err)
+	}
+	_ = second.Close()
+
+	if got := store["2026-10-18"]; got != 2400 {
+		t.Errorf("stored usage after the second run = %d, want 2400", got)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("expected 2 provider calls, got %d", got)
+	}
+}
@@ -1,7 +1,7 @@
 // Author: Kaviru Hapuarachchi
 // GitHub: https://github.com/Kavirubc
 // Created: 2026-02-02
-// Last Modified: 2026-02-02
+// Last Modified: 2026-10-18
 
 package github
 
@@ -10,12 +10,21 @@ import (
 	"net/http"
 
 	"github.com/google/go-github/v60/github"
+	"github.com/similigh/simili-bot/internal/utils/ratelimit"
 	"golang.org/x/oauth2"
 )
 
 // NewClient creates a new GitHub client using the provided token.
 // If token is empty, it returns an unauthenticated client.
 func NewClient(ctx context.Context, token string) *Client {
+	return NewRateLimitedClient(ctx, token, nil)
+}
+
+// NewRateLimitedClient creates a GitHub client whose REST and GraphQL calls
+// wait for limiter and back off when GitHub sends Retry-After or an exhausted
+// X-RateLimit-Reset. With a nil limiter calls are not throttled, but
+// Retry-After is still honoured.
+func NewRateLimitedClient(ctx context.Context, token string, limiter *ratelimit.Limiter) *Client {
 	var tc *http.Client
 	var graphql *GraphQLClient
 
@@ -24,6 +33,17 @@ func NewClient(ctx context.Context, token string) *Client {
 			&oauth2.Token{AccessToken: token},
 		)
 		tc = oauth2.NewClient(ctx, ts)
+	}
+
+	if limiter == nil {
+		limiter = ratelimit.Unlimited()
+	}
+	if tc == nil {
+		tc = &http.Client{}
+	}
+	tc.Transport = &ratelimit.Transport{Base: tc.Transport, Limiter: limiter}
+
+	if token != "" {
 		// Initialize GraphQL client for authenticated operations
 		graphql = NewGraphQLClient(tc, token)
 	}
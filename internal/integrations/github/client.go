@@ -1,22 +1,40 @@
 // Author: Kaviru Hapuarachchi
 // GitHub: https://github.com/Kavirubc
 // Created: 2026-02-02
-// Last Modified: 2026-02-02
+// Last Modified: 2026-10-18
 
 package github
 
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/google/go-github/v60/github"
 )
 
+// labelCacheTTL is how long ListLabels reuses a repository's labels.
+const labelCacheTTL = 10 * time.Minute
+
 // Client wraps the GitHub API client.
 type Client struct {
 	client  *github.Client
 	graphql *GraphQLClient
+
+	labelsMu sync.Mutex
+	labels   map[string]cachedLabels // keyed by "org/repo"
+
+	loginOnce sync.Once
+	login     string // Authenticated user, "" if unknown
+}
+
+// cachedLabels is a repository's label set as last fetched.
+type cachedLabels struct {
+	labels    []*github.Label
+	fetchedAt time.Time
 }
 
 // GetIssue fetches issue details.
@@ -58,12 +76,33 @@ func (c *Client) AddLabels(ctx context.Context, org, repo string, number int, la
 	return nil
 }
 
+// AddAssignees assigns users to an issue.
+func (c *Client) AddAssignees(ctx context.Context, org, repo string, number int, assignees []string) error {
+	if len(assignees) == 0 {
+		return fmt.Errorf("assignees cannot be empty")
+	}
+
+	_, _, err := c.client.Issues.AddAssignees(ctx, org, repo, number, assignees)
+	if err != nil {
+		return fmt.Errorf("failed to add assignees: %w", err)
+	}
+	return nil
+}
+
 // CloseIssue closes a GitHub issue by setting its state to "closed".
 func (c *Client) CloseIssue(ctx context.Context, org, repo string, number int) error {
-	closed := "closed"
-	_, _, err := c.client.Issues.Edit(ctx, org, repo, number, &github.IssueRequest{
-		State: &closed,
-	})
+	return c.CloseIssueWithReason(ctx, org, repo, number, "")
+}
+
+// CloseIssueWithReason closes an issue with a state reason such as
+// "completed" or "not_planned". An empty reason leaves GitHub's default
+// ("completed").
+func (c *Client) CloseIssueWithReason(ctx context.Context, org, repo string, number int, reason string) error {
+	req := &github.IssueRequest{State: github.String("closed")}
+	if reason != "" {
+		req.StateReason = github.String(reason)
+	}
+	_, _, err := c.client.Issues.Edit(ctx, org, repo, number, req)
 	if err != nil {
 		return fmt.Errorf("failed to close issue #%d: %w", number, err)
 	}
@@ -158,6 +197,41 @@ func (c *Client) ListComments(ctx context.Context, org, repo string, number int,
 	return comments, resp, nil
 }
 
+// ListLabels fetches all labels defined in a repository, with their
+// descriptions. Results are cached on the client for a few minutes, since
+// every triage run needs them.
+func (c *Client) ListLabels(ctx context.Context, org, repo string) ([]*github.Label, error) {
+	key := strings.ToLower(org + "/" + repo)
+	c.labelsMu.Lock()
+	cached, ok := c.labels[key]
+	c.labelsMu.Unlock()
+	if ok && time.Since(cached.fetchedAt) < labelCacheTTL {
+		return cached.labels, nil
+	}
+
+	var allLabels []*github.Label
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		labels, resp, err := c.client.Issues.ListLabels(ctx, org, repo, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list labels for %s/%s: %w", org, repo, err)
+		}
+		allLabels = append(allLabels, labels...)
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	c.labelsMu.Lock()
+	if c.labels == nil {
+		c.labels = make(map[string]cachedLabels)
+	}
+	c.labels[key] = cachedLabels{labels: allLabels, fetchedAt: time.Now()}
+	c.labelsMu.Unlock()
+	return allLabels, nil
+}
+
 // GetFileContent fetches the raw content of a file from a repository.
 // ref can be a branch, tag, or commit SHA. If empty, the default branch is used.
 func (c *Client) GetFileContent(ctx context.Context, org, repo, path, ref string) ([]byte, error) {
@@ -182,6 +256,27 @@ func (c *Client) GetFileContent(ctx context.Context, org, repo, path, ref string
 	return []byte(content), nil
 }
 
+// ListDirectory returns the paths of the files directly inside a repository
+// directory.
+func (c *Client) ListDirectory(ctx context.Context, org, repo, path, ref string) ([]string, error) {
+	opts := &github.RepositoryContentGetOptions{
+		Ref: ref,
+	}
+
+	_, entries, _, err := c.client.Repositories.GetContents(ctx, org, repo, path, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list directory %s/%s/%s: %w", org, repo, path, err)
+	}
+
+	var paths []string
+	for _, e := range entries {
+		if e.GetType() == "file" {
+			paths = append(paths, e.GetPath())
+		}
+	}
+	return paths, nil
+}
+
 // ListIssueEvents fetches timeline events for a specific issue.
 // This includes events like transferred, closed, reopened, labeled, etc.
 func (c *Client) ListIssueEvents(ctx context.Context, org, repo string, number int) ([]*github.IssueEvent, error) {
@@ -208,6 +303,16 @@ func (c *Client) ListIssueEvents(ctx context.Context, org, repo string, number i
 	return allEvents, nil
 }
 
+// ListIssueEdits returns the most recent edits of an issue's body, oldest
+// first. The REST API does not expose edits, so this needs the GraphQL
+// client.
+func (c *Client) ListIssueEdits(ctx context.Context, org, repo string, number int) ([]IssueEdit, error) {
+	if c.graphql == nil {
+		return nil, fmt.Errorf("listing issue edits requires authenticated GraphQL client")
+	}
+	return c.graphql.ListIssueEdits(ctx, org, repo, number)
+}
+
 // GetPullRequest fetches full PR details including changed file count.
 func (c *Client) GetPullRequest(ctx context.Context, org, repo string, number int) (*github.PullRequest, error) {
 	pr, _, err := c.client.PullRequests.Get(ctx, org, repo, number)
@@ -237,6 +342,37 @@ func (c *Client) ListPullRequestFiles(ctx context.Context, org, repo string, num
 	return allFiles, nil
 }
 
+// ListLinkedPullRequests returns the numbers of pull requests in the same
+// repository that reference an issue, from its cross-referenced timeline events.
+func (c *Client) ListLinkedPullRequests(ctx context.Context, org, repo string, number int) ([]int, error) {
+	var numbers []int
+	opts := &github.ListOptions{PerPage: 100}
+
+	for {
+		events, resp, err := c.client.Issues.ListIssueTimeline(ctx, org, repo, number, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list timeline for #%d in %s/%s: %w", number, org, repo, err)
+		}
+		for _, e := range events {
+			if e.GetEvent() != "cross-referenced" || e.Source == nil || e.Source.Issue == nil {
+				continue
+			}
+			src := e.Source.Issue
+			sameRepo := strings.EqualFold(src.GetRepository().GetFullName(), org+"/"+repo) ||
+				strings.HasSuffix(strings.ToLower(src.GetRepositoryURL()), strings.ToLower("/repos/"+org+"/"+repo))
+			if src.IsPullRequest() && sameRepo && !slices.Contains(numbers, src.GetNumber()) {
+				numbers = append(numbers, src.GetNumber())
+			}
+		}
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return numbers, nil
+}
+
 // ListIssueCommentReactions fetches all reactions on a specific issue comment.
 func (c *Client) ListIssueCommentReactions(ctx context.Context, org, repo string, commentID int64) ([]*github.Reaction, error) {
 	var allReactions []*github.Reaction
@@ -1,15 +1,32 @@
 // Author: Kaviru Hapuarachchi
 // GitHub: https://github.com/Kavirubc
 // Created: 2026-02-02
-// Last Modified: 2026-02-02
+// Last Modified: 2026-10-18
 
 package github
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/similigh/simili-bot/internal/utils/ratelimit"
 )
 
+func TestNewClientHonoursRetryAfter(t *testing.T) {
+	// Retry-After must be respected even without a configured rate limit.
+	for _, token := range []string{"", "token"} {
+		c := NewClient(context.Background(), token)
+		if _, ok := c.client.Client().Transport.(*ratelimit.Transport); !ok {
+			t.Errorf("token %q: expected the Retry-After transport, got %T", token, c.client.Client().Transport)
+		}
+	}
+}
+
 func TestCreateCommentValidation(t *testing.T) {
 	// Test that CreateComment rejects empty body
 	client := &Client{client: nil} // nil client for validation testing
@@ -83,3 +100,81 @@ func TestRemoveLabelValidation(t *testing.T) {
 		t.Error("Expected error for whitespace-only label")
 	}
 }
+
+func TestListLabelsIsCached(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, `[{"name": "bug", "description": "Something isn't working"}, {"name": "area/api"}]`)
+	}))
+	defer server.Close()
+
+	gh := github.NewClient(nil)
+	gh.BaseURL, _ = url.Parse(server.URL + "/")
+	client := &Client{client: gh}
+
+	for i := 0; i < 2; i++ {
+		labels, err := client.ListLabels(context.Background(), "Org", "repo")
+		if err != nil {
+			t.Fatalf("ListLabels() error = %v", err)
+		}
+		if len(labels) != 2 || labels[0].GetDescription() != "Something isn't working" {
+			t.Fatalf("Unexpected labels %v", labels)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("Expected one request, got %d", requests)
+	}
+}
+
+func TestListLinkedPullRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[
+			{"event": "labeled"},
+			{"event": "cross-referenced", "source": {"issue": {"number": 12, "pull_request": {"url": "x"}, "repository": {"full_name": "org/repo"}}}},
+			{"event": "cross-referenced", "source": {"issue": {"number": 13, "repository": {"full_name": "org/repo"}}}},
+			{"event": "cross-referenced", "source": {"issue": {"number": 14, "pull_request": {"url": "x"}, "repository": {"full_name": "org/other"}}}},
+			{"event": "cross-referenced", "source": {"issue": {"number": 12, "pull_request": {"url": "x"}, "repository_url": "https://api.github.com/repos/Org/Repo"}}}
+		]`)
+	}))
+	defer server.Close()
+
+	gh := github.NewClient(nil)
+	gh.BaseURL, _ = url.Parse(server.URL + "/")
+	client := &Client{client: gh}
+
+	numbers, err := client.ListLinkedPullRequests(context.Background(), "org", "repo", 1)
+	if err != nil {
+		t.Fatalf("ListLinkedPullRequests() error = %v", err)
+	}
+	if len(numbers) != 1 || numbers[0] != 12 {
+		t.Errorf("Expected [12], got %v", numbers)
+	}
+}
+
+func TestListDirectory(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/org/repo/contents/.github/ISSUE_TEMPLATE" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		fmt.Fprint(w, `[
+			{"type": "file", "path": ".github/ISSUE_TEMPLATE/bug.yml"},
+			{"type": "dir", "path": ".github/ISSUE_TEMPLATE/old"},
+			{"type": "file", "path": ".github/ISSUE_TEMPLATE/config.yml"}
+		]`)
+	}))
+	defer server.Close()
+
+	gh := github.NewClient(nil)
+	gh.BaseURL, _ = url.Parse(server.URL + "/")
+	client := &Client{client: gh}
+
+	paths, err := client.ListDirectory(context.Background(), "org", "repo", ".github/ISSUE_TEMPLATE", "")
+	if err != nil {
+		t.Fatalf("ListDirectory() error = %v", err)
+	}
+	want := []string{".github/ISSUE_TEMPLATE/bug.yml", ".github/ISSUE_TEMPLATE/config.yml"}
+	if len(paths) != 2 || paths[0] != want[0] || paths[1] != want[1] {
+		t.Errorf("Expected %v, got %v", want, paths)
+	}
+}
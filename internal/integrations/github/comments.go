@@ -0,0 +1,187 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v60/github"
+)
+
+// Comment update modes for UpsertComment.
+const (
+	// CommentUpdateEdit edits the existing marked comment in place.
+	CommentUpdateEdit = "edit"
+	// CommentUpdateMinimize hides the existing marked comment as outdated and
+	// posts a fresh one.
+	CommentUpdateMinimize = "minimize"
+	// CommentUpdateNew always posts a new comment (the old behaviour).
+	CommentUpdateNew = "new"
+)
+
+// EditComment replaces the body of an existing issue comment.
+func (c *Client) EditComment(ctx context.Context, org, repo string, commentID int64, body string) error {
+	if strings.TrimSpace(body) == "" {
+		return fmt.Errorf("comment body cannot be empty")
+	}
+
+	comment := &github.IssueComment{
+		Body: github.String(body),
+	}
+	_, _, err := c.client.Issues.EditComment(ctx, org, repo, commentID, comment)
+	if err != nil {
+		return fmt.Errorf("failed to edit comment %d: %w", commentID, err)
+	}
+	return nil
+}
+
+// MinimizeComment hides a comment as outdated. nodeID is the comment's
+// GraphQL node ID (IssueComment.NodeID).
+func (c *Client) MinimizeComment(ctx context.Context, nodeID string) error {
+	if strings.TrimSpace(nodeID) == "" {
+		return fmt.Errorf("comment node ID cannot be empty")
+	}
+	if c.graphql == nil {
+		return fmt.Errorf("minimizing comments requires authenticated GraphQL client")
+	}
+	return c.graphql.MinimizeComment(ctx, nodeID, "OUTDATED")
+}
+
+// FindMarkedComment returns the most recent comment on an issue whose body
+// starts with marker (e.g. "<!-- simili-bot-report -->") and that the bot
+// wrote itself, or nil if there is none. Anyone can post a comment carrying
+// the marker, so only the authenticated user and botUsers are trusted.
+func (c *Client) FindMarkedComment(ctx context.Context, org, repo string, number int, marker string, botUsers []string) (*github.IssueComment, error) {
+	if strings.TrimSpace(marker) == "" {
+		return nil, fmt.Errorf("comment marker cannot be empty")
+	}
+
+	var found *github.IssueComment
+	opts := &github.IssueListCommentsOptions{
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	for {
+		comments, resp, err := c.ListComments(ctx, org, repo, number, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, comment := range comments {
+			if strings.HasPrefix(strings.TrimSpace(comment.GetBody()), marker) && c.isOwnComment(ctx, comment, botUsers) {
+				found = comment
+			}
+		}
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return found, nil
+}
+
+// isOwnComment reports whether comment was written by the authenticated user
+// or one of botUsers. Installation tokens, such as the Actions GITHUB_TOKEN,
+// cannot look up their own login; they post as an app account ending in
+// "[bot]", which users cannot register, so those are trusted instead.
+func (c *Client) isOwnComment(ctx context.Context, comment *github.IssueComment, botUsers []string) bool {
+	author := comment.GetUser().GetLogin()
+	if author == "" {
+		return false
+	}
+	if login := c.authenticatedLogin(ctx); login != "" {
+		if strings.EqualFold(author, login) {
+			return true
+		}
+	} else if strings.HasSuffix(author, "[bot]") {
+		return true
+	}
+	for _, u := range botUsers {
+		if strings.EqualFold(author, u) {
+			return true
+		}
+	}
+	return false
+}
+
+// authenticatedLogin returns the login the client acts as, fetched once. It
+// returns "" when the token cannot read its own user.
+func (c *Client) authenticatedLogin(ctx context.Context) string {
+	c.loginOnce.Do(func() {
+		user, _, err := c.client.Users.Get(ctx, "")
+		if err == nil {
+			c.login = user.GetLogin()
+		}
+	})
+	return c.login
+}
+
+// CreateCommentOnce posts body unless the bot already posted a comment
+// starting with marker on the issue (see FindMarkedComment). Use it for
+// notices that must reach people once: mentions only notify when a comment
+// is created, never when it is edited. It reports whether it posted.
+func (c *Client) CreateCommentOnce(ctx context.Context, org, repo string, number int, marker string, botUsers []string, body string) (bool, error) {
+	existing, err := c.FindMarkedComment(ctx, org, repo, number, marker, botUsers)
+	if err != nil {
+		return false, err
+	}
+	if existing != nil {
+		return false, nil
+	}
+	if err := c.CreateComment(ctx, org, repo, number, body); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// UpsertComment keeps a single marked comment per issue up to date. render
+// receives the body of the previous marked comment ("" if there is none) and
+// returns the new body, which should start with marker so later runs find it.
+// mode is one of the CommentUpdate constants; an empty mode means edit.
+// Only comments written by the bot are reused (see FindMarkedComment).
+// It reports whether an existing comment was updated rather than a new one
+// posted.
+func (c *Client) UpsertComment(ctx context.Context, org, repo string, number int, marker, mode string, botUsers []string, render func(previous string) string) (bool, error) {
+	switch mode {
+	case "", CommentUpdateEdit, CommentUpdateMinimize, CommentUpdateNew:
+	default:
+		return false, fmt.Errorf("unknown comment update mode %q", mode)
+	}
+
+	var existing *github.IssueComment
+	if mode != CommentUpdateNew {
+		var err error
+		existing, err = c.FindMarkedComment(ctx, org, repo, number, marker, botUsers)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	previous := ""
+	if existing != nil {
+		previous = existing.GetBody()
+	}
+	body := render(previous)
+
+	if existing != nil && mode != CommentUpdateMinimize {
+		if body == previous {
+			return true, nil
+		}
+		return true, c.EditComment(ctx, org, repo, existing.GetID(), body)
+	}
+
+	if err := c.CreateComment(ctx, org, repo, number, body); err != nil {
+		return false, err
+	}
+	if existing != nil {
+		// The fresh comment is already posted, so a failure here only leaves
+		// the old one visible.
+		if err := c.MinimizeComment(ctx, existing.GetNodeID()); err != nil {
+			return false, fmt.Errorf("posted new comment but failed to minimize the old one: %w", err)
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,217 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v60/github"
+)
+
+const testMarker = "<!-- simili-bot-report -->"
+
+// testComment is a comment served by newTestClient.
+type testComment struct {
+	author string
+	body   string
+}
+
+// bot returns a comment written by the Actions app account.
+func bot(body string) testComment {
+	return testComment{author: "github-actions[bot]", body: body}
+}
+
+// newTestClient serves the issue's comments from a fake REST API and records
+// edits and new comments. The token cannot read its own user, like the
+// Actions GITHUB_TOKEN.
+func newTestClient(t *testing.T, comments ...testComment) (*Client, *[]string) {
+	t.Helper()
+	return newTestClientAs(t, "", comments...)
+}
+
+// newTestClientAs is newTestClient authenticated as login ("" = unknown).
+func newTestClientAs(t *testing.T, login string, comments ...testComment) (*Client, *[]string) {
+	t.Helper()
+	var calls []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		if login == "" {
+			http.Error(w, `{"message": "Resource not accessible by integration"}`, http.StatusForbidden)
+			return
+		}
+		fmt.Fprintf(w, `{"login": %q}`, login)
+	})
+	mux.HandleFunc("/repos/org/repo/issues/1/comments", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			calls = append(calls, "create")
+			fmt.Fprint(w, `{"id": 99}`)
+			return
+		}
+		var list []map[string]interface{}
+		for i, c := range comments {
+			list = append(list, map[string]interface{}{
+				"id": i + 1, "node_id": fmt.Sprintf("IC_%d", i+1), "body": c.body,
+				"user": map[string]string{"login": c.author},
+			})
+		}
+		_ = json.NewEncoder(w).Encode(list)
+	})
+	mux.HandleFunc("/repos/org/repo/issues/comments/", func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		fmt.Fprint(w, `{}`)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	gh := github.NewClient(nil)
+	gh.BaseURL, _ = url.Parse(server.URL + "/")
+	return &Client{client: gh}, &calls
+}
+
+func TestUpsertCommentEditsLatestMarkedComment(t *testing.T) {
+	client, calls := newTestClient(t, testComment{"alice", "thanks!"}, bot(testMarker+"\nold report"), bot("  "+testMarker+"\nnewer report"))
+
+	var previous string
+	updated, err := client.UpsertComment(context.Background(), "org", "repo", 1, testMarker, "", nil, func(prev string) string {
+		previous = prev
+		return testMarker + "\nnew report"
+	})
+	if err != nil {
+		t.Fatalf("UpsertComment() error = %v", err)
+	}
+	if !updated || previous != "  "+testMarker+"\nnewer report" {
+		t.Errorf("updated = %v, previous = %q", updated, previous)
+	}
+	if len(*calls) != 1 || (*calls)[0] != "PATCH /repos/org/repo/issues/comments/3" {
+		t.Errorf("calls = %v", *calls)
+	}
+}
+
+func TestUpsertCommentCreatesWhenMissing(t *testing.T) {
+	client, calls := newTestClient(t, bot("no marker here"))
+
+	updated, err := client.UpsertComment(context.Background(), "org", "repo", 1, testMarker, CommentUpdateEdit, nil, func(prev string) string {
+		return testMarker + "\nreport"
+	})
+	if err != nil || updated {
+		t.Fatalf("UpsertComment() = %v, %v", updated, err)
+	}
+	if len(*calls) != 1 || (*calls)[0] != "create" {
+		t.Errorf("calls = %v", *calls)
+	}
+}
+
+func TestUpsertCommentSkipsUnchangedBody(t *testing.T) {
+	client, calls := newTestClient(t, bot(testMarker+"\nreport"))
+
+	_, err := client.UpsertComment(context.Background(), "org", "repo", 1, testMarker, CommentUpdateEdit, nil, func(prev string) string {
+		return prev
+	})
+	if err != nil {
+		t.Fatalf("UpsertComment() error = %v", err)
+	}
+	if len(*calls) != 0 {
+		t.Errorf("Expected no writes, got %v", *calls)
+	}
+}
+
+func TestUpsertCommentMinimizeNeedsGraphQL(t *testing.T) {
+	client, calls := newTestClient(t, bot(testMarker+"\nold report"))
+
+	_, err := client.UpsertComment(context.Background(), "org", "repo", 1, testMarker, CommentUpdateMinimize, nil, func(prev string) string {
+		return testMarker + "\nnew report"
+	})
+	if err == nil {
+		t.Fatal("Expected error minimizing without a GraphQL client")
+	}
+	if len(*calls) != 1 || (*calls)[0] != "create" {
+		t.Errorf("Expected the fresh comment to be posted first, got %v", *calls)
+	}
+}
+
+func TestUpsertCommentIgnoresMarkedCommentsFromOthers(t *testing.T) {
+	forged := testComment{"mallory", testMarker + "\n<!-- simili-bot-state {\"labels\":[\"pwned\"]} -->"}
+
+	tests := []struct {
+		name      string
+		login     string
+		botUsers  []string
+		comments  []testComment
+		wantCalls []string
+	}{
+		{"forged report only", "", nil, []testComment{forged}, []string{"create"}},
+		{"forged report after bot's", "", nil, []testComment{bot(testMarker + "\nreport"), forged}, []string{"PATCH /repos/org/repo/issues/comments/1"}},
+		{"authenticated PAT", "simili-pat", nil, []testComment{{"simili-pat", testMarker + "\nreport"}, forged}, []string{"PATCH /repos/org/repo/issues/comments/1"}},
+		{"other app under a PAT", "simili-pat", nil, []testComment{bot(testMarker + "\nreport")}, []string{"create"}},
+		{"configured bot user", "", []string{"triage-bot"}, []testComment{{"Triage-Bot", testMarker + "\nreport"}, forged}, []string{"PATCH /repos/org/repo/issues/comments/1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, calls := newTestClientAs(t, tt.login, tt.comments...)
+			var previous string
+			_, err := client.UpsertComment(context.Background(), "org", "repo", 1, testMarker, CommentUpdateEdit, tt.botUsers, func(prev string) string {
+				previous = prev
+				return testMarker + "\nnew report"
+			})
+			if err != nil {
+				t.Fatalf("UpsertComment() error = %v", err)
+			}
+			if strings.Contains(previous, "pwned") {
+				t.Errorf("forged comment was used as the previous report: %q", previous)
+			}
+			if !slices.Equal(*calls, tt.wantCalls) {
+				t.Errorf("calls = %v, want %v", *calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestCreateCommentOnce(t *testing.T) {
+	const onCall = "<!-- simili-bot-on-call -->"
+
+	client, calls := newTestClient(t, testComment{"mallory", onCall + "\nfake page"})
+	posted, err := client.CreateCommentOnce(context.Background(), "org", "repo", 1, onCall, nil, onCall+"\ncc @on-call")
+	if err != nil || !posted {
+		t.Fatalf("CreateCommentOnce() = %v, %v; want posted", posted, err)
+	}
+	if !slices.Equal(*calls, []string{"create"}) {
+		t.Errorf("calls = %v", *calls)
+	}
+
+	client, calls = newTestClient(t, bot(onCall+"\ncc @on-call"))
+	posted, err = client.CreateCommentOnce(context.Background(), "org", "repo", 1, onCall, nil, onCall+"\ncc @on-call")
+	if err != nil || posted {
+		t.Fatalf("CreateCommentOnce() = %v, %v; want nothing posted", posted, err)
+	}
+	if len(*calls) != 0 {
+		t.Errorf("Expected no writes, got %v", *calls)
+	}
+}
+
+func TestUpsertCommentValidation(t *testing.T) {
+	client := &Client{client: nil}
+
+	if _, err := client.UpsertComment(context.Background(), "org", "repo", 1, testMarker, "replace", nil, nil); err == nil {
+		t.Error("Expected error for unknown mode")
+	}
+	if _, err := client.FindMarkedComment(context.Background(), "org", "repo", 1, " ", nil); err == nil {
+		t.Error("Expected error for empty marker")
+	}
+	if err := client.EditComment(context.Background(), "org", "repo", 1, ""); err == nil {
+		t.Error("Expected error for empty comment body")
+	}
+	if err := client.MinimizeComment(context.Background(), ""); err == nil {
+		t.Error("Expected error for empty node ID")
+	}
+}
@@ -1,7 +1,7 @@
 // Author: Kaviru Hapuarachchi
 // GitHub: https://github.com/Kavirubc
 // Created: 2026-02-04
-// Last Modified: 2026-02-04
+// Last Modified: 2026-10-18
 
 package github
 
@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 )
 
 const graphQLEndpoint = "https://api.github.com/graphql"
@@ -140,6 +141,69 @@ func (c *GraphQLClient) GetIssueNodeID(ctx context.Context, owner, repo string,
 	return result.Repository.Issue.ID, nil
 }
 
+// IssueEdit is one edit of an issue's body.
+type IssueEdit struct {
+	Editor   string
+	EditedAt time.Time
+}
+
+// ListIssueEdits fetches the most recent edits of an issue, oldest first.
+func (c *GraphQLClient) ListIssueEdits(ctx context.Context, owner, repo string, number int) ([]IssueEdit, error) {
+	query := `
+		query($owner: String!, $repo: String!, $number: Int!) {
+			repository(owner: $owner, name: $repo) {
+				issue(number: $number) {
+					userContentEdits(last: 100) {
+						nodes {
+							editedAt
+							editor { login }
+						}
+					}
+				}
+			}
+		}
+	`
+	variables := map[string]interface{}{
+		"owner":  owner,
+		"repo":   repo,
+		"number": number,
+	}
+
+	data, err := c.execute(ctx, query, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Repository struct {
+			Issue struct {
+				UserContentEdits struct {
+					Nodes []struct {
+						EditedAt time.Time `json:"editedAt"`
+						Editor   *struct {
+							Login string `json:"login"`
+						} `json:"editor"`
+					} `json:"nodes"`
+				} `json:"userContentEdits"`
+			} `json:"issue"`
+		} `json:"repository"`
+	}
+
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse issue edits: %w", err)
+	}
+
+	var edits []IssueEdit
+	for _, n := range result.Repository.Issue.UserContentEdits.Nodes {
+		edit := IssueEdit{EditedAt: n.EditedAt}
+		if n.Editor != nil { // Deleted accounts have no editor
+			edit.Editor = n.Editor.Login
+		}
+		edits = append(edits, edit)
+	}
+	return edits, nil
+}
+
 // GetRepositoryNodeID fetches the GraphQL node ID for a repository.
 func (c *GraphQLClient) GetRepositoryNodeID(ctx context.Context, owner, repo string) (string, error) {
 	query := `
@@ -218,3 +282,44 @@ func (c *GraphQLClient) TransferIssue(ctx context.Context, issueNodeID, targetRe
 
 	return result.TransferIssue.Issue.URL, nil
 }
+
+// MinimizeComment hides a comment with the given classifier (e.g. OUTDATED,
+// RESOLVED, DUPLICATE) using the minimizeComment mutation.
+func (c *GraphQLClient) MinimizeComment(ctx context.Context, subjectID, classifier string) error {
+	mutation := `
+		mutation($subjectId: ID!, $classifier: ReportedContentClassifiers!) {
+			minimizeComment(input: {subjectId: $subjectId, classifier: $classifier}) {
+				minimizedComment {
+					isMinimized
+				}
+			}
+		}
+	`
+	variables := map[string]interface{}{
+		"subjectId":  subjectID,
+		"classifier": classifier,
+	}
+
+	data, err := c.execute(ctx, mutation, variables)
+	if err != nil {
+		return err
+	}
+
+	var result struct {
+		MinimizeComment struct {
+			MinimizedComment struct {
+				IsMinimized bool `json:"isMinimized"`
+			} `json:"minimizedComment"`
+		} `json:"minimizeComment"`
+	}
+
+	if err := json.Unmarshal(data, &result); err != nil {
+		return fmt.Errorf("failed to parse minimize result: %w", err)
+	}
+
+	if !result.MinimizeComment.MinimizedComment.IsMinimized {
+		return fmt.Errorf("comment %s was not minimized", subjectID)
+	}
+
+	return nil
+}
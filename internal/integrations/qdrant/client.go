@@ -1,7 +1,7 @@
 // Author: Kaviru Hapuarachchi
 // GitHub: https://github.com/Kavirubc
 // Created: 2026-02-02
-// Last Modified: 2026-02-02
+// Last Modified: 2026-10-18
 
 package qdrant
 
@@ -269,6 +269,82 @@ func (c *Client) Search(ctx context.Context, collectionName string, vector []flo
 	return results, nil
 }
 
+// FindByPayload returns up to limit points whose payload field key matches
+// any of values, without a vector search.
+func (c *Client) FindByPayload(ctx context.Context, collectionName string, key string, values []string, limit int) ([]*SearchResult, error) {
+	authCtx, cancel := c.ctxWithAuth(ctx)
+	defer cancel()
+
+	n := uint32(limit)
+	resp, err := c.points.Scroll(authCtx, &pb.ScrollPoints{
+		CollectionName: collectionName,
+		Filter:         &pb.Filter{Must: []*pb.Condition{pb.NewMatchKeywords(key, values...)}},
+		Limit:          &n,
+		WithPayload:    &pb.WithPayloadSelector{SelectorOptions: &pb.WithPayloadSelector_Enable{Enable: true}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find points by %s: %w", key, err)
+	}
+
+	results := make([]*SearchResult, len(resp.Result))
+	for i, hit := range resp.Result {
+		payload := make(map[string]interface{})
+		for k, v := range hit.Payload {
+			payload[k] = fromQdrantValue(v)
+		}
+
+		id := hit.Id.GetUuid()
+		if id == "" {
+			id = fmt.Sprintf("%d", hit.Id.GetNum())
+		}
+
+		results[i] = &SearchResult{
+			ID:      id,
+			Payload: payload,
+		}
+	}
+
+	return results, nil
+}
+
+// Get fetches a point with its vector and payload. It returns nil if the
+// point does not exist.
+func (c *Client) Get(ctx context.Context, collectionName string, id string) (*Point, error) {
+	authCtx, cancel := c.ctxWithAuth(ctx)
+	defer cancel()
+
+	pointID := &pb.PointId{
+		PointIdOptions: &pb.PointId_Uuid{
+			Uuid: id,
+		},
+	}
+
+	resp, err := c.points.Get(authCtx, &pb.GetPoints{
+		CollectionName: collectionName,
+		Ids:            []*pb.PointId{pointID},
+		WithPayload:    &pb.WithPayloadSelector{SelectorOptions: &pb.WithPayloadSelector_Enable{Enable: true}},
+		WithVectors:    &pb.WithVectorsSelector{SelectorOptions: &pb.WithVectorsSelector_Enable{Enable: true}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get point: %w", err)
+	}
+	if len(resp.Result) == 0 {
+		return nil, nil
+	}
+
+	hit := resp.Result[0]
+	payload := make(map[string]interface{})
+	for k, v := range hit.Payload {
+		payload[k] = fromQdrantValue(v)
+	}
+
+	return &Point{
+		ID:      id,
+		Vector:  hit.GetVectors().GetVector().GetData(),
+		Payload: payload,
+	}, nil
+}
+
 // Delete removes a point by ID.
 func (c *Client) Delete(ctx context.Context, collectionName string, id string) error {
 	authCtx, cancel := c.ctxWithAuth(ctx)
@@ -345,6 +421,12 @@ func toQdrantValue(v interface{}) *pb.Value {
 		return &pb.Value{Kind: &pb.Value_DoubleValue{DoubleValue: val}}
 	case bool:
 		return &pb.Value{Kind: &pb.Value_BoolValue{BoolValue: val}}
+	case []string:
+		list := &pb.ListValue{Values: make([]*pb.Value, len(val))}
+		for i, s := range val {
+			list.Values[i] = toQdrantValue(s)
+		}
+		return &pb.Value{Kind: &pb.Value_ListValue{ListValue: list}}
 	default:
 		return &pb.Value{Kind: &pb.Value_StringValue{StringValue: fmt.Sprintf("%v", val)}}
 	}
@@ -361,6 +443,12 @@ func fromQdrantValue(v *pb.Value) interface{} {
 		return k.DoubleValue
 	case *pb.Value_BoolValue:
 		return k.BoolValue
+	case *pb.Value_ListValue:
+		list := make([]interface{}, len(k.ListValue.GetValues()))
+		for i, item := range k.ListValue.GetValues() {
+			list[i] = fromQdrantValue(item)
+		}
+		return list
 	default:
 		return nil
 	}
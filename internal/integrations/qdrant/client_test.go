@@ -1,11 +1,12 @@
 // Author: Kaviru Hapuarachchi
 // GitHub: https://github.com/Kavirubc
 // Created: 2026-02-02
-// Last Modified: 2026-02-02
+// Last Modified: 2026-10-18
 
 package qdrant
 
 import (
+	"reflect"
 	"testing"
 
 	pb "github.com/qdrant/go-client/qdrant"
@@ -45,3 +46,10 @@ func TestNilValue(t *testing.T) {
 		t.Errorf("Expected nil for null value, got %v", goVal)
 	}
 }
+
+func TestListValueConversion(t *testing.T) {
+	got := fromQdrantValue(toQdrantValue([]string{"a1b2", "c3d4"}))
+	if want := []interface{}{"a1b2", "c3d4"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
@@ -1,7 +1,7 @@
 // Author: Kaviru Hapuarachchi
 // GitHub: https://github.com/Kavirubc
 // Created: 2026-02-02
-// Last Modified: 2026-02-02
+// Last Modified: 2026-10-18
 
 // Package qdrant provides the vector database integration.
 package qdrant
@@ -37,6 +37,13 @@ type VectorStore interface {
 	// Search finds the nearest neighbors for a given vector.
 	Search(ctx context.Context, collectionName string, vector []float32, limit int, threshold float64) ([]*SearchResult, error)
 
+	// FindByPayload returns up to limit points whose payload field key equals,
+	// or for list fields contains, any of values. Results have no score (0): they are not ranked.
+	FindByPayload(ctx context.Context, collectionName string, key string, values []string, limit int) ([]*SearchResult, error)
+
+	// Get fetches a point with its vector and payload, or nil if it does not exist.
+	Get(ctx context.Context, collectionName string, id string) (*Point, error)
+
 	// Delete removes a point by ID.
 	Delete(ctx context.Context, collectionName string, id string) error
 
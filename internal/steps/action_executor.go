@@ -1,29 +1,36 @@
 // Author: Kaviru Hapuarachchi
 // GitHub: https://github.com/Kavirubc
 // Created: 2026-02-02
-// Last Modified: 2026-02-02
+// Last Modified: 2026-10-18
 
 // Package steps provides the action executor step.
 package steps
 
 import (
 	"log"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/similigh/simili-bot/internal/core/config"
 	"github.com/similigh/simili-bot/internal/core/pipeline"
+	"github.com/similigh/simili-bot/internal/core/state"
 	"github.com/similigh/simili-bot/internal/integrations/github"
 )
 
 // ActionExecutor executes the decided actions (posting comments, transferring, etc).
 type ActionExecutor struct {
-	client *github.Client
-	dryRun bool
+	client   *github.Client
+	feedback state.FeedbackStore
+	dryRun   bool
 }
 
 // NewActionExecutor creates a new action executor step.
 func NewActionExecutor(deps *pipeline.Dependencies) *ActionExecutor {
 	return &ActionExecutor{
-		client: deps.GitHub,
-		dryRun: deps.DryRun,
+		client:   deps.GitHub,
+		feedback: deps.Feedback,
+		dryRun:   deps.DryRun,
 	}
 }
 
@@ -37,14 +44,62 @@ func (s *ActionExecutor) Run(ctx *pipeline.Context) error {
 	// Get comment from metadata
 	comment, hasComment := ctx.Metadata["comment"].(string)
 
+	// When a duplicate is detected, only apply the "potential-duplicate" label
+	// and skip triage labels (e.g. "bug", "enhancement") to avoid noise on
+	// an issue that will be auto-closed.
+	labelsToApply := ctx.Result.SuggestedLabels
+	if ctx.Result.IsDuplicate && len(labelsToApply) > 0 {
+		var filtered []string
+		for _, l := range labelsToApply {
+			if l == "potential-duplicate" {
+				filtered = append(filtered, l)
+			}
+		}
+		labelsToApply = filtered
+	}
+
+	// Suggestions from every step (triage, classifiers, plugins) must name
+	// labels the repository has and the label policy permits.
+	labelsToApply = s.constrainSuggested(ctx, labelsToApply)
+
+	// Labels in an exclusive group replace the issue's current label from
+	// the same group instead of being added next to it.
+	var groups []config.LabelGroup
+	if ctx.Config != nil {
+		groups = ctx.Config.Labels.Groups
+	}
+	labelsToRemove := exclusiveGroupSwaps(groups, ctx.Issue.Labels, labelsToApply)
+
+	// Steps may also retire labels outright (e.g. needs_info once the issue
+	// has enough detail).
+	if queued, ok := ctx.Metadata["labels_to_remove"].([]string); ok {
+		for _, l := range queued {
+			if !hasLabel(labelsToRemove, l) {
+				labelsToRemove = append(labelsToRemove, l)
+			}
+		}
+	}
+
 	if s.dryRun {
 		if hasComment && comment != "" {
-			log.Printf("[action_executor] DRY RUN: Would post comment:\n%s", comment)
+			if strings.HasPrefix(comment, reportMarker) {
+				log.Printf("[action_executor] DRY RUN: Would post or update triage report:\n%s", comment)
+			} else {
+				log.Printf("[action_executor] DRY RUN: Would post comment:\n%s", comment)
+			}
+		}
+		if alert, ok := ctx.Metadata["on_call_alert"].(string); ok && alert != "" {
+			log.Printf("[action_executor] DRY RUN: Would page on-call (once per issue):\n%s", alert)
 		}
 		if ctx.TransferTarget != "" && ctx.Issue.EventType != "pull_request" && ctx.Issue.EventType != "pr_comment" {
 			log.Printf("[action_executor] DRY RUN: Would transfer to %s", ctx.TransferTarget)
 		}
-		// TODO: Dry run log for labels if we add label logic
+		if len(labelsToRemove) > 0 {
+			log.Printf("[action_executor] DRY RUN: Would remove labels: %v", labelsToRemove)
+		}
+		if assignees, ok := ctx.Metadata["assignees"].([]string); ok && len(assignees) > 0 {
+			log.Printf("[action_executor] DRY RUN: Would assign %v", assignees)
+		}
 		return nil
 	}
 
@@ -53,8 +108,11 @@ func (s *ActionExecutor) Run(ctx *pipeline.Context) error {
 		return nil
 	}
 
-	// 1. Post comment
-	if hasComment && comment != "" {
+	// 1. Post comment. The triage report is kept as a single comment that is
+	// updated on later runs; other comments (command replies) are always new.
+	if hasComment && comment != "" && strings.HasPrefix(comment, reportMarker) {
+		s.upsertReport(ctx, comment, labelsToApply)
+	} else if hasComment && comment != "" {
 		err := s.client.CreateComment(ctx.Ctx, ctx.Issue.Org, ctx.Issue.Repo, ctx.Issue.Number, comment)
 		if err != nil {
 			log.Printf("[action_executor] Failed to post comment: %v", err)
@@ -65,6 +123,12 @@ func (s *ActionExecutor) Run(ctx *pipeline.Context) error {
 		}
 	}
 
+	// Page on-call in a comment of its own: mentions added by editing the
+	// report would not notify anyone.
+	if alert, ok := ctx.Metadata["on_call_alert"].(string); ok && alert != "" {
+		s.pageOnCall(ctx, alert)
+	}
+
 	// 2. Transfer issue to another repository (issues only)
 	if ctx.TransferTarget != "" && ctx.Issue.EventType != "pull_request" && ctx.Issue.EventType != "pr_comment" {
 		newURL, err := s.client.TransferIssue(ctx.Ctx, ctx.Issue.Org, ctx.Issue.Repo, ctx.Issue.Number, ctx.TransferTarget)
@@ -78,21 +142,18 @@ func (s *ActionExecutor) Run(ctx *pipeline.Context) error {
 		}
 	}
 
-	// 3. Apply labels
-	// When a duplicate is detected, only apply the "potential-duplicate" label
-	// and skip triage labels (e.g. "bug", "enhancement") to avoid noise on
-	// an issue that will be auto-closed.
-	labelsToApply := ctx.Result.SuggestedLabels
-	if ctx.Result.IsDuplicate && len(labelsToApply) > 0 {
-		var filtered []string
-		for _, l := range labelsToApply {
-			if l == "potential-duplicate" {
-				filtered = append(filtered, l)
-			}
+	// 3. Apply labels, first removing the ones they replace or retire
+	for _, label := range labelsToRemove {
+		if err := s.client.RemoveLabel(ctx.Ctx, ctx.Issue.Org, ctx.Issue.Repo, ctx.Issue.Number, label); err != nil {
+			log.Printf("[action_executor] Failed to remove label %q: %v", label, err)
+			ctx.Result.Errors = append(ctx.Result.Errors, err.Error())
+			continue
 		}
-		labelsToApply = filtered
+		ctx.Result.LabelsRemoved = append(ctx.Result.LabelsRemoved, label)
+	}
+	if len(ctx.Result.LabelsRemoved) > 0 {
+		log.Printf("[action_executor] Removed labels: %v", ctx.Result.LabelsRemoved)
 	}
-
 	if len(labelsToApply) > 0 {
 		err := s.client.AddLabels(ctx.Ctx, ctx.Issue.Org, ctx.Issue.Repo, ctx.Issue.Number, labelsToApply)
 		if err != nil {
@@ -104,5 +165,115 @@ func (s *ActionExecutor) Run(ctx *pipeline.Context) error {
 		}
 	}
 
+	// 4. Assign suggested owners (owner_suggester in "assign" mode)
+	if assignees, ok := ctx.Metadata["assignees"].([]string); ok && len(assignees) > 0 {
+		if err := s.client.AddAssignees(ctx.Ctx, ctx.Issue.Org, ctx.Issue.Repo, ctx.Issue.Number, assignees); err != nil {
+			log.Printf("[action_executor] Failed to assign %v: %v", assignees, err)
+			ctx.Result.Errors = append(ctx.Result.Errors, err.Error())
+		} else {
+			log.Printf("[action_executor] Assigned: %v", assignees)
+			ctx.Result.AssigneesAdded = assignees
+		}
+	}
+
+	// 5. Record decisions so maintainer feedback on them can be tracked
+	if s.feedback != nil {
+		decisions := feedbackDecisions(ctx, time.Now())
+		if err := state.RecordDecisions(ctx.Ctx, s.feedback, ctx.Issue.Org, ctx.Issue.Repo, ctx.Issue.Number, decisions); err != nil {
+			log.Printf("[action_executor] Failed to record decisions: %v (non-blocking)", err)
+		}
+	}
+
 	return nil
 }
+
+// constrainSuggested maps suggested labels onto the repository's labels and
+// drops the ones it does not have or the label policy forbids. Labels the
+// bot manages itself (duplicate, needs-info and priority labels) are kept
+// as-is; GitHub creates them on first use.
+func (s *ActionExecutor) constrainSuggested(ctx *pipeline.Context, labels []string) []string {
+	if ctx.Config == nil || !slices.ContainsFunc(labels, func(l string) bool { return !isManagedLabel(l, ctx.Config) }) {
+		return labels
+	}
+
+	allowed := repoLabelOptions(ctx, s.client)
+	sources, _ := ctx.Metadata["label_sources"].(map[string]string)
+	var out, dropped []string
+	for _, l := range labels {
+		name := l
+		if !isManagedLabel(l, ctx.Config) {
+			kept, _ := constrainLabels([]string{l}, allowed, ctx.Config.Labels)
+			if len(kept) == 0 {
+				dropped = append(dropped, l)
+				continue
+			}
+			name = kept[0]
+		}
+		if hasLabel(out, name) {
+			continue
+		}
+		out = append(out, name)
+		// Keep feedback attributed to the step when the name was corrected.
+		if step, ok := sources[strings.ToLower(l)]; ok && !strings.EqualFold(l, name) {
+			setLabelSource(ctx, step, name)
+		}
+	}
+	if len(dropped) > 0 {
+		log.Printf("[action_executor] Dropped labels not in the repository's allowed set: %v", dropped)
+	}
+	return out
+}
+
+// isManagedLabel reports whether the bot applies label on its own account
+// rather than as a suggestion.
+func isManagedLabel(label string, cfg *config.Config) bool {
+	if strings.EqualFold(label, "potential-duplicate") || strings.EqualFold(label, cfg.NeedsInfo.Label) {
+		return true
+	}
+	return isPriorityLabel(label, cfg.Severity.Labels)
+}
+
+// pageOnCall posts the on-call alert unless an earlier run already did.
+func (s *ActionExecutor) pageOnCall(ctx *pipeline.Context, alert string) {
+	var botUsers []string
+	if ctx.Config != nil {
+		botUsers = ctx.Config.BotUsers
+	}
+	posted, err := s.client.CreateCommentOnce(ctx.Ctx, ctx.Issue.Org, ctx.Issue.Repo, ctx.Issue.Number, onCallMarker, botUsers, alert)
+	if err != nil {
+		log.Printf("[action_executor] Failed to page on-call: %v", err)
+		ctx.Result.Errors = append(ctx.Result.Errors, err.Error())
+		return
+	}
+	if posted {
+		log.Printf("[action_executor] Paged on-call for issue #%d", ctx.Issue.Number)
+	}
+}
+
+// upsertReport posts the triage report, or updates the one posted on an
+// earlier run with a changelog of what changed since.
+func (s *ActionExecutor) upsertReport(ctx *pipeline.Context, comment string, labels []string) {
+	mode, limit := github.CommentUpdateEdit, 0
+	var botUsers []string
+	if ctx.Config != nil {
+		mode, limit = ctx.Config.Comments.Update, ctx.Config.Comments.ChangelogEntries
+		botUsers = ctx.Config.BotUsers
+	}
+	cur := currentReportState(ctx, labels)
+	render := func(previous string) string {
+		return renderReport(comment, previous, cur, time.Now(), limit)
+	}
+
+	updated, err := s.client.UpsertComment(ctx.Ctx, ctx.Issue.Org, ctx.Issue.Repo, ctx.Issue.Number, reportMarker, mode, botUsers, render)
+	if err != nil {
+		log.Printf("[action_executor] Failed to post triage report: %v", err)
+		ctx.Result.Errors = append(ctx.Result.Errors, err.Error())
+		return
+	}
+	if updated {
+		log.Printf("[action_executor] Updated triage report on issue #%d", ctx.Issue.Number)
+	} else {
+		log.Printf("[action_executor] Posted triage report on issue #%d", ctx.Issue.Number)
+	}
+	ctx.Result.CommentPosted = true
+}
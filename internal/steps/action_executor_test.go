@@ -0,0 +1,63 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package steps
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/similigh/simili-bot/internal/core/config"
+	"github.com/similigh/simili-bot/internal/core/pipeline"
+)
+
+func TestActionExecutorConstrainsSuggestedLabels(t *testing.T) {
+	var added []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/acme/app/labels", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"name": "bug"}, {"name": "area/api"}, {"name": "internal"}]`)
+	})
+	mux.HandleFunc("/repos/acme/app/issues/7/labels", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&added)
+		fmt.Fprint(w, `[]`)
+	})
+
+	cfg := &config.Config{Labels: config.LabelsConfig{Deny: []string{"internal"}}}
+	cfg.ApplyDefaults()
+	ctx := pipeline.NewContext(context.Background(), &pipeline.Issue{Org: "acme", Repo: "app", Number: 7}, cfg)
+	// Labels from triage, a classifier and a plugin, plus the bot's own
+	// priority label, which the repository does not have yet.
+	ctx.Result.SuggestedLabels = []string{"bug", "priority/P1"}
+	suggestLabel(ctx, "llm_classifier", "API")
+	suggestLabel(ctx, "plugin", "made-up")
+	suggestLabel(ctx, "plugin", "internal")
+
+	step := NewActionExecutor(&pipeline.Dependencies{GitHub: newFakeGitHub(t, mux)})
+	if err := step.Run(ctx); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	want := []string{"bug", "priority/P1", "area/api"}
+	if !reflect.DeepEqual(added, want) || !reflect.DeepEqual(ctx.Result.LabelsApplied, want) {
+		t.Errorf("added %v, applied %v, want %v", added, ctx.Result.LabelsApplied, want)
+	}
+	if sources := ctx.Metadata["label_sources"].(map[string]string); sources["area/api"] != "llm_classifier" {
+		t.Errorf("Expected the corrected label to keep its source, got %v", sources)
+	}
+}
+
+func TestActionExecutorNilConfig(t *testing.T) {
+	ctx := pipeline.NewContext(context.Background(), &pipeline.Issue{Org: "acme", Repo: "app", Number: 7, Labels: []string{"bug"}}, nil)
+	ctx.Result.SuggestedLabels = []string{"enhancement"}
+
+	step := NewActionExecutor(&pipeline.Dependencies{DryRun: true})
+	if err := step.Run(ctx); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+}
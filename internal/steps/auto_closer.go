@@ -1,7 +1,7 @@
 // Author: Kaviru Hapuarachchi
 // GitHub: https://github.com/kavirubc
 // Created: 2026-02-22
-// Last Modified: 2026-02-25
+// Last Modified: 2026-10-18
 
 // Package steps provides the auto-closer logic for confirmed duplicate issues.
 package steps
@@ -16,6 +16,7 @@ import (
 	githubapi "github.com/google/go-github/v60/github"
 
 	"github.com/similigh/simili-bot/internal/core/config"
+	"github.com/similigh/simili-bot/internal/core/state"
 	"github.com/similigh/simili-bot/internal/integrations/github"
 )
 
@@ -39,10 +40,11 @@ type AutoCloseDetail struct {
 // AutoCloser scans issues with the potential-duplicate label and closes
 // those whose grace period has expired and have no human activity.
 type AutoCloser struct {
-	github  *github.Client
-	cfg     *config.Config
-	dryRun  bool
-	verbose bool
+	github   *github.Client
+	cfg      *config.Config
+	feedback state.FeedbackStore
+	dryRun   bool
+	verbose  bool
 }
 
 // NewAutoCloser creates a new AutoCloser.
@@ -55,6 +57,12 @@ func NewAutoCloser(gh *github.Client, cfg *config.Config, dryRun, verbose bool)
 	}
 }
 
+// WithFeedback records each closure in store so reopens can be tracked.
+func (ac *AutoCloser) WithFeedback(store state.FeedbackStore) *AutoCloser {
+	ac.feedback = store
+	return ac
+}
+
 // Run processes all open issues with the "potential-duplicate" label.
 func (ac *AutoCloser) Run(ctx context.Context, org, repo string) (*AutoCloseResult, error) {
 	if ac.github == nil {
@@ -96,7 +104,7 @@ func (ac *AutoCloser) Run(ctx context.Context, org, repo string) (*AutoCloseResu
 		detail := AutoCloseDetail{Number: number}
 
 		// 1. Check grace period
-		labeledAt, err := ac.findLabeledTime(ctx, org, repo, number, "potential-duplicate")
+		labeledAt, err := findLabeledTime(ctx, ac.github, org, repo, number, "potential-duplicate")
 		if err != nil {
 			detail.Action = "error"
 			detail.Reason = fmt.Sprintf("failed to check label time: %v", err)
@@ -170,6 +178,11 @@ func (ac *AutoCloser) Run(ctx context.Context, org, repo string) (*AutoCloseResu
 		result.Closed++
 		result.Details = append(result.Details, detail)
 		log.Printf("[auto-closer] Closed #%d: %s", number, detail.Reason)
+
+		closeDecision := state.Decision{Step: "auto_closer", Action: "close", Labels: []string{"duplicate"}, DecidedAt: time.Now()}
+		if err := state.RecordDecisions(ctx, ac.feedback, org, repo, number, []state.Decision{closeDecision}); err != nil {
+			log.Printf("[auto-closer] Warning: failed to record close decision for #%d: %v", number, err)
+		}
 	}
 
 	return result, nil
@@ -206,24 +219,23 @@ func (ac *AutoCloser) fetchPotentialDuplicates(ctx context.Context, org, repo st
 	return all, nil
 }
 
-// findLabeledTime finds when the "potential-duplicate" label was most recently applied.
-func (ac *AutoCloser) findLabeledTime(ctx context.Context, org, repo string, number int, label string) (time.Time, error) {
-	events, err := ac.github.ListIssueEvents(ctx, org, repo, number)
+// findLabeledTime finds when label was most recently applied to the issue.
+// It returns the zero time if the label was never applied.
+func findLabeledTime(ctx context.Context, gh *github.Client, org, repo string, number int, label string) (time.Time, error) {
+	events, err := gh.ListIssueEvents(ctx, org, repo, number)
 	if err != nil {
 		return time.Time{}, err
 	}
 
 	var latest time.Time
 	for _, event := range events {
-		if event.Event != nil && *event.Event == "labeled" {
-			if event.Label != nil && event.Label.Name != nil && *event.Label.Name == label {
-				if event.CreatedAt != nil && event.CreatedAt.Time.After(latest) {
-					latest = event.CreatedAt.Time
-				}
-			}
+		if event.GetEvent() != "labeled" || !strings.EqualFold(event.GetLabel().GetName(), label) {
+			continue
+		}
+		if t := event.GetCreatedAt().Time; t.After(latest) {
+			latest = t
 		}
 	}
-
 	return latest, nil
 }
 
@@ -241,7 +253,7 @@ func (ac *AutoCloser) hasHumanActivity(ctx context.Context, org, repo string, nu
 	// Only consider comments within a 24-hour window before `since` to avoid
 	// fetching the entire comment history on high-traffic issues.
 	triageWindow := since.Add(-24 * time.Hour)
-	allComments, err := ac.fetchAllComments(ctx, org, repo, number)
+	allComments, err := fetchComments(ctx, ac.github, org, repo, number, nil)
 	if err != nil {
 		return false, err
 	}
@@ -308,50 +320,37 @@ func (ac *AutoCloser) hasHumanActivity(ctx context.Context, org, repo string, nu
 	}
 
 	// Check C: human comment after labeledAt
-	opts := &githubapi.IssueListCommentsOptions{
-		Since: &since,
-		ListOptions: githubapi.ListOptions{
-			PerPage: 100,
-		},
+	comments, err := fetchComments(ctx, ac.github, org, repo, number, &since)
+	if err != nil {
+		return false, err
 	}
-
-	for {
-		comments, resp, err := ac.github.ListComments(ctx, org, repo, number, opts)
-		if err != nil {
-			return false, err
+	for _, comment := range comments {
+		if comment.User == nil {
+			continue
 		}
-
-		for _, comment := range comments {
-			if comment.User == nil {
-				continue
-			}
-			author := comment.User.GetLogin()
-			if !isBotUser(author, ac.cfg.BotUsers) {
-				if ac.verbose {
-					log.Printf("[auto-closer] #%d: human comment by %q at %v",
-						number, author, comment.CreatedAt.Time)
-				}
-				return true, nil
+		author := comment.User.GetLogin()
+		if !isBotUser(author, ac.cfg.BotUsers) {
+			if ac.verbose {
+				log.Printf("[auto-closer] #%d: human comment by %q at %v",
+					number, author, comment.CreatedAt.Time)
 			}
+			return true, nil
 		}
-
-		if resp == nil || resp.NextPage == 0 {
-			break
-		}
-		opts.Page = resp.NextPage
 	}
 
 	return false, nil
 }
 
-// fetchAllComments retrieves all comments on an issue without a time filter.
-func (ac *AutoCloser) fetchAllComments(ctx context.Context, org, repo string, number int) ([]*githubapi.IssueComment, error) {
+// fetchComments retrieves every comment on an issue, or only those updated
+// after since when it is not nil.
+func fetchComments(ctx context.Context, gh *github.Client, org, repo string, number int, since *time.Time) ([]*githubapi.IssueComment, error) {
 	var all []*githubapi.IssueComment
 	opts := &githubapi.IssueListCommentsOptions{
+		Since:       since,
 		ListOptions: githubapi.ListOptions{PerPage: 100},
 	}
 	for {
-		comments, resp, err := ac.github.ListComments(ctx, org, repo, number, opts)
+		comments, resp, err := gh.ListComments(ctx, org, repo, number, opts)
 		if err != nil {
 			return nil, err
 		}
@@ -10,18 +10,23 @@ import (
 	"strings"
 
 	"github.com/similigh/simili-bot/internal/core/pipeline"
+	"github.com/similigh/simili-bot/internal/integrations/ai"
 	"github.com/similigh/simili-bot/internal/integrations/github"
 )
 
 // CommandHandler processes bot commands like /undo.
 type CommandHandler struct {
-	gh *github.Client
+	gh     *github.Client
+	llm    *ai.LLMClient
+	dryRun bool
 }
 
 // NewCommandHandler creates a new command handler step.
 func NewCommandHandler(deps *pipeline.Dependencies) *CommandHandler {
 	return &CommandHandler{
-		gh: deps.GitHub,
+		gh:     deps.GitHub,
+		llm:    deps.LLMClient,
+		dryRun: deps.DryRun,
 	}
 }
 
@@ -34,7 +39,7 @@ func (s *CommandHandler) Name() string {
 // It checks for the hidden HTML marker (preferred), the legacy emoji marker,
 // and the plain-text header as a fallback.
 func isBotComment(body string) bool {
-	return strings.Contains(body, "<!-- simili-bot-report -->") ||
+	return strings.Contains(body, reportMarker) ||
 		strings.Contains(body, "🤖 Simili Triage Report") ||
 		strings.Contains(body, "### Simili Triage Report")
 }
@@ -83,6 +88,12 @@ func (s *CommandHandler) Run(ctx *pipeline.Context) error {
 			return pipeline.ErrSkipPipeline
 		}
 
+		// The author answering a needs-info request may have supplied
+		// what was missing.
+		if isNeedsInfoReply(ctx) {
+			s.handleNeedsInfoReply(ctx)
+		}
+
 		// Not a command? Skip the entire triage pipeline for comments.
 		return pipeline.ErrSkipPipeline
 	}
@@ -267,6 +278,49 @@ func (s *CommandHandler) handleUndo(ctx *pipeline.Context) error {
 	return nil
 }
 
+// handleNeedsInfoReply re-assesses the issue together with the author's
+// reply and removes the needs-info label once the quality threshold is met.
+func (s *CommandHandler) handleNeedsInfoReply(ctx *pipeline.Context) {
+	cfg := ctx.Config.NeedsInfo
+	if s.llm == nil {
+		log.Printf("[command_handler] No LLM client, cannot re-assess needs-info issue #%d", ctx.Issue.Number)
+		return
+	}
+
+	input := &ai.IssueInput{
+		Title:  ctx.Issue.Title,
+		Body:   ctx.Issue.Body + "\n\n" + ctx.Issue.CommentBody,
+		Author: ctx.Issue.Author,
+		Labels: ctx.Issue.Labels,
+	}
+	quality, err := s.llm.AssessQuality(ctx.Ctx, input)
+	if err != nil {
+		log.Printf("[command_handler] Failed to re-assess #%d: %v (non-blocking)", ctx.Issue.Number, err)
+		return
+	}
+	ctx.Result.QualityScore = quality.Score
+	ctx.Result.QualityIssues = quality.Issues
+	if quality.Score < cfg.Threshold {
+		log.Printf("[command_handler] Quality of #%d is still %.2f after the author's reply, keeping %q", ctx.Issue.Number, quality.Score, cfg.Label)
+		return
+	}
+
+	if s.dryRun {
+		log.Printf("[command_handler] DRY RUN: Would remove %q from #%d (quality %.2f)", cfg.Label, ctx.Issue.Number, quality.Score)
+		return
+	}
+	if s.gh == nil {
+		return
+	}
+	if err := s.gh.RemoveLabel(ctx.Ctx, ctx.Issue.Org, ctx.Issue.Repo, ctx.Issue.Number, cfg.Label); err != nil {
+		log.Printf("[command_handler] Failed to remove %q from #%d: %v (non-blocking)", cfg.Label, ctx.Issue.Number, err)
+		ctx.Result.Errors = append(ctx.Result.Errors, err.Error())
+		return
+	}
+	ctx.Result.LabelsRemoved = append(ctx.Result.LabelsRemoved, cfg.Label)
+	log.Printf("[command_handler] Removed %q from #%d after the author's reply (quality %.2f)", cfg.Label, ctx.Issue.Number, quality.Score)
+}
+
 // extractSourceRepo pulls the repo name out of the triage report body.
 func (s *CommandHandler) extractSourceRepo(body string) string {
 	// Simple marker-based extraction
@@ -1,16 +1,19 @@
 // Author: Kaviru Hapuarachchi
 // GitHub: https://github.com/kavirubc
 // Created: 2026-02-04
-// Last Modified: 2026-03-06
+// Last Modified: 2026-10-18
 
 package steps
 
 import (
 	"context"
+	"fmt"
 	"log"
 
 	"github.com/similigh/simili-bot/internal/core/pipeline"
 	"github.com/similigh/simili-bot/internal/integrations/ai"
+	"github.com/similigh/simili-bot/internal/utils/issueforms"
+	"github.com/similigh/simili-bot/internal/utils/text"
 )
 
 // duplicateDetectorLLM is the subset of ai.LLMClient used by DuplicateDetector.
@@ -20,6 +23,8 @@ type duplicateDetectorLLM interface {
 }
 
 // DuplicateDetector analyzes similarity results for duplicates using LLM.
+// With near_duplicate enabled, re-posts whose body is a copy of a candidate's
+// are flagged from their SimHash signatures first, without an LLM call.
 type DuplicateDetector struct {
 	llm duplicateDetectorLLM
 }
@@ -42,10 +47,6 @@ func (s *DuplicateDetector) Run(ctx *pipeline.Context) error {
 	if ctx.Issue.EventType == "issue_comment" || ctx.Issue.EventType == "pr_comment" {
 		return nil
 	}
-	if s.llm == nil {
-		log.Printf("[duplicate_detector] No LLM client, skipping duplicate detection")
-		return nil
-	}
 
 	// Skip if transfer scheduled
 	if ctx.TransferTarget != "" {
@@ -59,6 +60,17 @@ func (s *DuplicateDetector) Run(ctx *pipeline.Context) error {
 		return nil
 	}
 
+	// Copy-pasted re-posts are flagged without asking the LLM.
+	if result := nearDuplicate(ctx); result != nil && result.Confidence >= duplicateThreshold(ctx) {
+		s.markDuplicate(ctx, result)
+		return nil
+	}
+
+	if s.llm == nil {
+		log.Printf("[duplicate_detector] No LLM client, skipping duplicate detection")
+		return nil
+	}
+
 	log.Printf("[duplicate_detector] Analyzing %d similar issues for duplicates", len(ctx.SimilarIssues))
 
 	// Use configured candidate limit (default 5 per issue #56).
@@ -73,12 +85,13 @@ func (s *DuplicateDetector) Run(ctx *pipeline.Context) error {
 	similarInput := make([]ai.SimilarIssueInput, maxSimilar)
 	for i := 0; i < maxSimilar; i++ {
 		similarInput[i] = ai.SimilarIssueInput{
-			Number:     ctx.SimilarIssues[i].Number,
-			Title:      ctx.SimilarIssues[i].Title,
-			Body:       ctx.SimilarIssues[i].Body,
-			URL:        ctx.SimilarIssues[i].URL,
-			Similarity: ctx.SimilarIssues[i].Similarity,
-			State:      ctx.SimilarIssues[i].State,
+			Number:         ctx.SimilarIssues[i].Number,
+			Title:          ctx.SimilarIssues[i].Title,
+			Body:           ctx.SimilarIssues[i].Body,
+			URL:            ctx.SimilarIssues[i].URL,
+			Similarity:     ctx.SimilarIssues[i].Similarity,
+			State:          ctx.SimilarIssues[i].State,
+			SameStackTrace: ctx.SimilarIssues[i].SameStackTrace,
 		}
 	}
 
@@ -98,15 +111,18 @@ func (s *DuplicateDetector) Run(ctx *pipeline.Context) error {
 		return nil // Graceful degradation
 	}
 
+	s.markDuplicate(ctx, result)
+	return nil
+}
+
+// markDuplicate stores a duplicate check result and flags the issue when the
+// result is a duplicate above the configured confidence threshold.
+func (s *DuplicateDetector) markDuplicate(ctx *pipeline.Context, result *ai.DuplicateResult) {
 	// Store full result and related issues in context.
 	ctx.Metadata["duplicate_result"] = result
 	ctx.Metadata["related_issues"] = result.RelatedIssues
 
-	// Get threshold from config (default 0.85 to align with prompt guidance).
-	threshold := ctx.Config.Transfer.DuplicateConfidenceThreshold
-	if threshold == 0 {
-		threshold = 0.85
-	}
+	threshold := duplicateThreshold(ctx)
 
 	// Store reasoning regardless of duplicate status
 	ctx.Result.DuplicateReason = result.Reasoning
@@ -116,6 +132,7 @@ func (s *DuplicateDetector) Run(ctx *pipeline.Context) error {
 		ctx.Result.IsDuplicate = true
 		ctx.Result.DuplicateOf = result.DuplicateOf
 		ctx.Result.DuplicateConfidence = result.Confidence
+		ctx.Metadata["duplicate_threshold"] = threshold
 		// Add "potential-duplicate" label for the auto-close workflow
 		ctx.Result.SuggestedLabels = append(ctx.Result.SuggestedLabels, "potential-duplicate")
 		log.Printf("[duplicate_detector] Duplicate detected: #%d (%.2f confidence)",
@@ -123,6 +140,56 @@ func (s *DuplicateDetector) Run(ctx *pipeline.Context) error {
 	} else {
 		log.Printf("[duplicate_detector] No high-confidence duplicate found (reason: %s)", result.Reasoning)
 	}
+}
+
+// duplicateThreshold returns the confidence needed to flag a duplicate
+// (default 0.85 to align with prompt guidance).
+func duplicateThreshold(ctx *pipeline.Context) float64 {
+	if threshold := ctx.Config.Transfer.DuplicateConfidenceThreshold; threshold != 0 {
+		return threshold
+	}
+	return 0.85
+}
 
-	return nil
+// nearDuplicate compares the issue body's SimHash with the indexed
+// signatures of the similar issues and returns a duplicate result for the
+// closest one within near_duplicate.max_distance bits. It returns nil when
+// the check is disabled, the body is too short to sign, or no candidate is
+// close enough, leaving the decision to the LLM.
+func nearDuplicate(ctx *pipeline.Context) *ai.DuplicateResult {
+	cfg := ctx.Config.NearDuplicate
+	if cfg.Enabled == nil || !*cfg.Enabled {
+		return nil
+	}
+	hash := issueforms.SimHash(ctx.Issue.Body)
+	if hash == 0 {
+		return nil
+	}
+
+	var best *pipeline.SimilarIssue
+	bestDistance := cfg.MaxDistance + 1
+	for i := range ctx.SimilarIssues {
+		candidate := &ctx.SimilarIssues[i]
+		if candidate.BodySimHash == 0 || candidate.Type == "pr" {
+			continue
+		}
+		if d := text.SimHashDistance(hash, candidate.BodySimHash); d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+	if best == nil {
+		return nil
+	}
+
+	result := &ai.DuplicateResult{IsDuplicate: true, DuplicateOf: best.Number}
+	if bestDistance == 0 {
+		result.Confidence = 1
+		result.Reasoning = fmt.Sprintf("The description is identical to #%d.", best.Number)
+	} else {
+		result.Confidence = 0.95
+		result.Reasoning = fmt.Sprintf("The description is a near-exact copy of #%d.", best.Number)
+	}
+	log.Printf("[duplicate_detector] #%d matches #%d by SimHash (distance %d), skipping LLM check",
+		ctx.Issue.Number, best.Number, bestDistance)
+	return result
 }
@@ -1,7 +1,7 @@
 // Author: Kaviru Hapuarachchi
 // GitHub: https://github.com/kavirubc
 // Created: 2026-03-06
-// Last Modified: 2026-03-06
+// Last Modified: 2026-10-18
 
 package steps
 
@@ -12,6 +12,8 @@ import (
 	"github.com/similigh/simili-bot/internal/core/config"
 	"github.com/similigh/simili-bot/internal/core/pipeline"
 	"github.com/similigh/simili-bot/internal/integrations/ai"
+	"github.com/similigh/simili-bot/internal/utils/issueforms"
+	"github.com/similigh/simili-bot/internal/utils/text"
 )
 
 // fakeLLM implements duplicateDetectorLLM for unit tests.
@@ -164,3 +166,132 @@ func TestDuplicateDetector_RelatedNotMarkedDuplicate(t *testing.T) {
 		t.Error("related_issues should still be stored in metadata even when confidence gate blocks duplicate")
 	}
 }
+
+const repostBody = "When I click the export button on the reports page nothing happens. " +
+	"The browser console shows a network error for the export endpoint and no file is downloaded. " +
+	"This started after upgrading to version 2.3 and happens in both Chrome and Firefox."
+
+// newRepostCtx builds a context whose second similar issue has the SimHash of
+// candidateBody, with the near-duplicate pre-check enabled.
+func newRepostCtx(body, candidateBody string) *pipeline.Context {
+	cfg := newMinimalConfig(5, 0.85)
+	cfg.NearDuplicate = config.NearDuplicateConfig{Enabled: boolPtr(true), MaxDistance: 3}
+	ctx := newTestCtx(cfg, 3)
+	ctx.Issue.Body = body
+	ctx.SimilarIssues[1].BodySimHash = issueforms.SimHash(candidateBody)
+	return ctx
+}
+
+func TestDuplicateDetector_NearDuplicateSkipsLLM(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		wantConfidence float64
+	}{
+		{name: "exact", body: repostBody, wantConfidence: 1},
+		{name: "near-exact", body: repostBody + " Any ideas?", wantConfidence: 0.95},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeLLM{result: &ai.DuplicateResult{}}
+			step := &DuplicateDetector{llm: fake}
+			ctx := newRepostCtx(tt.body, repostBody)
+
+			if err := step.Run(ctx); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if fake.capturedInput != nil {
+				t.Error("LLM should not be called for a re-post")
+			}
+			if !ctx.Result.IsDuplicate || ctx.Result.DuplicateOf != 2 {
+				t.Errorf("expected duplicate of #2, got IsDuplicate=%v DuplicateOf=%d", ctx.Result.IsDuplicate, ctx.Result.DuplicateOf)
+			}
+			if ctx.Result.DuplicateConfidence != tt.wantConfidence {
+				t.Errorf("DuplicateConfidence = %.2f, want %.2f", ctx.Result.DuplicateConfidence, tt.wantConfidence)
+			}
+			if _, ok := ctx.Metadata["duplicate_result"].(*ai.DuplicateResult); !ok {
+				t.Error("duplicate_result should be stored for the response builder")
+			}
+		})
+	}
+}
+
+func TestDuplicateDetector_NearDuplicateFallsBackToLLM(t *testing.T) {
+	different := "The dark theme makes the sidebar text unreadable because the contrast between the " +
+		"grey labels and the background is too low, especially on laptop screens in daylight."
+
+	tests := []struct {
+		name string
+		ctx  func() *pipeline.Context
+	}{
+		{name: "different body", ctx: func() *pipeline.Context { return newRepostCtx(different, repostBody) }},
+		{name: "short body", ctx: func() *pipeline.Context { return newRepostCtx("Export is broken", "Export is broken") }},
+		{name: "disabled", ctx: func() *pipeline.Context {
+			ctx := newRepostCtx(repostBody, repostBody)
+			ctx.Config.NearDuplicate.Enabled = boolPtr(false)
+			return ctx
+		}},
+		{name: "stricter threshold", ctx: func() *pipeline.Context {
+			ctx := newRepostCtx(repostBody+" Any ideas?", repostBody)
+			ctx.Config.Transfer.DuplicateConfidenceThreshold = 0.97
+			return ctx
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeLLM{result: &ai.DuplicateResult{}}
+			step := &DuplicateDetector{llm: fake}
+			if err := step.Run(tt.ctx()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if fake.capturedInput == nil {
+				t.Error("ambiguous cases should go to the LLM")
+			}
+		})
+	}
+}
+
+// bugFormBody renders a bug report as GitHub does for an issue form where
+// only the description and version were filled in.
+func bugFormBody(description, version string) string {
+	return "### Describe the bug\n\n" + description +
+		"\n\n### Steps to reproduce\n\n_No response_\n\n### Expected behavior\n\n_No response_" +
+		"\n\n### Version\n\n" + version +
+		"\n\n### Operating system\n\n_No response_\n\n### Browser\n\n_No response_" +
+		"\n\n### Relevant log output\n\n_No response_\n\n### Additional context\n\n_No response_" +
+		"\n\n### Checklist\n\n- [X] I have searched the existing issues for a similar report" +
+		"\n- [X] I am using the latest released version\n- [ ] I am willing to submit a pull request" +
+		"\n\n### Code of Conduct\n\n- [X] I agree to follow this project's Code of Conduct"
+}
+
+func TestDuplicateDetector_NearDuplicateIgnoresFormBoilerplate(t *testing.T) {
+	body := bugFormBody("The export button does nothing", "2.4.1")
+	candidate := bugFormBody("The sidebar text is unreadable", "2.4.1")
+	if d := text.SimHashDistance(text.SimHash(body), text.SimHash(candidate)); d > 3 {
+		t.Fatalf("test bodies should be within 3 bits when the form is signed too, got %d", d)
+	}
+
+	fake := &fakeLLM{result: &ai.DuplicateResult{}}
+	step := &DuplicateDetector{llm: fake}
+	ctx := newRepostCtx(body, candidate)
+	if err := step.Run(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fake.capturedInput == nil {
+		t.Error("different reports from the same form should go to the LLM")
+	}
+	if ctx.Result.IsDuplicate {
+		t.Errorf("expected no duplicate, got DuplicateOf=%d", ctx.Result.DuplicateOf)
+	}
+
+	// A re-post of the same report is still caught.
+	long := bugFormBody(repostBody, "2.3.0")
+	fake = &fakeLLM{result: &ai.DuplicateResult{}}
+	ctx = newRepostCtx(long, long)
+	if err := (&DuplicateDetector{llm: fake}).Run(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fake.capturedInput != nil || ctx.Result.DuplicateOf != 2 {
+		t.Errorf("expected a re-posted form to match #2 without the LLM, got DuplicateOf=%d", ctx.Result.DuplicateOf)
+	}
+}
@@ -0,0 +1,116 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package steps
+
+import (
+	"fmt"
+	"log"
+	"math"
+
+	"github.com/similigh/simili-bot/internal/core/pipeline"
+	"github.com/similigh/simili-bot/internal/integrations/ai"
+	"github.com/similigh/simili-bot/internal/integrations/github"
+	"github.com/similigh/simili-bot/internal/integrations/qdrant"
+)
+
+// EditFilter stops the pipeline for issue edits that do not change what the
+// issue is about (typo fixes, formatting), so similarity, duplicate and
+// quality checks and their LLM calls only run again for meaningful edits.
+// Skipped edits still reach the indexer, which refreshes the stored point.
+type EditFilter struct {
+	embedder *ai.Embedder
+	store    qdrant.VectorStore
+	github   *github.Client
+}
+
+// NewEditFilter creates a new edit filter step.
+func NewEditFilter(deps *pipeline.Dependencies) *EditFilter {
+	return &EditFilter{
+		embedder: deps.Embedder,
+		store:    deps.VectorStore,
+		github:   deps.GitHub,
+	}
+}
+
+// Name returns the step name.
+func (s *EditFilter) Name() string {
+	return "edit_filter"
+}
+
+// Run compares the edited issue with its indexed version.
+func (s *EditFilter) Run(ctx *pipeline.Context) error {
+	if ctx.Issue.EventType != "issues" || ctx.Issue.EventAction != "edited" {
+		return nil
+	}
+	if ctx.Config.Defaults.EditRetriage != nil && !*ctx.Config.Defaults.EditRetriage {
+		return nil
+	}
+	// An author answering a needs-info request often adds only a version or
+	// a short repro; those edits must reach needs_info to be re-assessed.
+	if ni := ctx.Config.NeedsInfo; ni.Enabled != nil && *ni.Enabled && hasLabel(ctx.Issue.Labels, ni.Label) {
+		log.Printf("[edit_filter] Issue #%d is waiting for information, re-triaging", ctx.Issue.Number)
+		return nil
+	}
+	if s.embedder == nil || s.store == nil {
+		log.Printf("[edit_filter] WARNING: Dependencies missing, re-triaging edit")
+		return nil
+	}
+
+	stored, err := s.store.Get(ctx.Ctx, ctx.Config.Qdrant.Collection, issuePointID(ctx.Issue))
+	if err != nil {
+		log.Printf("[edit_filter] Failed to fetch indexed issue #%d: %v (non-blocking)", ctx.Issue.Number, err)
+		return nil
+	}
+	if stored == nil || len(stored.Vector) == 0 {
+		log.Printf("[edit_filter] Issue #%d is not indexed yet, re-triaging", ctx.Issue.Number)
+		return nil
+	}
+	if title, _ := stored.Payload["title"].(string); title != ctx.Issue.Title {
+		log.Printf("[edit_filter] Title of issue #%d changed, re-triaging", ctx.Issue.Number)
+		return nil
+	}
+
+	content := buildIndexContent(ctx, fetchIndexComments(ctx, s.github))
+	embedding, err := s.embedder.Embed(ctx.Ctx, content)
+	if err != nil {
+		log.Printf("[edit_filter] Failed to embed edited issue: %v (non-blocking)", err)
+		return nil
+	}
+	// Let the indexer reuse the embedding.
+	ctx.Metadata["index_content"] = content
+	ctx.Metadata["index_embedding"] = embedding
+
+	distance := cosineDistance(embedding, stored.Vector)
+	ctx.Metadata["edit_distance"] = distance
+	delta := ctx.Config.Defaults.EditSemanticDelta
+	if distance > delta {
+		log.Printf("[edit_filter] Edit to issue #%d changed its meaning (distance %.3f > %.3f), re-triaging", ctx.Issue.Number, distance, delta)
+		return nil
+	}
+
+	log.Printf("[edit_filter] Minor edit to issue #%d (distance %.3f <= %.3f), only refreshing the index", ctx.Issue.Number, distance, delta)
+	ctx.Result.Skipped = true
+	ctx.Result.SkipReason = fmt.Sprintf("minor edit (semantic distance %.3f)", distance)
+	return pipeline.ErrSkipPipeline
+}
+
+// cosineDistance returns 1 - cosine similarity of a and b, or 1 when they
+// cannot be compared.
+func cosineDistance(a, b []float32) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 1
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 1
+	}
+	return 1 - dot/(math.Sqrt(normA)*math.Sqrt(normB))
+}
@@ -0,0 +1,142 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package steps
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/similigh/simili-bot/internal/core/config"
+	"github.com/similigh/simili-bot/internal/core/pipeline"
+	"github.com/similigh/simili-bot/internal/integrations/ai"
+	"github.com/similigh/simili-bot/internal/integrations/qdrant"
+	"github.com/similigh/simili-bot/internal/utils/text"
+)
+
+// replayEmbedder returns an embedder that answers text with vector.
+func replayEmbedder(t *testing.T, text string, vector []float32) *ai.Embedder {
+	t.Helper()
+	dir := t.TempDir()
+	sum := sha256.Sum256([]byte("embedding\x00" + text))
+	key := hex.EncodeToString(sum[:])
+	data, _ := json.Marshal(map[string]interface{}{"kind": "embedding", "prompt": text, "embedding": vector})
+	if err := os.WriteFile(filepath.Join(dir, "embedding-"+key[:16]+".json"), data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cassette, err := ai.OpenCassette(dir, ai.CassetteReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ai.NewReplayEmbedder(cassette, "")
+}
+
+func TestEditFilter(t *testing.T) {
+	issue := pipeline.Issue{Org: "acme", Repo: "app", Number: 7, Title: "Crash on start", Body: "It crashes when started.", EventType: "issues", EventAction: "edited"}
+	content := text.BuildEmbeddingContent(issue.Title, issue.Body, nil)
+
+	tests := []struct {
+		name        string
+		action      string
+		storedTitle string
+		stored      []float32
+		edited      []float32
+		wantSkip    bool
+	}{
+		{name: "typo fix", action: "edited", storedTitle: issue.Title, stored: []float32{1, 0, 0}, edited: []float32{0.999, 0.02, 0}, wantSkip: true},
+		{name: "meaning changed", action: "edited", storedTitle: issue.Title, stored: []float32{1, 0, 0}, edited: []float32{0.6, 0.8, 0}},
+		{name: "title changed", action: "edited", storedTitle: "Old title", stored: []float32{1, 0, 0}, edited: []float32{1, 0, 0}},
+		{name: "not indexed", action: "edited", edited: []float32{1, 0, 0}},
+		{name: "not an edit", action: "opened", storedTitle: issue.Title, stored: []float32{1, 0, 0}, edited: []float32{1, 0, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issue := issue
+			issue.EventAction = tt.action
+			store := &tcMockStore{}
+			if tt.stored != nil {
+				_ = store.Upsert(context.Background(), "issues", []*qdrant.Point{{
+					ID:      issuePointID(&issue),
+					Vector:  tt.stored,
+					Payload: map[string]interface{}{"title": tt.storedTitle},
+				}})
+			}
+			cfg := &config.Config{Qdrant: config.QdrantConfig{Collection: "issues"}}
+			cfg.ApplyDefaults()
+			ctx := pipeline.NewContext(context.Background(), &issue, cfg)
+
+			step := NewEditFilter(&pipeline.Dependencies{Embedder: replayEmbedder(t, content, tt.edited), VectorStore: store})
+			err := step.Run(ctx)
+
+			if skipped := errors.Is(err, pipeline.ErrSkipPipeline); skipped != tt.wantSkip {
+				t.Fatalf("Run() = %v, want skip %v", err, tt.wantSkip)
+			}
+			if !tt.wantSkip && err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			if ctx.Result.Skipped != tt.wantSkip {
+				t.Errorf("Result.Skipped = %v", ctx.Result.Skipped)
+			}
+			if tt.wantSkip {
+				if cached, _ := ctx.Metadata["index_embedding"].([]float32); len(cached) != 3 {
+					t.Errorf("Expected the embedding to be cached for the indexer, got %v", ctx.Metadata["index_embedding"])
+				}
+			}
+		})
+	}
+}
+
+func TestEditFilterDisabled(t *testing.T) {
+	off := false
+	cfg := &config.Config{Defaults: config.DefaultsConfig{EditRetriage: &off}}
+	cfg.ApplyDefaults()
+	ctx := pipeline.NewContext(context.Background(), &pipeline.Issue{EventType: "issues", EventAction: "edited"}, cfg)
+
+	if err := NewEditFilter(&pipeline.Dependencies{VectorStore: &tcMockStore{}}).Run(ctx); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+}
+
+func TestEditFilterNeedsInfo(t *testing.T) {
+	issue := pipeline.Issue{Org: "acme", Repo: "app", Number: 7, Title: "Crash on start", Body: "It crashes when started.", Labels: []string{"needs-info"}, EventType: "issues", EventAction: "edited"}
+	store := &tcMockStore{}
+	_ = store.Upsert(context.Background(), "issues", []*qdrant.Point{{
+		ID:      issuePointID(&issue),
+		Vector:  []float32{1, 0, 0},
+		Payload: map[string]interface{}{"title": issue.Title},
+	}})
+	enabled := true
+	cfg := &config.Config{Qdrant: config.QdrantConfig{Collection: "issues"}, NeedsInfo: config.NeedsInfoConfig{Enabled: &enabled}}
+	cfg.ApplyDefaults()
+	ctx := pipeline.NewContext(context.Background(), &issue, cfg)
+
+	content := text.BuildEmbeddingContent(issue.Title, issue.Body, nil)
+	step := NewEditFilter(&pipeline.Dependencies{Embedder: replayEmbedder(t, content, []float32{0.999, 0.02, 0}), VectorStore: store})
+	if err := step.Run(ctx); err != nil {
+		t.Fatalf("Expected a small edit to an issue waiting for information to be re-triaged, got %v", err)
+	}
+	if ctx.Result.Skipped {
+		t.Error("Result.Skipped = true")
+	}
+}
+
+func TestCosineDistance(t *testing.T) {
+	if d := cosineDistance([]float32{1, 2}, []float32{2, 4}); d > 1e-9 {
+		t.Errorf("parallel vectors: distance = %v, want 0", d)
+	}
+	if d := cosineDistance([]float32{1, 0}, []float32{0, 1}); d != 1 {
+		t.Errorf("orthogonal vectors: distance = %v, want 1", d)
+	}
+	if d := cosineDistance([]float32{1}, []float32{1, 0}); d != 1 {
+		t.Errorf("mismatched lengths: distance = %v, want 1", d)
+	}
+}
@@ -0,0 +1,279 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package steps
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	githubapi "github.com/google/go-github/v60/github"
+
+	"github.com/similigh/simili-bot/internal/core/config"
+	"github.com/similigh/simili-bot/internal/core/pipeline"
+	"github.com/similigh/simili-bot/internal/core/state"
+	"github.com/similigh/simili-bot/internal/integrations/github"
+)
+
+// autoCloseMarker identifies the comment posted by the auto-closer.
+const autoCloseMarker = "<!-- simili-bot-auto-close -->"
+
+// reportWindow bounds how far apart a bot comment and the decisions it
+// announces can be recorded. A report edited in place announces decisions
+// from its creation up to its last edit.
+const reportWindow = 10 * time.Minute
+
+// suggestLabel adds a label to the suggestions and remembers which step
+// suggested it, so feedback on the label can be attributed.
+func suggestLabel(ctx *pipeline.Context, step, label string) {
+	if label == "" || hasLabel(ctx.Result.SuggestedLabels, label) {
+		return
+	}
+	ctx.Result.SuggestedLabels = append(ctx.Result.SuggestedLabels, label)
+	setLabelSource(ctx, step, label)
+}
+
+// setLabelSource records the step that suggested a label.
+func setLabelSource(ctx *pipeline.Context, step, label string) {
+	sources, _ := ctx.Metadata["label_sources"].(map[string]string)
+	if sources == nil {
+		sources = make(map[string]string)
+		ctx.Metadata["label_sources"] = sources
+	}
+	sources[strings.ToLower(label)] = step
+}
+
+// feedbackDecisions describes the actions the executor carried out.
+func feedbackDecisions(ctx *pipeline.Context, now time.Time) []state.Decision {
+	var decisions []state.Decision
+
+	if ctx.Result.IsDuplicate && hasLabel(ctx.Result.LabelsApplied, "potential-duplicate") {
+		threshold, _ := ctx.Metadata["duplicate_threshold"].(float64)
+		decisions = append(decisions, state.Decision{
+			Step:       "duplicate_detector",
+			Action:     "duplicate",
+			Target:     fmt.Sprintf("#%d", ctx.Result.DuplicateOf),
+			Labels:     []string{"potential-duplicate"},
+			Confidence: ctx.Result.DuplicateConfidence,
+			Threshold:  threshold,
+			DecidedAt:  now,
+		})
+	}
+
+	if ctx.Result.Transferred {
+		step := "transfer_check"
+		if method, _ := ctx.Metadata["transfer_method"].(string); method == "llm" {
+			step = "llm_router"
+		}
+		threshold, _ := ctx.Metadata["transfer_threshold"].(float64)
+		decisions = append(decisions, state.Decision{
+			Step:       step,
+			Action:     "transfer",
+			Target:     ctx.Result.TransferTarget,
+			Confidence: ctx.Result.TransferConfidence,
+			Threshold:  threshold,
+			DecidedAt:  now,
+		})
+	}
+
+	sources, _ := ctx.Metadata["label_sources"].(map[string]string)
+	for _, label := range ctx.Result.LabelsApplied {
+		if strings.EqualFold(label, "potential-duplicate") {
+			continue
+		}
+		step := sources[strings.ToLower(label)]
+		if step == "" {
+			step = "triage"
+		}
+		decisions = append(decisions, state.Decision{
+			Step:      step,
+			Action:    "label",
+			Target:    label,
+			Labels:    []string{label},
+			DecidedAt: now,
+		})
+	}
+
+	return decisions
+}
+
+// collectSignals attaches human feedback found in an issue's history to the
+// decisions of its record and returns the number of new signals.
+//
+//   - reopened: a human reopened the issue after the bot closed it; counts
+//     against the close and the duplicate decisions before it.
+//   - unlabeled: a human removed a label the bot applied.
+//   - /undo: a human asked to revert a transfer.
+//   - reactions on the bot's comment: attributed to the decisions the comment
+//     announced. The API has no reaction timestamps, so At is left empty.
+func collectSignals(record *state.FeedbackRecord, events []*githubapi.IssueEvent, comments []*githubapi.IssueComment, reactions map[int64][]*githubapi.Reaction, botUsers []string) int {
+	added := 0
+	attach := func(match func(d *state.Decision) bool, signal state.FeedbackSignal) {
+		for i := range record.Decisions {
+			if d := &record.Decisions[i]; match(d) && d.AddSignal(signal) {
+				added++
+			}
+		}
+	}
+
+	for _, e := range events {
+		actor := e.GetActor().GetLogin()
+		if isBotUser(actor, botUsers) || e.CreatedAt == nil {
+			continue
+		}
+		at := e.GetCreatedAt().Time
+		switch e.GetEvent() {
+		case "reopened":
+			closedBefore := false
+			for _, d := range record.Decisions {
+				if d.Action == "close" && d.DecidedAt.Before(at) {
+					closedBefore = true
+				}
+			}
+			if !closedBefore {
+				continue
+			}
+			attach(func(d *state.Decision) bool {
+				return (d.Action == "close" || d.Action == "duplicate") && d.DecidedAt.Before(at)
+			}, state.FeedbackSignal{Kind: state.SignalReopened, Actor: actor, At: at})
+		case "unlabeled":
+			label := e.GetLabel().GetName()
+			attach(func(d *state.Decision) bool {
+				return hasLabel(d.Labels, label) && d.DecidedAt.Before(at)
+			}, state.FeedbackSignal{Kind: state.SignalLabelRemoved, Actor: actor, Detail: label, At: at})
+		}
+	}
+
+	for _, c := range comments {
+		author := c.GetUser().GetLogin()
+		body := strings.TrimSpace(c.GetBody())
+		created := c.GetCreatedAt().Time
+		updated := c.GetUpdatedAt().Time
+		if updated.Before(created) {
+			updated = created
+		}
+
+		if isBotUser(author, botUsers) {
+			closeComment := strings.Contains(body, autoCloseMarker)
+			if !closeComment && !isBotComment(body) {
+				continue
+			}
+			for _, r := range reactions[c.GetID()] {
+				user := r.GetUser().GetLogin()
+				if isBotUser(user, botUsers) {
+					continue
+				}
+				attach(func(d *state.Decision) bool {
+					if (d.Action == "close") != closeComment {
+						return false
+					}
+					return d.DecidedAt.After(created.Add(-reportWindow)) && d.DecidedAt.Before(updated.Add(reportWindow))
+				}, state.FeedbackSignal{Kind: state.SignalReaction, Actor: user, Detail: r.GetContent()})
+			}
+			continue
+		}
+
+		if strings.HasPrefix(strings.ToLower(body), "/undo") {
+			attach(func(d *state.Decision) bool {
+				return d.Action == "transfer" && d.DecidedAt.Before(created)
+			}, state.FeedbackSignal{Kind: state.SignalUndo, Actor: author, At: created})
+		}
+	}
+
+	return added
+}
+
+// FeedbackCollectResult holds the summary of a feedback collection run.
+type FeedbackCollectResult struct {
+	Records int      `json:"records"`
+	Updated int      `json:"updated"`
+	Signals int      `json:"signals"` // New signals recorded in this run
+	Errors  []string `json:"errors,omitempty"`
+}
+
+// FeedbackCollector scans issues with recorded decisions for human feedback
+// and stores the signals it finds.
+type FeedbackCollector struct {
+	github  *github.Client
+	store   state.FeedbackStore
+	cfg     *config.Config
+	verbose bool
+}
+
+// NewFeedbackCollector creates a new FeedbackCollector.
+func NewFeedbackCollector(gh *github.Client, store state.FeedbackStore, cfg *config.Config, verbose bool) *FeedbackCollector {
+	return &FeedbackCollector{
+		github:  gh,
+		store:   store,
+		cfg:     cfg,
+		verbose: verbose,
+	}
+}
+
+// Run collects feedback for every recorded decision in the repository.
+func (fc *FeedbackCollector) Run(ctx context.Context, org, repo string) (*FeedbackCollectResult, error) {
+	if fc.github == nil || fc.store == nil {
+		return nil, fmt.Errorf("GitHub client and feedback store are required to collect feedback")
+	}
+
+	records, err := fc.store.ListFeedback(ctx, org, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list feedback records: %w", err)
+	}
+
+	result := &FeedbackCollectResult{Records: len(records)}
+	for _, record := range records {
+		added, err := fc.collect(ctx, record)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("#%d: %v", record.IssueNumber, err))
+			continue
+		}
+		if added == 0 {
+			continue
+		}
+		if err := fc.store.SaveFeedback(ctx, record); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("#%d: failed to save feedback: %v", record.IssueNumber, err))
+			continue
+		}
+		result.Updated++
+		result.Signals += added
+		if fc.verbose {
+			log.Printf("[feedback] #%d: recorded %d new signals", record.IssueNumber, added)
+		}
+	}
+	return result, nil
+}
+
+// collect fetches the history of one issue and attaches new signals.
+func (fc *FeedbackCollector) collect(ctx context.Context, record *state.FeedbackRecord) (int, error) {
+	events, err := fc.github.ListIssueEvents(ctx, record.Org, record.Repo, record.IssueNumber)
+	if err != nil {
+		return 0, err
+	}
+
+	comments, err := fetchComments(ctx, fc.github, record.Org, record.Repo, record.IssueNumber, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	reactions := make(map[int64][]*githubapi.Reaction)
+	for _, c := range comments {
+		body := c.GetBody()
+		if !isBotUser(c.GetUser().GetLogin(), fc.cfg.BotUsers) || (!isBotComment(body) && !strings.Contains(body, autoCloseMarker)) {
+			continue
+		}
+		r, err := fc.github.ListIssueCommentReactions(ctx, record.Org, record.Repo, c.GetID())
+		if err != nil {
+			log.Printf("[feedback] #%d: warning: failed to list reactions: %v", record.IssueNumber, err)
+			continue
+		}
+		reactions[c.GetID()] = r
+	}
+
+	return collectSignals(record, events, comments, reactions, fc.cfg.BotUsers), nil
+}
@@ -0,0 +1,167 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package steps
+
+import (
+	"testing"
+	"time"
+
+	githubapi "github.com/google/go-github/v60/github"
+
+	"github.com/similigh/simili-bot/internal/core/pipeline"
+	"github.com/similigh/simili-bot/internal/core/state"
+)
+
+func TestFeedbackDecisions(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	ctx := &pipeline.Context{
+		Issue: &pipeline.Issue{Org: "org", Repo: "app", Number: 9},
+		Result: &pipeline.Result{
+			IsDuplicate:         true,
+			DuplicateOf:         4,
+			DuplicateConfidence: 0.9,
+			Transferred:         true,
+			TransferTarget:      "org/docs",
+			TransferConfidence:  0.7,
+			LabelsApplied:       []string{"potential-duplicate", "bug", "area/ui"},
+		},
+		Metadata: map[string]interface{}{
+			"duplicate_threshold": 0.85,
+			"transfer_method":     "llm",
+			"transfer_threshold":  0.6,
+		},
+	}
+	setLabelSource(ctx, "llm_classifier:area", "Area/UI")
+
+	got := feedbackDecisions(ctx, now)
+	if len(got) != 4 {
+		t.Fatalf("got %d decisions, want 4: %+v", len(got), got)
+	}
+
+	dup := got[0]
+	if dup.Step != "duplicate_detector" || dup.Target != "#4" || dup.Threshold != 0.85 || dup.Labels[0] != "potential-duplicate" {
+		t.Errorf("duplicate decision = %+v", dup)
+	}
+	transfer := got[1]
+	if transfer.Step != "llm_router" || transfer.Target != "org/docs" || transfer.Threshold != 0.6 {
+		t.Errorf("transfer decision = %+v", transfer)
+	}
+	if got[2].Step != "triage" || got[2].Target != "bug" {
+		t.Errorf("label decision = %+v, want triage/bug", got[2])
+	}
+	if got[3].Step != "llm_classifier:area" || got[3].Target != "area/ui" {
+		t.Errorf("label decision = %+v, want llm_classifier:area/area/ui", got[3])
+	}
+	for _, d := range got {
+		if !d.DecidedAt.Equal(now) {
+			t.Errorf("DecidedAt = %v, want %v", d.DecidedAt, now)
+		}
+	}
+}
+
+func TestFeedbackDecisionsNothingApplied(t *testing.T) {
+	ctx := &pipeline.Context{
+		Issue:    &pipeline.Issue{Org: "org", Repo: "app", Number: 9},
+		Result:   &pipeline.Result{IsDuplicate: true, DuplicateOf: 4},
+		Metadata: map[string]interface{}{},
+	}
+	if got := feedbackDecisions(ctx, time.Now()); len(got) != 0 {
+		t.Errorf("expected no decisions when nothing was applied, got %+v", got)
+	}
+}
+
+func TestCollectSignals(t *testing.T) {
+	decided := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	closed := decided.Add(72 * time.Hour)
+	ts := func(t time.Time) *githubapi.Timestamp { return &githubapi.Timestamp{Time: t} }
+	user := func(login string) *githubapi.User { return &githubapi.User{Login: githubapi.String(login)} }
+
+	record := &state.FeedbackRecord{Org: "org", Repo: "app", IssueNumber: 9, Decisions: []state.Decision{
+		{Step: "duplicate_detector", Action: "duplicate", Labels: []string{"potential-duplicate"}, DecidedAt: decided},
+		{Step: "transfer_check", Action: "transfer", Target: "org/docs", DecidedAt: decided},
+		{Step: "triage", Action: "label", Target: "bug", Labels: []string{"bug"}, DecidedAt: decided},
+		{Step: "auto_closer", Action: "close", Labels: []string{"duplicate"}, DecidedAt: closed},
+	}}
+
+	events := []*githubapi.IssueEvent{
+		{Event: githubapi.String("unlabeled"), Actor: user("alice"), Label: &githubapi.Label{Name: githubapi.String("bug")}, CreatedAt: ts(decided.Add(time.Hour))},
+		{Event: githubapi.String("unlabeled"), Actor: user("github-actions[bot]"), Label: &githubapi.Label{Name: githubapi.String("potential-duplicate")}, CreatedAt: ts(closed)},
+		{Event: githubapi.String("reopened"), Actor: user("bob"), CreatedAt: ts(closed.Add(time.Hour))},
+	}
+	comments := []*githubapi.IssueComment{
+		{ID: githubapi.Int64(1), User: user("gh-simili-bot"), Body: githubapi.String("<!-- simili-bot-report -->\n## Report"), CreatedAt: ts(decided.Add(-time.Minute))},
+		{ID: githubapi.Int64(2), User: user("carol"), Body: githubapi.String("/undo"), CreatedAt: ts(decided.Add(2 * time.Hour))},
+		{ID: githubapi.Int64(3), User: user("gh-simili-bot"), Body: githubapi.String(autoCloseMarker + "\n### Auto-Closed"), CreatedAt: ts(closed)},
+	}
+	reactions := map[int64][]*githubapi.Reaction{
+		1: {
+			{Content: githubapi.String("-1"), User: user("dave")},
+			{Content: githubapi.String("+1"), User: user("simili-bot")},
+		},
+		3: {{Content: githubapi.String("confused"), User: user("erin")}},
+	}
+
+	added := collectSignals(record, events, comments, reactions, nil)
+
+	kinds := func(d state.Decision) map[state.SignalKind]int {
+		m := make(map[state.SignalKind]int)
+		for _, s := range d.Signals {
+			m[s.Kind]++
+		}
+		return m
+	}
+
+	dup := kinds(record.Decisions[0])
+	if dup[state.SignalReopened] != 1 || dup[state.SignalReaction] != 1 || dup[state.SignalLabelRemoved] != 0 {
+		t.Errorf("duplicate signals = %+v", record.Decisions[0].Signals)
+	}
+	transfer := kinds(record.Decisions[1])
+	if transfer[state.SignalUndo] != 1 || transfer[state.SignalReaction] != 1 {
+		t.Errorf("transfer signals = %+v", record.Decisions[1].Signals)
+	}
+	label := kinds(record.Decisions[2])
+	if label[state.SignalLabelRemoved] != 1 || label[state.SignalReaction] != 1 {
+		t.Errorf("label signals = %+v", record.Decisions[2].Signals)
+	}
+	closeDecision := kinds(record.Decisions[3])
+	if closeDecision[state.SignalReopened] != 1 || closeDecision[state.SignalReaction] != 1 {
+		t.Errorf("close signals = %+v", record.Decisions[3].Signals)
+	}
+	if added != 8 {
+		t.Errorf("added = %d, want 8", added)
+	}
+
+	// Collecting the same history again records nothing new.
+	if again := collectSignals(record, events, comments, reactions, nil); again != 0 {
+		t.Errorf("second collection added %d signals, want 0", again)
+	}
+}
+
+func TestCollectSignalsEditedReport(t *testing.T) {
+	created := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	edited := created.Add(48 * time.Hour)
+	ts := func(t time.Time) *githubapi.Timestamp { return &githubapi.Timestamp{Time: t} }
+	user := func(login string) *githubapi.User { return &githubapi.User{Login: githubapi.String(login)} }
+
+	record := &state.FeedbackRecord{Org: "org", Repo: "app", IssueNumber: 9, Decisions: []state.Decision{
+		{Step: "triage", Action: "label", Target: "bug", DecidedAt: created},
+		{Step: "duplicate_detector", Action: "duplicate", Target: "#4", DecidedAt: edited},
+		{Step: "triage", Action: "label", Target: "ui", DecidedAt: edited.Add(time.Hour)},
+	}}
+	// The report was posted on the first run and edited in place on a later one.
+	comments := []*githubapi.IssueComment{
+		{ID: githubapi.Int64(1), User: user("gh-simili-bot"), Body: githubapi.String("<!-- simili-bot-report -->\n## Report"), CreatedAt: ts(created), UpdatedAt: ts(edited)},
+	}
+	reactions := map[int64][]*githubapi.Reaction{1: {{Content: githubapi.String("-1"), User: user("dave")}}}
+
+	collectSignals(record, nil, comments, reactions, nil)
+
+	for i, want := range []int{1, 1, 0} {
+		if got := len(record.Decisions[i].Signals); got != want {
+			t.Errorf("decision %d (%s %s) has %d signals, want %d", i, record.Decisions[i].Action, record.Decisions[i].Target, got, want)
+		}
+	}
+}
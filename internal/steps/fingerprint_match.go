@@ -0,0 +1,83 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package steps
+
+import (
+	"log"
+
+	"github.com/similigh/simili-bot/internal/core/pipeline"
+	"github.com/similigh/simili-bot/internal/integrations/qdrant"
+	"github.com/similigh/simili-bot/internal/utils/text"
+)
+
+// FingerprintMatch finds indexed issues that contain the same stack trace as
+// the current issue, by an exact match on the "fingerprints" payload field.
+// Crash reports worded differently embed far apart, so these matches are
+// listed ahead of the semantic results of similarity_search.
+type FingerprintMatch struct {
+	store qdrant.VectorStore
+}
+
+// NewFingerprintMatch creates a new fingerprint match step.
+func NewFingerprintMatch(deps *pipeline.Dependencies) *FingerprintMatch {
+	return &FingerprintMatch{
+		store: deps.VectorStore,
+	}
+}
+
+// Name returns the step name.
+func (s *FingerprintMatch) Name() string {
+	return "fingerprint_match"
+}
+
+// Run looks up the fingerprints of the issue's stack traces.
+func (s *FingerprintMatch) Run(ctx *pipeline.Context) error {
+	cfg := ctx.Config.Fingerprints
+	if cfg.Enabled == nil || !*cfg.Enabled {
+		return nil
+	}
+	if skip, ok := ctx.Metadata["skip_duplicate_detection"].(bool); ok && skip {
+		return nil
+	}
+	if s.store == nil {
+		log.Printf("[fingerprint_match] WARNING: No vector store, skipping fingerprint match")
+		return nil
+	}
+
+	var comments []text.Comment
+	if ctx.Issue.CommentBody != "" {
+		comments = append(comments, text.Comment{Author: ctx.Issue.CommentAuthor, Body: ctx.Issue.CommentBody})
+	}
+	fingerprints := text.IssueFingerprints(ctx.Issue.Title, ctx.Issue.Body, comments, cfg.LineNumbers)
+	if len(fingerprints) == 0 {
+		return nil
+	}
+	ctx.Metadata["fingerprints"] = fingerprints
+
+	results, err := s.store.FindByPayload(ctx.Ctx, ctx.Config.Qdrant.Collection, "fingerprints", fingerprints, ctx.Config.Defaults.MaxSimilarToShow)
+	if err != nil {
+		log.Printf("[fingerprint_match] Failed to look up fingerprints: %v (non-blocking)", err)
+		return nil
+	}
+
+	var matches []pipeline.SimilarIssue
+	for _, res := range results {
+		issue, ok := similarFromResult(ctx, res)
+		if !ok {
+			continue
+		}
+		issue.SameStackTrace = true
+		matches = mergeSimilar(matches, []pipeline.SimilarIssue{issue}, 0)
+	}
+	if len(matches) == 0 {
+		return nil
+	}
+
+	ctx.SimilarIssues = matches
+	ctx.Result.SimilarFound = matches
+	log.Printf("[fingerprint_match] Found %d issue(s) with the same stack trace as #%d", len(matches), ctx.Issue.Number)
+	return nil
+}
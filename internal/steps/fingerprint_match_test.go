@@ -0,0 +1,179 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package steps
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/similigh/simili-bot/internal/core/config"
+	"github.com/similigh/simili-bot/internal/core/pipeline"
+	"github.com/similigh/simili-bot/internal/integrations/qdrant"
+	"github.com/similigh/simili-bot/internal/utils/text"
+)
+
+// fpMockStore returns fixed results for payload lookups and records the
+// values it was asked for.
+type fpMockStore struct {
+	tcMockStore
+	matches []*qdrant.SearchResult
+	key     string
+	values  []string
+}
+
+func (m *fpMockStore) FindByPayload(_ context.Context, _ string, key string, values []string, _ int) ([]*qdrant.SearchResult, error) {
+	m.key = key
+	m.values = values
+	return m.matches, nil
+}
+
+const fpTestTrace = `panic: runtime error: index out of range [3] with length 3
+
+goroutine 1 [running]:
+main.parse(...)
+	/home/dev/app/parse.go:42 +0x1d
+main.main()
+	/home/dev/app/main.go:12 +0x25
+exit status 2`
+
+func TestFingerprintMatch(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.ApplyDefaults()
+	cfg.Fingerprints.Enabled = boolPtr(true)
+
+	store := &fpMockStore{matches: []*qdrant.SearchResult{
+		{ID: "a", Payload: map[string]interface{}{"issue_number": int64(7), "repo": "myrepo", "title": "Crash on parse", "url": "https://github.com/myorg/myrepo/issues/7", "state": "open"}},
+		{ID: "b", Payload: map[string]interface{}{"issue_number": int64(7), "repo": "myrepo", "title": "Crash on parse", "url": "https://github.com/myorg/myrepo/issues/7", "state": "open"}},
+		{ID: "c", Payload: map[string]interface{}{"issue_number": int64(42), "repo": "myrepo", "title": "This issue"}},
+	}}
+	step := &FingerprintMatch{store: store}
+
+	ctx := makeCtx(cfg, "App crashes")
+	ctx.Issue.Body = "Steps: run it\n\n```\n" + fpTestTrace + "\n```"
+	if err := step.Run(ctx); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if store.key != "fingerprints" {
+		t.Errorf("lookup key = %q, want %q", store.key, "fingerprints")
+	}
+	if want := text.Fingerprints(fpTestTrace, false); !reflect.DeepEqual(store.values, want) {
+		t.Errorf("lookup values = %v, want %v", store.values, want)
+	}
+	// Chunks of the same issue collapse into one match and the issue itself is dropped.
+	if len(ctx.SimilarIssues) != 1 {
+		t.Fatalf("SimilarIssues = %+v, want one match", ctx.SimilarIssues)
+	}
+	if got := ctx.SimilarIssues[0]; got.Number != 7 || !got.SameStackTrace {
+		t.Errorf("match = %+v, want #7 with SameStackTrace", got)
+	}
+	if len(ctx.Result.SimilarFound) != 1 {
+		t.Errorf("Result.SimilarFound = %+v, want one match", ctx.Result.SimilarFound)
+	}
+}
+
+// payloadMockStore answers payload lookups from the points upserted to it.
+type payloadMockStore struct {
+	tcMockStore
+}
+
+func (m *payloadMockStore) FindByPayload(_ context.Context, _ string, key string, values []string, _ int) ([]*qdrant.SearchResult, error) {
+	var results []*qdrant.SearchResult
+	for _, p := range m.points {
+		stored, _ := p.Payload[key].([]string)
+		for _, v := range values {
+			if slices.Contains(stored, v) {
+				results = append(results, &qdrant.SearchResult{ID: p.ID, Payload: p.Payload})
+				break
+			}
+		}
+	}
+	return results, nil
+}
+
+// TestFingerprintMatchFindsIndexedTrace indexes an issue that is a bare
+// pasted trace and looks it up from another report of the same crash.
+func TestFingerprintMatchFindsIndexedTrace(t *testing.T) {
+	cfg := &config.Config{Qdrant: config.QdrantConfig{Collection: "issues"}}
+	cfg.ApplyDefaults()
+	cfg.Fingerprints.Enabled = boolPtr(true)
+	store := &payloadMockStore{}
+
+	indexed := &pipeline.Issue{Org: "myorg", Repo: "myrepo", Number: 7, Title: "Crash", Body: fpTestTrace, URL: "https://github.com/myorg/myrepo/issues/7", State: "open", EventType: "issues", EventAction: "opened"}
+	content := text.BuildEmbeddingContent(indexed.Title, indexed.Body, nil)
+	indexer := NewIndexer(&pipeline.Dependencies{Embedder: replayEmbedder(t, content, []float32{1, 0, 0}), VectorStore: store})
+	if err := indexer.Run(pipeline.NewContext(context.Background(), indexed, cfg)); err != nil {
+		t.Fatalf("Indexer.Run() error = %v", err)
+	}
+
+	ctx := makeCtx(cfg, "App panics on parse")
+	ctx.Issue.Body = "Started the app with an empty file:\n\n" + fpTestTrace
+	if err := NewFingerprintMatch(&pipeline.Dependencies{VectorStore: store}).Run(ctx); err != nil {
+		t.Fatalf("FingerprintMatch.Run() error = %v", err)
+	}
+
+	if len(ctx.SimilarIssues) != 1 {
+		t.Fatalf("SimilarIssues = %+v, want the indexed issue", ctx.SimilarIssues)
+	}
+	if got := ctx.SimilarIssues[0]; got.Number != 7 || !got.SameStackTrace || got.Similarity != 0 {
+		t.Errorf("match = %+v, want #7 with SameStackTrace and no similarity score", got)
+	}
+}
+
+func TestFingerprintMatch_Skips(t *testing.T) {
+	tests := []struct {
+		name    string
+		enabled bool
+		body    string
+	}{
+		{name: "disabled", enabled: false, body: fpTestTrace},
+		{name: "no stack trace", enabled: true, body: "The button is the wrong colour."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{}
+			cfg.ApplyDefaults()
+			cfg.Fingerprints.Enabled = boolPtr(tt.enabled)
+
+			store := &fpMockStore{}
+			step := &FingerprintMatch{store: store}
+			ctx := makeCtx(cfg, "App crashes")
+			ctx.Issue.Body = tt.body
+			if err := step.Run(ctx); err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			if store.values != nil {
+				t.Errorf("FindByPayload called with %v, want no lookup", store.values)
+			}
+		})
+	}
+}
+
+func TestMergeSimilar(t *testing.T) {
+	exact := []pipeline.SimilarIssue{{Number: 7, URL: "u7", SameStackTrace: true}}
+	semantic := []pipeline.SimilarIssue{{Number: 3, URL: "u3"}, {Number: 7, URL: "u7", Similarity: 0.8}, {Number: 9, URL: "u9"}}
+
+	got := mergeSimilar(exact, semantic, 3)
+	var numbers []int
+	for _, s := range got {
+		numbers = append(numbers, s.Number)
+	}
+	if want := []int{7, 3, 9}; !reflect.DeepEqual(numbers, want) {
+		t.Errorf("mergeSimilar() numbers = %v, want %v", numbers, want)
+	}
+	if !got[0].SameStackTrace || got[0].Similarity != 0.8 {
+		t.Errorf("exact match should keep SameStackTrace and take the semantic score, got %+v", got[0])
+	}
+
+	if got := mergeSimilar(exact, semantic, 2); len(got) != 2 {
+		t.Errorf("mergeSimilar() with limit 2 returned %d issues", len(got))
+	}
+	if got := mergeSimilar(nil, semantic, 1); len(got) != len(semantic) {
+		t.Errorf("mergeSimilar() without exact matches should return the semantic results unchanged, got %d", len(got))
+	}
+}
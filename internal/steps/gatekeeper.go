@@ -1,7 +1,7 @@
 // Author: Kaviru Hapuarachchi
 // GitHub: https://github.com/Kavirubc
 // Created: 2026-02-02
-// Last Modified: 2026-02-02
+// Last Modified: 2026-10-18
 
 // Package steps contains the modular "Lego block" pipeline steps.
 // Each step implements the pipeline.Step interface.
@@ -129,13 +129,7 @@ func isBotAuthor(author string, configBotUsers []string) bool {
 
 // findRepoConfig looks up the repository configuration.
 func findRepoConfig(ctx *pipeline.Context) *config.RepositoryConfig {
-	for i := range ctx.Config.Repositories {
-		repo := &ctx.Config.Repositories[i]
-		if repo.Org == ctx.Issue.Org && repo.Repo == ctx.Issue.Repo {
-			return repo
-		}
-	}
-	return nil
+	return ctx.Config.FindRepository(ctx.Issue.Org, ctx.Issue.Repo)
 }
 
 // checkIfRecentlyTransferred checks if an issue was recently transferred by examining
@@ -1,7 +1,7 @@
 // Author: Kaviru Hapuarachchi
 // GitHub: https://github.com/Kavirubc
 // Created: 2026-02-02
-// Last Modified: 2026-02-13
+// Last Modified: 2026-10-18
 
 // Package steps provides the indexer step for adding issues to the vector database.
 package steps
@@ -17,6 +17,7 @@ import (
 	"github.com/similigh/simili-bot/internal/integrations/ai"
 	similiGithub "github.com/similigh/simili-bot/internal/integrations/github"
 	"github.com/similigh/simili-bot/internal/integrations/qdrant"
+	"github.com/similigh/simili-bot/internal/utils/issueforms"
 	"github.com/similigh/simili-bot/internal/utils/text"
 )
 
@@ -75,51 +76,25 @@ func (s *Indexer) Run(ctx *pipeline.Context) error {
 		return s.updateState(ctx, collectionName)
 	}
 
-	// Fetch all comment pages for richer embedding content.
-	var textComments []text.Comment
-	if s.github != nil {
-		page := 1
-		for {
-			ghComments, resp, err := s.github.ListComments(ctx.Ctx, ctx.Issue.Org, ctx.Issue.Repo, ctx.Issue.Number, &github.IssueListCommentsOptions{
-				ListOptions: github.ListOptions{PerPage: 100, Page: page},
-			})
-			if err != nil {
-				log.Printf("[indexer] WARNING: failed to fetch comments for #%d: %v", ctx.Issue.Number, err)
-				break
-			}
-			for _, c := range ghComments {
-				author := "deleted-user"
-				if c.User != nil {
-					author = c.User.GetLogin()
-				}
-				textComments = append(textComments, text.Comment{Author: author, Body: strings.TrimSpace(c.GetBody())})
-			}
-			if resp == nil || resp.NextPage == 0 {
-				break
-			}
-			page = resp.NextPage
+	// Reuse the embedding computed by edit_filter when the content is unchanged.
+	comments := fetchIndexComments(ctx, s.github)
+	content := buildIndexContent(ctx, comments)
+	embedding, ok := ctx.Metadata["index_embedding"].([]float32)
+	if cached, _ := ctx.Metadata["index_content"].(string); !ok || cached != content {
+		var err error
+		embedding, err = s.embedder.Embed(ctx.Ctx, content)
+		if err != nil {
+			return fmt.Errorf("failed to generate embedding: %w", err)
 		}
 	}
 
-	// Create content for embedding
-	content := text.BuildEmbeddingContent(ctx.Issue.Title, ctx.Issue.Body, textComments)
-
-	// Generate embedding
-	embedding, err := s.embedder.Embed(ctx.Ctx, content)
-	if err != nil {
-		return fmt.Errorf("failed to generate embedding: %w", err)
-	}
-
 	// Resolve canonical item type for downstream consumers.
 	itemType := "issue"
 	if ctx.Issue.EventType == "pull_request" || ctx.Issue.EventType == "pr_comment" {
 		itemType = "pull_request"
 	}
 
-	// Generate deterministic UUID using the same format as the bulk indexer
-	// (index.go) so that pipeline-indexed and bulk-indexed issues share the
-	// same Qdrant point ID and don't create duplicates.
-	uuidID := uuid.NewMD5(uuid.NameSpaceURL, fmt.Appendf(nil, "%s/%s#%d-chunk-0", ctx.Issue.Org, ctx.Issue.Repo, ctx.Issue.Number)).String()
+	uuidID := issuePointID(ctx.Issue)
 
 	// Prepare point for Qdrant
 	point := &qdrant.Point{
@@ -136,11 +111,15 @@ func (s *Indexer) Run(ctx *pipeline.Context) error {
 			"author":       ctx.Issue.Author,
 			"labels":       ctx.Issue.Labels,
 			"type":         itemType,
+			"assignees":    strings.Join(ctx.Issue.Assignees, ","),
+			"closed_by":    ctx.Issue.ClosedBy,
+			"fingerprints": text.IssueFingerprints(ctx.Issue.Title, ctx.Issue.Body, comments, ctx.Config.Fingerprints.LineNumbers),
+			"simhash":      text.FormatSimHash(issueforms.SimHash(ctx.Issue.Body)),
 		},
 	}
 
 	// Upsert to Qdrant
-	err = s.store.Upsert(ctx.Ctx, collectionName, []*qdrant.Point{point})
+	err := s.store.Upsert(ctx.Ctx, collectionName, []*qdrant.Point{point})
 	if err != nil {
 		return fmt.Errorf("failed to index issue: %w", err)
 	}
@@ -151,13 +130,19 @@ func (s *Indexer) Run(ctx *pipeline.Context) error {
 	return nil
 }
 
-// updateState patches only the "state" field in Qdrant for an existing point.
+// updateState patches the state fields in Qdrant for an existing point:
+// "state", the assignees and, on close, who closed the issue.
 func (s *Indexer) updateState(ctx *pipeline.Context, collectionName string) error {
-	uuidID := uuid.NewMD5(uuid.NameSpaceURL, fmt.Appendf(nil, "%s/%s#%d-chunk-0", ctx.Issue.Org, ctx.Issue.Repo, ctx.Issue.Number)).String()
+	uuidID := issuePointID(ctx.Issue)
 
-	err := s.store.SetPayload(ctx.Ctx, collectionName, uuidID, map[string]interface{}{
-		"state": ctx.Issue.State,
-	})
+	payload := map[string]interface{}{
+		"state":     ctx.Issue.State,
+		"assignees": strings.Join(ctx.Issue.Assignees, ","),
+	}
+	if ctx.Issue.ClosedBy != "" {
+		payload["closed_by"] = ctx.Issue.ClosedBy
+	}
+	err := s.store.SetPayload(ctx.Ctx, collectionName, uuidID, payload)
 	if err != nil {
 		return fmt.Errorf("failed to update state for issue #%d: %w", ctx.Issue.Number, err)
 	}
@@ -166,3 +151,47 @@ func (s *Indexer) updateState(ctx *pipeline.Context, collectionName string) erro
 	ctx.Result.Indexed = true
 	return nil
 }
+
+// issuePointID returns the deterministic point ID of an issue, in the same
+// format as the bulk indexer (index.go), so that pipeline-indexed and
+// bulk-indexed issues share the same Qdrant point and don't create duplicates.
+func issuePointID(issue *pipeline.Issue) string {
+	return uuid.NewMD5(uuid.NameSpaceURL, fmt.Appendf(nil, "%s/%s#%d-chunk-0", issue.Org, issue.Repo, issue.Number)).String()
+}
+
+// fetchIndexComments returns all comments of the issue, or none when no
+// GitHub client is available.
+func fetchIndexComments(ctx *pipeline.Context, gh *similiGithub.Client) []text.Comment {
+	var textComments []text.Comment
+	if gh != nil {
+		page := 1
+		for {
+			ghComments, resp, err := gh.ListComments(ctx.Ctx, ctx.Issue.Org, ctx.Issue.Repo, ctx.Issue.Number, &github.IssueListCommentsOptions{
+				ListOptions: github.ListOptions{PerPage: 100, Page: page},
+			})
+			if err != nil {
+				log.Printf("[indexer] WARNING: failed to fetch comments for #%d: %v", ctx.Issue.Number, err)
+				break
+			}
+			for _, c := range ghComments {
+				author := "deleted-user"
+				if c.User != nil {
+					author = c.User.GetLogin()
+				}
+				textComments = append(textComments, text.Comment{Author: author, Body: strings.TrimSpace(c.GetBody())})
+			}
+			if resp == nil || resp.NextPage == 0 {
+				break
+			}
+			page = resp.NextPage
+		}
+	}
+
+	return textComments
+}
+
+// buildIndexContent builds the text the indexer embeds for an issue: its
+// title, body and comments.
+func buildIndexContent(ctx *pipeline.Context, comments []text.Comment) string {
+	return text.BuildEmbeddingContent(ctx.Issue.Title, ctx.Issue.Body, comments)
+}
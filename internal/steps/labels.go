@@ -0,0 +1,237 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package steps
+
+import (
+	"log"
+	"path"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/similigh/simili-bot/internal/core/config"
+	"github.com/similigh/simili-bot/internal/core/pipeline"
+	"github.com/similigh/simili-bot/internal/integrations/ai"
+	"github.com/similigh/simili-bot/internal/integrations/github"
+)
+
+// repoLabelOptions returns the repository's labels that steps may suggest,
+// filtered by the configured allow and deny lists. It returns nil when the
+// labels cannot be fetched, in which case suggestions are not restricted to
+// the repository's label set.
+func repoLabelOptions(ctx *pipeline.Context, gh *github.Client) []ai.LabelOption {
+	if gh == nil {
+		return nil
+	}
+	labels, err := gh.ListLabels(ctx.Ctx, ctx.Issue.Org, ctx.Issue.Repo)
+	if err != nil {
+		log.Printf("[triage] Failed to list repository labels: %v (non-blocking)", err)
+		return nil
+	}
+	if len(labels) == 0 {
+		return nil
+	}
+
+	options := make([]ai.LabelOption, 0, len(labels))
+	for _, l := range labels {
+		if labelPermitted(l.GetName(), ctx.Config.Labels) {
+			options = append(options, ai.LabelOption{Name: l.GetName(), Description: l.GetDescription()})
+		}
+	}
+	return options
+}
+
+// labelPermitted applies the allow and deny lists to a label name.
+func labelPermitted(name string, policy config.LabelsConfig) bool {
+	if len(policy.Allow) > 0 && !matchesLabelPattern(name, policy.Allow) {
+		return false
+	}
+	return !matchesLabelPattern(name, policy.Deny)
+}
+
+// matchesLabelPattern reports whether name matches any of the
+// case-insensitive glob patterns.
+func matchesLabelPattern(name string, patterns []string) bool {
+	name = strings.ToLower(name)
+	for _, p := range patterns {
+		if ok, err := path.Match(strings.ToLower(strings.TrimSpace(p)), name); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+// constrainLabels maps suggested labels onto the allowed set, fixing case and
+// spelling differences, and drops the ones with no match. With no allowed
+// set, only the deny list is applied. It returns the labels to keep and the
+// suggestions that were dropped.
+func constrainLabels(suggested []string, allowed []ai.LabelOption, policy config.LabelsConfig) (kept, dropped []string) {
+	for _, label := range suggested {
+		name := label
+		if allowed != nil {
+			name = matchLabel(label, allowed)
+		}
+		if name == "" || !labelPermitted(name, policy) {
+			dropped = append(dropped, label)
+			continue
+		}
+		if !hasLabel(kept, name) {
+			kept = append(kept, name)
+		}
+	}
+	return kept, dropped
+}
+
+// matchLabel finds the allowed label a suggestion refers to, trying in turn:
+// an exact case-insensitive match, a match ignoring punctuation and plurals
+// ("Feature Request" -> "feature-request", "bugs" -> "bug"), and a match on
+// the unscoped part of scoped labels ("bug" -> "type/bug"). A step that
+// matches more than one label is ambiguous, and "" is returned.
+func matchLabel(label string, allowed []ai.LabelOption) string {
+	label = strings.TrimSpace(label)
+	if label == "" {
+		return ""
+	}
+
+	strategies := []func(string) string{
+		strings.ToLower,
+		normalizeLabel,
+		func(s string) string { return normalizeLabel(unscopedLabel(s)) },
+	}
+	for _, key := range strategies {
+		want := key(label)
+		match := ""
+		for _, option := range allowed {
+			if key(option.Name) != want {
+				continue
+			}
+			if match != "" && match != option.Name {
+				return ""
+			}
+			match = option.Name
+		}
+		if match != "" {
+			return match
+		}
+	}
+	return ""
+}
+
+// normalizeLabel lowercases a label and drops punctuation, spaces and a
+// trailing plural "s".
+func normalizeLabel(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	out := b.String()
+	if len(out) > 3 {
+		out = strings.TrimSuffix(out, "s")
+	}
+	return out
+}
+
+// unscopedLabel strips a scope prefix such as "type/" or "Type: ".
+func unscopedLabel(s string) string {
+	if i := strings.LastIndexAny(s, "/:"); i >= 0 {
+		return s[i+1:]
+	}
+	return s
+}
+
+// labelGroupOptions lists the labels of each configured group for the
+// triage prompt. Members are expanded against the repository's labels when
+// they are known; otherwise glob patterns are left out.
+func labelGroupOptions(groups []config.LabelGroup, allowed []ai.LabelOption) []ai.LabelGroupOption {
+	options := make([]ai.LabelGroupOption, 0, len(groups))
+	for _, g := range groups {
+		opt := ai.LabelGroupOption{Name: g.Name, Required: g.Required}
+		if allowed != nil {
+			for _, l := range allowed {
+				if matchesLabelPattern(l.Name, g.Members) {
+					opt.Labels = append(opt.Labels, l.Name)
+				}
+			}
+		} else {
+			for _, m := range g.Members {
+				if !strings.ContainsAny(m, "*?[") {
+					opt.Labels = append(opt.Labels, m)
+				}
+			}
+		}
+		if len(opt.Labels) > 0 {
+			options = append(options, opt)
+		}
+	}
+	return options
+}
+
+// applyLabelGroups merges the LLM's group picks into labels. A pick is used
+// when it names a member of its group and is confident enough (required
+// groups take any pick). Exclusive groups keep a single member: the pick, or
+// else the first member already suggested. It also returns the required
+// groups left without a label.
+func applyLabelGroups(labels []string, picks []ai.LabelGroupPick, groups []config.LabelGroup, options []ai.LabelGroupOption) (out []string, missing []string) {
+	out = append(out, labels...)
+	for _, g := range groups {
+		chosen := ""
+		for _, opt := range options {
+			if opt.Name != g.Name {
+				continue
+			}
+			members := make([]ai.LabelOption, len(opt.Labels))
+			for i, l := range opt.Labels {
+				members[i] = ai.LabelOption{Name: l}
+			}
+			for _, pick := range picks {
+				if strings.EqualFold(pick.Group, g.Name) && (g.Required || pick.Confidence >= g.MinConfidence) {
+					chosen = matchLabel(pick.Label, members)
+					break
+				}
+			}
+		}
+
+		if g.Exclusive {
+			var kept []string
+			for _, l := range out {
+				if !matchesLabelPattern(l, g.Members) {
+					kept = append(kept, l)
+				} else if chosen == "" {
+					chosen = l
+				}
+			}
+			out = kept
+		}
+		if chosen != "" && !hasLabel(out, chosen) {
+			out = append(out, chosen)
+		}
+
+		if g.Required && !slices.ContainsFunc(out, func(l string) bool { return matchesLabelPattern(l, g.Members) }) {
+			missing = append(missing, g.Name)
+		}
+	}
+	return out, missing
+}
+
+// exclusiveGroupSwaps returns the labels currently on the issue that must be
+// removed so that applying labels leaves at most one member of each
+// exclusive group.
+func exclusiveGroupSwaps(groups []config.LabelGroup, current, applying []string) []string {
+	var remove []string
+	for _, g := range groups {
+		if !g.Exclusive || !slices.ContainsFunc(applying, func(l string) bool { return matchesLabelPattern(l, g.Members) }) {
+			continue
+		}
+		for _, l := range current {
+			if matchesLabelPattern(l, g.Members) && !hasLabel(applying, l) && !hasLabel(remove, l) {
+				remove = append(remove, l)
+			}
+		}
+	}
+	return remove
+}
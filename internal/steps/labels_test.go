@@ -0,0 +1,120 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package steps
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/similigh/simili-bot/internal/core/config"
+	"github.com/similigh/simili-bot/internal/integrations/ai"
+)
+
+func TestMatchLabel(t *testing.T) {
+	allowed := []ai.LabelOption{
+		{Name: "bug"}, {Name: "feature-request"}, {Name: "area/api"}, {Name: "kind/docs"}, {Name: "team/docs"},
+	}
+	tests := []struct {
+		label string
+		want  string
+	}{
+		{"bug", "bug"},
+		{"Bug", "bug"},
+		{"bugs", "bug"},
+		{"Feature Request", "feature-request"},
+		{"api", "area/api"},
+		{"Area: API", "area/api"},
+		{"docs", ""}, // kind/docs or team/docs
+		{"enhancement", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := matchLabel(tt.label, allowed); got != tt.want {
+			t.Errorf("matchLabel(%q) = %q, want %q", tt.label, got, tt.want)
+		}
+	}
+}
+
+func TestConstrainLabels(t *testing.T) {
+	allowed := []ai.LabelOption{{Name: "bug"}, {Name: "question"}, {Name: "wontfix"}}
+	policy := config.LabelsConfig{Deny: []string{"wont*"}}
+
+	kept, dropped := constrainLabels([]string{"Bug", "bug", "enhancement", "wontfix", "Question"}, allowed, policy)
+	if !reflect.DeepEqual(kept, []string{"bug", "question"}) {
+		t.Errorf("kept = %v", kept)
+	}
+	if !reflect.DeepEqual(dropped, []string{"enhancement", "wontfix"}) {
+		t.Errorf("dropped = %v", dropped)
+	}
+
+	// Without the repository's labels only the deny list applies.
+	kept, _ = constrainLabels([]string{"enhancement", "wontfix"}, nil, policy)
+	if !reflect.DeepEqual(kept, []string{"enhancement"}) {
+		t.Errorf("kept = %v", kept)
+	}
+}
+
+func TestLabelPermitted(t *testing.T) {
+	policy := config.LabelsConfig{Allow: []string{"area/*", "Bug"}, Deny: []string{"area/internal"}}
+	for label, want := range map[string]bool{
+		"area/api":      true,
+		"AREA/UI":       true,
+		"bug":           true,
+		"area/internal": false,
+		"question":      false,
+	} {
+		if got := labelPermitted(label, policy); got != want {
+			t.Errorf("labelPermitted(%q) = %v, want %v", label, got, want)
+		}
+	}
+}
+
+func TestApplyLabelGroups(t *testing.T) {
+	groups := []config.LabelGroup{
+		{Name: "type", Members: []string{"type/*"}, Exclusive: true, Required: true},
+		{Name: "priority", Members: []string{"p1", "p2"}, Exclusive: true, MinConfidence: 0.7},
+		{Name: "area", Members: []string{"area/*"}, Required: true},
+	}
+	allowed := []ai.LabelOption{{Name: "type/bug"}, {Name: "type/feature"}, {Name: "p1"}, {Name: "p2"}, {Name: "area/api"}, {Name: "question"}}
+
+	options := labelGroupOptions(groups, allowed)
+	if len(options) != 3 || !reflect.DeepEqual(options[0].Labels, []string{"type/bug", "type/feature"}) {
+		t.Fatalf("options = %+v", options)
+	}
+
+	picks := []ai.LabelGroupPick{
+		{Group: "type", Label: "bug", Confidence: 0.4},
+		{Group: "priority", Label: "p1", Confidence: 0.5},
+	}
+	labels, missing := applyLabelGroups([]string{"question", "type/feature", "p2"}, picks, groups, options)
+	if !reflect.DeepEqual(labels, []string{"question", "type/bug", "p2"}) {
+		t.Errorf("labels = %v", labels)
+	}
+	if !reflect.DeepEqual(missing, []string{"area"}) {
+		t.Errorf("missing = %v", missing)
+	}
+
+	// Without the repository's labels, only literal members are offered.
+	options = labelGroupOptions(groups, nil)
+	if len(options) != 1 || options[0].Name != "priority" {
+		t.Errorf("options without repository labels = %+v", options)
+	}
+}
+
+func TestExclusiveGroupSwaps(t *testing.T) {
+	groups := []config.LabelGroup{
+		{Name: "priority", Members: []string{"p*"}, Exclusive: true},
+		{Name: "area", Members: []string{"area/*"}},
+	}
+	current := []string{"p2", "area/ui", "bug"}
+
+	if got := exclusiveGroupSwaps(groups, current, []string{"p1", "area/api"}); !reflect.DeepEqual(got, []string{"p2"}) {
+		t.Errorf("swaps = %v, want [p2]", got)
+	}
+	if got := exclusiveGroupSwaps(groups, current, []string{"p2", "bug"}); got != nil {
+		t.Errorf("swaps = %v, want none", got)
+	}
+}
@@ -0,0 +1,303 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package steps
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/similigh/simili-bot/internal/core/config"
+	"github.com/similigh/simili-bot/internal/core/pipeline"
+)
+
+// classifierLLM is the subset of ai.LLMClient used by LLMClassifier.
+type classifierLLM interface {
+	GenerateJSON(ctx context.Context, prompt string, temperature float32, out interface{}) error
+}
+
+// LLMClassifier runs a prompt declared under "classifiers:" in the config and
+// maps fields of the JSON answer to labels, comment sections or metadata.
+// It is configured in the steps list as "llm_classifier:<name>".
+type LLMClassifier struct {
+	name string
+	llm  classifierLLM
+}
+
+// NewLLMClassifier creates a classifier step for the named config entry.
+func NewLLMClassifier(name string, deps *pipeline.Dependencies) (*LLMClassifier, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("llm_classifier step requires a name (e.g. llm_classifier:needs-repro)")
+	}
+	s := &LLMClassifier{name: name}
+	if deps != nil && deps.LLMClient != nil {
+		s.llm = deps.LLMClient
+	}
+	return s, nil
+}
+
+// Name returns the step name.
+func (s *LLMClassifier) Name() string {
+	return "llm_classifier:" + s.name
+}
+
+// classifierPromptData is the data available to classifier prompt templates.
+type classifierPromptData struct {
+	Issue         *pipeline.Issue
+	SimilarIssues []pipeline.SimilarIssue
+}
+
+// classifierRuleData is the data available to rule label/comment templates.
+type classifierRuleData struct {
+	Value  any
+	Output map[string]any
+	Issue  *pipeline.Issue
+}
+
+// Run renders the prompt, queries the LLM and applies the mapping rules.
+func (s *LLMClassifier) Run(ctx *pipeline.Context) error {
+	// Only run on new issues, skip for comments/commands
+	if ctx.Issue.EventType == "issue_comment" || ctx.Issue.EventType == "pr_comment" {
+		return nil
+	}
+	if s.llm == nil {
+		log.Printf("[%s] No LLM client, skipping", s.Name())
+		return nil
+	}
+
+	def := findClassifier(ctx.Config, s.name)
+	if def == nil {
+		log.Printf("[%s] Classifier not defined in config (non-blocking)", s.Name())
+		ctx.Result.Errors = append(ctx.Result.Errors, fmt.Sprintf("%s: classifier %q not defined", s.Name(), s.name))
+		return nil
+	}
+
+	output, err := s.classify(ctx, def)
+	if err != nil {
+		log.Printf("[%s] Classification failed: %v (non-blocking)", s.Name(), err)
+		ctx.Result.Errors = append(ctx.Result.Errors, fmt.Sprintf("%s: %v", s.Name(), err))
+		return nil
+	}
+
+	ctx.Metadata["classifier:"+s.name] = output
+
+	if err := applyClassifierRules(ctx, s.Name(), def.Rules, output); err != nil {
+		log.Printf("[%s] Failed to apply rules: %v (non-blocking)", s.Name(), err)
+		ctx.Result.Errors = append(ctx.Result.Errors, fmt.Sprintf("%s: %v", s.Name(), err))
+	}
+	return nil
+}
+
+// classify renders the prompt and returns the validated JSON output.
+func (s *LLMClassifier) classify(ctx *pipeline.Context, def *config.ClassifierConfig) (map[string]any, error) {
+	prompt, err := renderClassifierPrompt(def, &classifierPromptData{
+		Issue:         ctx.Issue,
+		SimilarIssues: ctx.SimilarIssues,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	temperature := float32(0.2)
+	if def.Temperature != nil {
+		temperature = float32(*def.Temperature)
+	}
+
+	var output map[string]any
+	if err := s.llm.GenerateJSON(ctx.Ctx, prompt, temperature, &output); err != nil {
+		return nil, err
+	}
+	if err := validateClassifierOutput(def.Output, output); err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+// findClassifier returns the classifier definition with the given name.
+func findClassifier(cfg *config.Config, name string) *config.ClassifierConfig {
+	if cfg == nil {
+		return nil
+	}
+	for i := range cfg.Classifiers {
+		if cfg.Classifiers[i].Name == name {
+			return &cfg.Classifiers[i]
+		}
+	}
+	return nil
+}
+
+// renderClassifierPrompt executes the prompt template and appends the JSON
+// output contract derived from the declared schema.
+func renderClassifierPrompt(def *config.ClassifierConfig, data *classifierPromptData) (string, error) {
+	tmpl, err := template.New(def.Name).Option("missingkey=error").Parse(def.Prompt)
+	if err != nil {
+		return "", fmt.Errorf("invalid prompt template: %w", err)
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("failed to render prompt: %w", err)
+	}
+
+	if len(def.Output) > 0 {
+		fields := make([]string, 0, len(def.Output))
+		for field := range def.Output {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+
+		b.WriteString("\n\nRespond with a JSON object containing exactly these fields:\n")
+		for _, field := range fields {
+			fmt.Fprintf(&b, "- %q: %s\n", field, def.Output[field])
+		}
+	}
+	return b.String(), nil
+}
+
+// validateClassifierOutput checks that every declared field is present with
+// the declared type.
+func validateClassifierOutput(schema map[string]string, output map[string]any) error {
+	for field, typ := range schema {
+		v, ok := output[field]
+		if !ok {
+			return fmt.Errorf("missing output field %q", field)
+		}
+		var valid bool
+		switch strings.ToLower(typ) {
+		case "string":
+			_, valid = v.(string)
+		case "number":
+			_, valid = v.(float64)
+		case "boolean", "bool":
+			_, valid = v.(bool)
+		case "array":
+			_, valid = v.([]any)
+		default:
+			valid = true
+		}
+		if !valid {
+			return fmt.Errorf("output field %q is not a %s", field, typ)
+		}
+	}
+	return nil
+}
+
+// applyClassifierRules applies every matching rule to the pipeline context.
+func applyClassifierRules(ctx *pipeline.Context, step string, rules []config.ClassifierRule, output map[string]any) error {
+	for _, rule := range rules {
+		value, ok := output[rule.Field]
+		if !ok || !classifierRuleMatches(rule, value) {
+			continue
+		}
+		data := &classifierRuleData{Value: value, Output: output, Issue: ctx.Issue}
+
+		if rule.Label != "" {
+			label, err := renderRuleTemplate(rule.Label, data)
+			if err != nil {
+				return fmt.Errorf("rule %q label: %w", rule.Field, err)
+			}
+			suggestLabel(ctx, step, label)
+		}
+		if rule.Comment != "" {
+			section, err := renderRuleTemplate(rule.Comment, data)
+			if err != nil {
+				return fmt.Errorf("rule %q comment: %w", rule.Field, err)
+			}
+			if section != "" {
+				appendCommentSection(ctx, section)
+			}
+		}
+		if rule.Metadata != "" {
+			ctx.Metadata[rule.Metadata] = value
+		}
+	}
+	return nil
+}
+
+// classifierRuleMatches reports whether a value satisfies the rule's conditions.
+func classifierRuleMatches(rule config.ClassifierRule, value any) bool {
+	hasCondition := rule.Equals != "" || len(rule.In) > 0 || rule.Min != nil || rule.Max != nil
+	if !hasCondition {
+		return isTruthy(value)
+	}
+
+	text := classifierValueString(value)
+	if rule.Equals != "" && !strings.EqualFold(text, rule.Equals) {
+		return false
+	}
+	if len(rule.In) > 0 {
+		found := false
+		for _, candidate := range rule.In {
+			if strings.EqualFold(text, candidate) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if rule.Min != nil || rule.Max != nil {
+		n, ok := value.(float64)
+		if !ok {
+			return false
+		}
+		if rule.Min != nil && n < *rule.Min {
+			return false
+		}
+		if rule.Max != nil && n > *rule.Max {
+			return false
+		}
+	}
+	return true
+}
+
+// isTruthy treats false, zero, empty strings and empty arrays as unset.
+func isTruthy(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case float64:
+		return v != 0
+	case string:
+		return strings.TrimSpace(v) != ""
+	case []any:
+		return len(v) > 0
+	default:
+		return true
+	}
+}
+
+// classifierValueString formats a JSON value for string comparison.
+func classifierValueString(value any) string {
+	switch v := value.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// renderRuleTemplate executes a label or comment template.
+func renderRuleTemplate(text string, data *classifierRuleData) (string, error) {
+	tmpl, err := template.New("rule").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(b.String()), nil
+}
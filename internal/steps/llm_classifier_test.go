@@ -0,0 +1,167 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package steps
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/similigh/simili-bot/internal/core/config"
+	"github.com/similigh/simili-bot/internal/core/pipeline"
+)
+
+// mockClassifierLLM returns a canned JSON answer and records the prompt.
+type mockClassifierLLM struct {
+	response string
+	err      error
+	prompt   string
+}
+
+func (m *mockClassifierLLM) GenerateJSON(_ context.Context, prompt string, _ float32, out interface{}) error {
+	m.prompt = prompt
+	if m.err != nil {
+		return m.err
+	}
+	return json.Unmarshal([]byte(m.response), out)
+}
+
+func floatPtr(f float64) *float64 { return &f }
+
+func newClassifierTestCtx(classifiers ...config.ClassifierConfig) *pipeline.Context {
+	issue := &pipeline.Issue{Org: "org", Repo: "repo", Number: 12, Title: "Crash on save", Body: "Stack trace attached", EventType: "issues"}
+	cfg := &config.Config{Classifiers: classifiers}
+	ctx := pipeline.NewContext(context.Background(), issue, cfg)
+	ctx.SimilarIssues = []pipeline.SimilarIssue{{Number: 4, Title: "Save fails"}}
+	return ctx
+}
+
+func reproClassifier() config.ClassifierConfig {
+	return config.ClassifierConfig{
+		Name:   "needs-repro",
+		Prompt: "Issue: {{.Issue.Title}}\n{{range .SimilarIssues}}Similar #{{.Number}}: {{.Title}}\n{{end}}",
+		Output: map[string]string{"has_repro": "boolean", "area": "string", "confidence": "number"},
+		Rules: []config.ClassifierRule{
+			{Field: "has_repro", Equals: "false", Label: "needs-repro", Comment: "#### Reproduction\nPlease add steps to reproduce."},
+			{Field: "area", Label: "area/{{.Value}}", Metadata: "area"},
+			{Field: "confidence", Min: floatPtr(0.9), Label: "high-confidence"},
+		},
+	}
+}
+
+func TestLLMClassifier_AppliesRules(t *testing.T) {
+	llm := &mockClassifierLLM{response: `{"has_repro": false, "area": "editor", "confidence": 0.5}`}
+	step := &LLMClassifier{name: "needs-repro", llm: llm}
+	ctx := newClassifierTestCtx(reproClassifier())
+
+	if err := step.Run(ctx); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if !strings.Contains(llm.prompt, "Issue: Crash on save") || !strings.Contains(llm.prompt, "Similar #4: Save fails") {
+		t.Errorf("prompt template not rendered, got %q", llm.prompt)
+	}
+	if !strings.Contains(llm.prompt, `"has_repro": boolean`) {
+		t.Errorf("prompt should include output contract, got %q", llm.prompt)
+	}
+
+	for _, want := range []string{"needs-repro", "area/editor"} {
+		if !hasLabel(ctx.Result.SuggestedLabels, want) {
+			t.Errorf("expected label %q, got %v", want, ctx.Result.SuggestedLabels)
+		}
+	}
+	if hasLabel(ctx.Result.SuggestedLabels, "high-confidence") {
+		t.Errorf("min rule should not fire for 0.5, got %v", ctx.Result.SuggestedLabels)
+	}
+	sections, _ := ctx.Metadata["comment_sections"].([]string)
+	if len(sections) != 1 || !strings.Contains(sections[0], "steps to reproduce") {
+		t.Errorf("expected comment section, got %v", sections)
+	}
+	if ctx.Metadata["area"] != "editor" {
+		t.Errorf("expected area metadata, got %v", ctx.Metadata["area"])
+	}
+	if _, ok := ctx.Metadata["classifier:needs-repro"].(map[string]any); !ok {
+		t.Error("expected raw classifier output in metadata")
+	}
+}
+
+func TestLLMClassifier_FailuresAreNonBlocking(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		llmErr   error
+		classes  []config.ClassifierConfig
+		wantErr  string
+	}{
+		{"undefined classifier", `{}`, nil, nil, "not defined"},
+		{"llm error", "", errors.New("boom"), []config.ClassifierConfig{reproClassifier()}, "boom"},
+		{"missing field", `{"has_repro": true, "area": "x"}`, nil, []config.ClassifierConfig{reproClassifier()}, `missing output field "confidence"`},
+		{"wrong type", `{"has_repro": "no", "area": "x", "confidence": 1}`, nil, []config.ClassifierConfig{reproClassifier()}, `"has_repro" is not a boolean`},
+		{"bad template", `{}`, nil, []config.ClassifierConfig{{Name: "needs-repro", Prompt: "{{.Nope"}}, "invalid prompt template"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step := &LLMClassifier{name: "needs-repro", llm: &mockClassifierLLM{response: tt.response, err: tt.llmErr}}
+			ctx := newClassifierTestCtx(tt.classes...)
+			if err := step.Run(ctx); err != nil {
+				t.Fatalf("expected nil error, got %v", err)
+			}
+			if len(ctx.Result.Errors) != 1 || !strings.Contains(ctx.Result.Errors[0], tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, ctx.Result.Errors)
+			}
+			if len(ctx.Result.SuggestedLabels) != 0 {
+				t.Errorf("expected no labels on failure, got %v", ctx.Result.SuggestedLabels)
+			}
+		})
+	}
+}
+
+func TestClassifierRuleMatches(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  config.ClassifierRule
+		value any
+		want  bool
+	}{
+		{"truthy bool", config.ClassifierRule{}, true, true},
+		{"falsy bool", config.ClassifierRule{}, false, false},
+		{"empty string", config.ClassifierRule{}, "", false},
+		{"equals case-insensitive", config.ClassifierRule{Equals: "Bug"}, "bug", true},
+		{"equals number", config.ClassifierRule{Equals: "2"}, float64(2), true},
+		{"in list", config.ClassifierRule{In: []string{"p0", "p1"}}, "P1", true},
+		{"not in list", config.ClassifierRule{In: []string{"p0", "p1"}}, "p2", false},
+		{"within range", config.ClassifierRule{Min: floatPtr(0.5), Max: floatPtr(1)}, 0.7, true},
+		{"below min", config.ClassifierRule{Min: floatPtr(0.5)}, 0.2, false},
+		{"range on string", config.ClassifierRule{Min: floatPtr(0.5)}, "0.7", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifierRuleMatches(tt.rule, tt.value); got != tt.want {
+				t.Errorf("classifierRuleMatches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegistry_BuildsClassifierSteps(t *testing.T) {
+	r := pipeline.NewRegistry()
+	RegisterAll(r)
+
+	p, err := r.BuildFromNames([]string{"llm_classifier:needs-repro"}, &pipeline.Dependencies{})
+	if err != nil {
+		t.Fatalf("BuildFromNames: %v", err)
+	}
+	if got := p.Steps()[0].Name(); got != "llm_classifier:needs-repro" {
+		t.Errorf("expected classifier step name, got %q", got)
+	}
+	if _, err := r.BuildFromNames([]string{"llm_classifier:"}, &pipeline.Dependencies{}); err == nil {
+		t.Error("expected error for classifier step without a name")
+	}
+}
@@ -1,7 +1,7 @@
 // Author: Kaviru Hapuarachchi
 // GitHub: https://github.com/kavirubc
 // Created: 2026-02-04
-// Last Modified: 2026-02-05
+// Last Modified: 2026-10-18
 
 package steps
 
@@ -232,6 +232,8 @@ func (s *LLMRouter) Run(ctx *pipeline.Context) error {
 			ctx.TransferTarget = targetRepo
 			ctx.Result.TransferTarget = targetRepo
 			ctx.Metadata["original_repo"] = currentRepo
+			ctx.Metadata["transfer_method"] = "llm"
+			ctx.Metadata["transfer_threshold"] = ctx.Config.Transfer.MediumConfidence
 			log.Printf("[llm_router] Proactive transfer (%.2f) from %s to %s", confidence, currentRepo, targetRepo)
 		} else {
 			// Low confidence: silent
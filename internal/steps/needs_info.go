@@ -0,0 +1,103 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package steps
+
+import (
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/similigh/simili-bot/internal/core/pipeline"
+	"github.com/similigh/simili-bot/internal/integrations/ai"
+)
+
+// NeedsInfo runs the needs-info loop on issue events. Issues whose quality
+// score is below the configured threshold get the needs-info label and a
+// list of questions for their author; once an edit brings the score up to
+// the threshold the label is removed again. Replies from the author are
+// re-assessed by the command handler (see handleNeedsInfoReply), and
+// close-stale closes issues that never got one.
+type NeedsInfo struct{}
+
+// NewNeedsInfo creates a new needs-info step.
+func NewNeedsInfo(deps *pipeline.Dependencies) *NeedsInfo {
+	return &NeedsInfo{}
+}
+
+// Name returns the step name.
+func (s *NeedsInfo) Name() string {
+	return "needs_info"
+}
+
+// Run labels low-quality issues or clears the label from improved ones,
+// based on the quality_checker result.
+func (s *NeedsInfo) Run(ctx *pipeline.Context) error {
+	cfg := ctx.Config.NeedsInfo
+	if cfg.Enabled == nil || !*cfg.Enabled {
+		return nil
+	}
+	if ctx.Issue.EventType != "issues" {
+		return nil
+	}
+	quality, ok := ctx.Metadata["quality_result"].(*ai.QualityResult)
+	if !ok || quality == nil {
+		return nil
+	}
+
+	labelled := hasLabel(ctx.Issue.Labels, cfg.Label)
+	if quality.Score >= cfg.Threshold {
+		if labelled {
+			log.Printf("[needs_info] Quality of #%d is now %.2f, removing %q", ctx.Issue.Number, quality.Score, cfg.Label)
+			queueLabelRemoval(ctx, cfg.Label)
+		}
+		return nil
+	}
+
+	questions := needsInfoQuestions(quality)
+	if len(questions) == 0 {
+		return nil
+	}
+	ctx.Metadata["needs_info_questions"] = questions
+	if !labelled && !hasLabel(ctx.Result.SuggestedLabels, cfg.Label) {
+		ctx.Result.SuggestedLabels = append(ctx.Result.SuggestedLabels, cfg.Label)
+		setLabelSource(ctx, s.Name(), cfg.Label)
+	}
+	log.Printf("[needs_info] Quality of #%d is %.2f, asking the author for %d detail(s)", ctx.Issue.Number, quality.Score, len(questions))
+	return nil
+}
+
+// needsInfoQuestions lists what the author is asked to add: the missing
+// elements found by the quality check, then its suggestions.
+func needsInfoQuestions(quality *ai.QualityResult) []string {
+	var questions []string
+	for _, q := range append(append([]string{}, quality.Issues...), quality.Suggestions...) {
+		q = strings.TrimSpace(q)
+		if q != "" && !slices.Contains(questions, q) {
+			questions = append(questions, q)
+		}
+	}
+	return questions
+}
+
+// isNeedsInfoReply reports whether the comment being processed is the
+// issue author's reply on an issue that is waiting for more information.
+func isNeedsInfoReply(ctx *pipeline.Context) bool {
+	cfg := ctx.Config.NeedsInfo
+	if cfg.Enabled == nil || !*cfg.Enabled || ctx.Issue.EventType != "issue_comment" {
+		return false
+	}
+	return ctx.Issue.CommentAuthor != "" &&
+		strings.EqualFold(ctx.Issue.CommentAuthor, ctx.Issue.Author) &&
+		hasLabel(ctx.Issue.Labels, cfg.Label)
+}
+
+// queueLabelRemoval asks the action executor to remove label from the issue.
+func queueLabelRemoval(ctx *pipeline.Context, label string) {
+	labels, _ := ctx.Metadata["labels_to_remove"].([]string)
+	if !hasLabel(labels, label) {
+		ctx.Metadata["labels_to_remove"] = append(labels, label)
+	}
+}
@@ -0,0 +1,99 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package steps
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/similigh/simili-bot/internal/core/config"
+	"github.com/similigh/simili-bot/internal/core/pipeline"
+	"github.com/similigh/simili-bot/internal/integrations/ai"
+)
+
+func TestNeedsInfoRun(t *testing.T) {
+	lowQuality := &ai.QualityResult{Score: 0.3, Issues: []string{"Steps to reproduce", "Version"}, Suggestions: []string{"Version", "Add logs"}}
+	goodQuality := &ai.QualityResult{Score: 0.8}
+
+	tests := []struct {
+		name          string
+		labels        []string
+		quality       *ai.QualityResult
+		wantLabels    []string
+		wantQuestions []string
+		wantRemoved   []string
+	}{
+		{"asks for details", nil, lowQuality, []string{"bug", "needs-info"}, []string{"Steps to reproduce", "Version", "Add logs"}, nil},
+		{"already labelled", []string{"Needs-Info"}, lowQuality, []string{"bug"}, []string{"Steps to reproduce", "Version", "Add logs"}, nil},
+		{"improved", []string{"needs-info"}, goodQuality, []string{"bug"}, nil, []string{"needs-info"}},
+		{"good from the start", nil, goodQuality, []string{"bug"}, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enabled := true
+			cfg := &config.Config{NeedsInfo: config.NeedsInfoConfig{Enabled: &enabled}}
+			cfg.ApplyDefaults()
+			ctx := pipeline.NewContext(context.Background(), &pipeline.Issue{EventType: "issues", Labels: tt.labels}, cfg)
+			ctx.Result.SuggestedLabels = []string{"bug"}
+			ctx.Metadata["quality_result"] = tt.quality
+
+			if err := NewNeedsInfo(&pipeline.Dependencies{}).Run(ctx); err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			if !reflect.DeepEqual(ctx.Result.SuggestedLabels, tt.wantLabels) {
+				t.Errorf("SuggestedLabels = %v, want %v", ctx.Result.SuggestedLabels, tt.wantLabels)
+			}
+			questions, _ := ctx.Metadata["needs_info_questions"].([]string)
+			if !reflect.DeepEqual(questions, tt.wantQuestions) {
+				t.Errorf("questions = %v, want %v", questions, tt.wantQuestions)
+			}
+			removed, _ := ctx.Metadata["labels_to_remove"].([]string)
+			if !reflect.DeepEqual(removed, tt.wantRemoved) {
+				t.Errorf("labels_to_remove = %v, want %v", removed, tt.wantRemoved)
+			}
+		})
+	}
+}
+
+func TestNeedsInfoDisabled(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.ApplyDefaults()
+	ctx := pipeline.NewContext(context.Background(), &pipeline.Issue{EventType: "issues"}, cfg)
+	ctx.Metadata["quality_result"] = &ai.QualityResult{Score: 0.1, Issues: []string{"Version"}}
+
+	if err := NewNeedsInfo(&pipeline.Dependencies{}).Run(ctx); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(ctx.Result.SuggestedLabels) != 0 || ctx.Metadata["needs_info_questions"] != nil {
+		t.Errorf("Expected no changes when disabled, got labels %v", ctx.Result.SuggestedLabels)
+	}
+}
+
+func TestIsNeedsInfoReply(t *testing.T) {
+	enabled := true
+	cfg := &config.Config{NeedsInfo: config.NeedsInfoConfig{Enabled: &enabled}}
+	cfg.ApplyDefaults()
+
+	tests := []struct {
+		name  string
+		issue pipeline.Issue
+		want  bool
+	}{
+		{"author reply", pipeline.Issue{EventType: "issue_comment", Author: "alice", CommentAuthor: "Alice", Labels: []string{"needs-info"}}, true},
+		{"other commenter", pipeline.Issue{EventType: "issue_comment", Author: "alice", CommentAuthor: "bob", Labels: []string{"needs-info"}}, false},
+		{"not waiting", pipeline.Issue{EventType: "issue_comment", Author: "alice", CommentAuthor: "alice", Labels: []string{"bug"}}, false},
+		{"issue event", pipeline.Issue{EventType: "issues", Author: "alice", CommentAuthor: "alice", Labels: []string{"needs-info"}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := pipeline.NewContext(context.Background(), &tt.issue, cfg)
+			if got := isNeedsInfoReply(ctx); got != tt.want {
+				t.Errorf("isNeedsInfoReply() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
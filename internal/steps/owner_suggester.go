@@ -0,0 +1,273 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package steps
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/similigh/simili-bot/internal/core/config"
+	"github.com/similigh/simili-bot/internal/core/pipeline"
+	"github.com/similigh/simili-bot/internal/integrations/github"
+	"github.com/similigh/simili-bot/internal/utils/codeowners"
+)
+
+// maxLinkedPRs caps the linked pull requests whose files are checked
+// against CODEOWNERS.
+const maxLinkedPRs = 5
+
+var (
+	urlPattern  = regexp.MustCompile(`https?://\S+`)
+	pathPattern = regexp.MustCompile(`(?:\.{0,2}/)?(?:[\w.-]+/)+[\w-]+\.[A-Za-z0-9]+`)
+)
+
+// OwnerSuggester suggests who should look at an issue. It combines the
+// CODEOWNERS entries for paths mentioned in the issue or changed by its
+// linked pull requests, the assignees and closers of similar past issues,
+// and the configured area owners. In "assign" mode the top suggested user is
+// also assigned to issues that have no assignee yet.
+type OwnerSuggester struct {
+	github *github.Client
+}
+
+// NewOwnerSuggester creates a new owner suggester step.
+func NewOwnerSuggester(deps *pipeline.Dependencies) *OwnerSuggester {
+	return &OwnerSuggester{
+		github: deps.GitHub,
+	}
+}
+
+// Name returns the step name.
+func (s *OwnerSuggester) Name() string {
+	return "owner_suggester"
+}
+
+// Run ranks the candidate owners of the issue.
+func (s *OwnerSuggester) Run(ctx *pipeline.Context) error {
+	cfg := ctx.Config.Owners
+	if cfg.Enabled == nil || !*cfg.Enabled {
+		return nil
+	}
+	if ctx.Issue.EventType == "issue_comment" || ctx.Issue.EventType == "pr_comment" {
+		return nil
+	}
+
+	var prFiles map[int][]string
+	owners := s.loadCodeOwners(ctx)
+	if owners != nil {
+		prFiles = s.linkedPRFiles(ctx)
+	}
+
+	suggestions := rankOwners(ctx, owners, prFiles)
+	if len(suggestions) > cfg.MaxSuggestions {
+		suggestions = suggestions[:cfg.MaxSuggestions]
+	}
+	ctx.Result.SuggestedOwners = suggestions
+	if len(suggestions) == 0 {
+		return nil
+	}
+	log.Printf("[owner_suggester] Suggested owners for #%d: %v", ctx.Issue.Number, ownerNames(suggestions))
+
+	// Only the best-ranked user is assigned; the rest stay suggestions.
+	if cfg.Mode == "assign" && len(ctx.Issue.Assignees) == 0 {
+		for _, sug := range suggestions {
+			if isUserOwner(sug.Owner) {
+				ctx.Metadata["assignees"] = []string{strings.TrimPrefix(sug.Owner, "@")}
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// loadCodeOwners fetches the repository's CODEOWNERS file, or returns nil
+// when there is none.
+func (s *OwnerSuggester) loadCodeOwners(ctx *pipeline.Context) *codeowners.File {
+	if s.github == nil {
+		return nil
+	}
+	for _, path := range codeowners.Locations {
+		data, err := s.github.GetFileContent(ctx.Ctx, ctx.Issue.Org, ctx.Issue.Repo, path, "")
+		if err == nil {
+			return codeowners.Parse(data)
+		}
+	}
+	return nil
+}
+
+// linkedPRFiles returns the files changed by the pull request being
+// processed, or by the pull requests that reference the issue.
+func (s *OwnerSuggester) linkedPRFiles(ctx *pipeline.Context) map[int][]string {
+	numbers := []int{ctx.Issue.Number}
+	if ctx.Issue.EventType != "pull_request" {
+		var err error
+		numbers, err = s.github.ListLinkedPullRequests(ctx.Ctx, ctx.Issue.Org, ctx.Issue.Repo, ctx.Issue.Number)
+		if err != nil {
+			log.Printf("[owner_suggester] Failed to list linked pull requests: %v (non-blocking)", err)
+			return nil
+		}
+		if len(numbers) > maxLinkedPRs {
+			numbers = numbers[:maxLinkedPRs]
+		}
+	}
+
+	files := make(map[int][]string, len(numbers))
+	for _, n := range numbers {
+		changed, err := s.github.ListPullRequestFiles(ctx.Ctx, ctx.Issue.Org, ctx.Issue.Repo, n)
+		if err != nil {
+			log.Printf("[owner_suggester] Failed to list files of #%d: %v (non-blocking)", n, err)
+			continue
+		}
+		for _, f := range changed {
+			files[n] = append(files[n], f.GetFilename())
+		}
+	}
+	return files
+}
+
+// ownerScores accumulates scores and reasons per owner. A reason counts once
+// per owner, however many paths or issues repeat it.
+type ownerScores map[string]*pipeline.OwnerSuggestion
+
+func (o ownerScores) add(owner string, score float64, reason string) {
+	key := strings.ToLower(strings.TrimPrefix(owner, "@"))
+	if key == "" {
+		return
+	}
+	sug, ok := o[key]
+	if !ok {
+		if !strings.Contains(owner, "@") {
+			owner = "@" + owner
+		}
+		sug = &pipeline.OwnerSuggestion{Owner: owner}
+		o[key] = sug
+	}
+	for _, r := range sug.Reasons {
+		if r == reason {
+			return
+		}
+	}
+	sug.Score += score
+	sug.Reasons = append(sug.Reasons, reason)
+}
+
+// rankOwners scores every candidate owner and returns them best first.
+// CODEOWNERS and area matches score 1 each; assignees of a similar issue
+// score its similarity and its closer half of that. The issue's author, bots
+// and bot_users (e.g. a PAT account that closed duplicates) are never
+// suggested.
+func rankOwners(ctx *pipeline.Context, owners *codeowners.File, prFiles map[int][]string) []pipeline.OwnerSuggestion {
+	scores := ownerScores{}
+
+	if owners != nil {
+		for _, path := range mentionedPaths(ctx.Issue.Title + "\n" + ctx.Issue.Body) {
+			for _, owner := range owners.Owners(path) {
+				scores.add(owner, 1, fmt.Sprintf("owns `%s`", path))
+			}
+		}
+		prs := make([]int, 0, len(prFiles))
+		for n := range prFiles {
+			prs = append(prs, n)
+		}
+		sort.Ints(prs)
+		for _, n := range prs {
+			for _, path := range prFiles[n] {
+				for _, owner := range owners.Owners(path) {
+					scores.add(owner, 1, fmt.Sprintf("owns files changed in #%d", n))
+				}
+			}
+		}
+	}
+
+	for _, similar := range ctx.SimilarIssues {
+		weight := similar.Similarity
+		if similar.SameStackTrace && weight == 0 {
+			// Found only by its stack trace: the same crash is a strong signal.
+			weight = 1
+		}
+		for _, assignee := range similar.Assignees {
+			scores.add(assignee, weight, fmt.Sprintf("assigned to similar #%d", similar.Number))
+		}
+		if similar.ClosedBy != "" {
+			scores.add(similar.ClosedBy, weight/2, fmt.Sprintf("closed similar #%d", similar.Number))
+		}
+	}
+
+	labels := append(append([]string{}, ctx.Issue.Labels...), ctx.Result.SuggestedLabels...)
+	for _, area := range ctx.Config.Owners.Areas {
+		if !inArea(area, labels) {
+			continue
+		}
+		for _, owner := range area.Owners {
+			scores.add(owner, 1, fmt.Sprintf("owns the %s area", area.Area))
+		}
+	}
+
+	var ranked []pipeline.OwnerSuggestion
+	for key, sug := range scores {
+		if key == strings.ToLower(ctx.Issue.Author) || isBotAuthor(key, ctx.Config.BotUsers) {
+			continue
+		}
+		ranked = append(ranked, *sug)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		return ranked[i].Owner < ranked[j].Owner
+	})
+	return ranked
+}
+
+// inArea reports whether any of the labels puts the issue in the area.
+func inArea(area config.OwnerArea, labels []string) bool {
+	patterns := area.Labels
+	if len(patterns) == 0 {
+		patterns = []string{area.Area}
+	}
+	for _, l := range labels {
+		if matchesLabelPattern(l, patterns) {
+			return true
+		}
+	}
+	return false
+}
+
+// mentionedPaths extracts file paths such as "internal/api/handler.go" or
+// "./src/app.ts:42" from text, ignoring URLs.
+func mentionedPaths(text string) []string {
+	text = urlPattern.ReplaceAllString(text, " ")
+	var paths []string
+	for _, match := range pathPattern.FindAllString(text, -1) {
+		path := strings.TrimPrefix(strings.TrimPrefix(match, "./"), "/")
+		if strings.HasPrefix(path, "../") {
+			continue
+		}
+		if !slices.Contains(paths, path) {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// isUserOwner reports whether an owner is a single user that can be
+// assigned, rather than a team or an email address.
+func isUserOwner(owner string) bool {
+	return strings.HasPrefix(owner, "@") && !strings.Contains(owner, "/")
+}
+
+// ownerNames lists the owners of the suggestions.
+func ownerNames(suggestions []pipeline.OwnerSuggestion) []string {
+	names := make([]string, len(suggestions))
+	for i, s := range suggestions {
+		names[i] = s.Owner
+	}
+	return names
+}
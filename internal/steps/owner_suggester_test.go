@@ -0,0 +1,96 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package steps
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/similigh/simili-bot/internal/core/config"
+	"github.com/similigh/simili-bot/internal/core/pipeline"
+	"github.com/similigh/simili-bot/internal/utils/codeowners"
+)
+
+func TestMentionedPaths(t *testing.T) {
+	body := "Crash in `internal/api/handler.go:42`, see ./web/src/app.ts and " +
+		"https://github.com/acme/app/blob/main/docs/setup.md. Also ../outside/x.go and internal/api/handler.go again, and/or this."
+	want := []string{"internal/api/handler.go", "web/src/app.ts"}
+	if got := mentionedPaths(body); !reflect.DeepEqual(got, want) {
+		t.Errorf("mentionedPaths() = %v, want %v", got, want)
+	}
+}
+
+func TestRankOwners(t *testing.T) {
+	cfg := &config.Config{Owners: config.OwnersConfig{Areas: []config.OwnerArea{
+		{Area: "api", Labels: []string{"area/api"}, Owners: []string{"@acme/api"}},
+		{Area: "docs", Owners: []string{"@writer"}},
+	}}, BotUsers: []string{"simili-pat"}}
+	cfg.ApplyDefaults()
+	ctx := pipeline.NewContext(context.Background(), &pipeline.Issue{
+		Author: "reporter",
+		Body:   "The panic comes from internal/api/handler.go",
+		Labels: []string{"bug"},
+	}, cfg)
+	ctx.Result.SuggestedLabels = []string{"area/api"}
+	ctx.SimilarIssues = []pipeline.SimilarIssue{
+		{Number: 12, Similarity: 0.9, Assignees: []string{"alice", "dependabot[bot]"}, ClosedBy: "bob"},
+		{Number: 13, Similarity: 0.8, Assignees: []string{"Reporter"}},
+		{Number: 14, Similarity: 0.7, ClosedBy: "Simili-PAT"},
+	}
+	owners := codeowners.Parse([]byte("/internal/api/ @alice @acme/api\n/web/ @carol\n"))
+	prFiles := map[int][]string{20: {"web/app.ts", "web/index.ts"}}
+
+	got := rankOwners(ctx, owners, prFiles)
+	want := []pipeline.OwnerSuggestion{
+		{Owner: "@acme/api", Score: 2, Reasons: []string{"owns `internal/api/handler.go`", "owns the api area"}},
+		{Owner: "@alice", Score: 1.9, Reasons: []string{"owns `internal/api/handler.go`", "assigned to similar #12"}},
+		{Owner: "@carol", Score: 1, Reasons: []string{"owns files changed in #20"}},
+		{Owner: "@bob", Score: 0.45, Reasons: []string{"closed similar #12"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("rankOwners() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestOwnerSuggesterAssignMode(t *testing.T) {
+	enabled := true
+	cfg := &config.Config{Owners: config.OwnersConfig{Enabled: &enabled, Mode: "assign", MaxSuggestions: 2, Areas: []config.OwnerArea{
+		{Area: "api", Owners: []string{"@acme/api", "@dave"}},
+	}}}
+	cfg.ApplyDefaults()
+
+	ctx := pipeline.NewContext(context.Background(), &pipeline.Issue{EventType: "issues", Labels: []string{"API"}}, cfg)
+	ctx.SimilarIssues = []pipeline.SimilarIssue{{Number: 3, Similarity: 0.5, Assignees: []string{"erin"}}}
+	if err := NewOwnerSuggester(&pipeline.Dependencies{}).Run(ctx); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(ctx.Result.SuggestedOwners) != 2 {
+		t.Errorf("Expected 2 suggestions, got %+v", ctx.Result.SuggestedOwners)
+	}
+	if got, _ := ctx.Metadata["assignees"].([]string); !reflect.DeepEqual(got, []string{"dave"}) {
+		t.Errorf("assignees = %v, want [dave]", got)
+	}
+
+	// Only the top user is assigned, however many are suggested.
+	cfg.Owners.MaxSuggestions = 3
+	ctx = pipeline.NewContext(context.Background(), &pipeline.Issue{EventType: "issues", Labels: []string{"api"}}, cfg)
+	ctx.SimilarIssues = []pipeline.SimilarIssue{{Number: 3, Similarity: 0.5, Assignees: []string{"erin"}}}
+	_ = NewOwnerSuggester(&pipeline.Dependencies{}).Run(ctx)
+	if len(ctx.Result.SuggestedOwners) != 3 {
+		t.Errorf("Expected 3 suggestions, got %+v", ctx.Result.SuggestedOwners)
+	}
+	if got, _ := ctx.Metadata["assignees"].([]string); !reflect.DeepEqual(got, []string{"dave"}) {
+		t.Errorf("assignees = %v, want only [dave]", got)
+	}
+
+	// Issues that already have an assignee are left alone.
+	ctx = pipeline.NewContext(context.Background(), &pipeline.Issue{EventType: "issues", Labels: []string{"api"}, Assignees: []string{"frank"}}, cfg)
+	_ = NewOwnerSuggester(&pipeline.Dependencies{}).Run(ctx)
+	if _, ok := ctx.Metadata["assignees"]; ok {
+		t.Errorf("Expected no assignment for an assigned issue")
+	}
+}
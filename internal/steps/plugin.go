@@ -0,0 +1,247 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package steps
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/similigh/simili-bot/internal/core/pipeline"
+)
+
+// PluginSchemaVersion is the version of the JSON protocol spoken with
+// out-of-process plugin steps. Plugins must echo it back in their response.
+const PluginSchemaVersion = 1
+
+// PluginRequest is the JSON document written to a plugin's stdin.
+type PluginRequest struct {
+	SchemaVersion int                     `json:"schema_version"`
+	Step          string                  `json:"step"`
+	Issue         *pipeline.Issue         `json:"issue"`
+	Result        *pipeline.Result        `json:"result"`
+	SimilarIssues []pipeline.SimilarIssue `json:"similar_issues"`
+	Metadata      map[string]any          `json:"metadata"`
+}
+
+// PluginResponse is the patch a plugin writes to stdout.
+// All fields are optional; an empty object is a valid no-op response.
+type PluginResponse struct {
+	SchemaVersion  int            `json:"schema_version"`
+	AddLabels      []string       `json:"add_labels,omitempty"`
+	CommentSection string         `json:"comment_section,omitempty"`
+	Metadata       map[string]any `json:"metadata,omitempty"`
+	Skip           bool           `json:"skip,omitempty"`
+	SkipReason     string         `json:"skip_reason,omitempty"`
+	Errors         []string       `json:"errors,omitempty"`
+}
+
+// pluginExcludedMetadata lists metadata keys that are never sent to plugins,
+// either because they are large (embeddings) or internal to the bot.
+var pluginExcludedMetadata = map[string]bool{
+	"issue_embedding": true,
+}
+
+// pluginMetadataPrefix namespaces the metadata keys a plugin may set, so it
+// cannot replace the keys built-in steps rely on.
+const pluginMetadataPrefix = "plugin."
+
+// pluginEnvKeys are the only environment variables passed to plugins. The
+// bot's tokens and API keys are not among them.
+var pluginEnvKeys = []string{"PATH", "HOME", "TMPDIR", "LANG", "LC_ALL", "TZ"}
+
+// PluginStep runs an external command as a pipeline step. It is configured
+// in the steps list as "exec:<command> [args...]".
+type PluginStep struct {
+	name    string
+	command string
+	args    []string
+}
+
+// NewPluginStep creates a plugin step from the text following "exec:".
+func NewPluginStep(spec string) (*PluginStep, error) {
+	fields := strings.Fields(spec)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("exec step requires a command (e.g. exec:./my-step)")
+	}
+	return &PluginStep{
+		name:    "exec:" + strings.TrimSpace(spec),
+		command: fields[0],
+		args:    fields[1:],
+	}, nil
+}
+
+// Name returns the step name.
+func (s *PluginStep) Name() string {
+	return s.name
+}
+
+// Run invokes the plugin and applies its patch to the pipeline context.
+// Plugin failures are logged and recorded in Result.Errors but do not fail
+// the pipeline, matching the graceful degradation of built-in steps.
+func (s *PluginStep) Run(ctx *pipeline.Context) error {
+	timeout := 30 * time.Second
+	if ctx.Config != nil && ctx.Config.Plugins.TimeoutSeconds > 0 {
+		timeout = time.Duration(ctx.Config.Plugins.TimeoutSeconds) * time.Second
+	}
+
+	resp, err := s.invoke(ctx, timeout)
+	if err != nil {
+		log.Printf("[%s] Plugin failed: %v (non-blocking)", s.name, err)
+		ctx.Result.Errors = append(ctx.Result.Errors, fmt.Sprintf("%s: %v", s.name, err))
+		return nil
+	}
+
+	return applyPluginResponse(ctx, s.name, resp)
+}
+
+// invoke runs the plugin process with the serialized context on stdin and
+// decodes its stdout.
+func (s *PluginStep) invoke(ctx *pipeline.Context, timeout time.Duration) (*PluginResponse, error) {
+	input, err := json.Marshal(buildPluginRequest(ctx, s.name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal plugin request: %w", err)
+	}
+
+	parent := ctx.Ctx
+	if parent == nil {
+		parent = context.Background()
+	}
+	runCtx, cancel := context.WithTimeout(parent, timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(runCtx, s.command, s.args...)
+	cmd.Env = pluginEnv()
+	cmd.Stdin = bytes.NewReader(input)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	// Don't wait on grandchildren that inherited stdout after a timeout kill.
+	cmd.WaitDelay = time.Second
+
+	runErr := cmd.Run()
+	if stderrText := strings.TrimSpace(stderr.String()); stderrText != "" {
+		log.Printf("[%s] stderr: %s", s.name, stderrText)
+	}
+	if errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+		return nil, fmt.Errorf("timed out after %s", timeout)
+	}
+	if runErr != nil {
+		return nil, fmt.Errorf("command failed: %w", runErr)
+	}
+
+	return parsePluginResponse(stdout.Bytes())
+}
+
+// pluginEnv returns the minimal environment plugins run with.
+func pluginEnv() []string {
+	var env []string
+	for _, key := range pluginEnvKeys {
+		if value, ok := os.LookupEnv(key); ok {
+			env = append(env, key+"="+value)
+		}
+	}
+	return env
+}
+
+// buildPluginRequest serializes the parts of the pipeline context exposed to
+// plugins. Metadata values that cannot be encoded as JSON are dropped.
+func buildPluginRequest(ctx *pipeline.Context, name string) *PluginRequest {
+	metadata := make(map[string]any, len(ctx.Metadata))
+	for k, v := range ctx.Metadata {
+		if pluginExcludedMetadata[k] {
+			continue
+		}
+		if _, err := json.Marshal(v); err != nil {
+			continue
+		}
+		metadata[k] = v
+	}
+
+	similar := ctx.SimilarIssues
+	if similar == nil {
+		similar = []pipeline.SimilarIssue{}
+	}
+
+	return &PluginRequest{
+		SchemaVersion: PluginSchemaVersion,
+		Step:          name,
+		Issue:         ctx.Issue,
+		Result:        ctx.Result,
+		SimilarIssues: similar,
+		Metadata:      metadata,
+	}
+}
+
+// parsePluginResponse decodes and validates a plugin's stdout.
+// A missing schema_version is treated as the current version.
+func parsePluginResponse(data []byte) (*PluginResponse, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return &PluginResponse{SchemaVersion: PluginSchemaVersion}, nil
+	}
+
+	var resp PluginResponse
+	if err := json.Unmarshal(trimmed, &resp); err != nil {
+		return nil, fmt.Errorf("invalid plugin response: %w", err)
+	}
+	if resp.SchemaVersion == 0 {
+		resp.SchemaVersion = PluginSchemaVersion
+	}
+	if resp.SchemaVersion != PluginSchemaVersion {
+		return nil, fmt.Errorf("unsupported plugin schema_version %d (expected %d)", resp.SchemaVersion, PluginSchemaVersion)
+	}
+	return &resp, nil
+}
+
+// applyPluginResponse merges a plugin patch into the pipeline context.
+func applyPluginResponse(ctx *pipeline.Context, name string, resp *PluginResponse) error {
+	for _, label := range resp.AddLabels {
+		suggestLabel(ctx, name, strings.TrimSpace(label))
+	}
+
+	if section := strings.TrimSpace(resp.CommentSection); section != "" {
+		appendCommentSection(ctx, section)
+	}
+
+	for k, v := range resp.Metadata {
+		if !strings.HasPrefix(k, pluginMetadataPrefix) {
+			log.Printf("[%s] Ignoring metadata key %q (plugin keys must start with %q)", name, k, pluginMetadataPrefix)
+			continue
+		}
+		ctx.Metadata[k] = v
+	}
+
+	for _, e := range resp.Errors {
+		ctx.Result.Errors = append(ctx.Result.Errors, fmt.Sprintf("%s: %s", name, e))
+	}
+
+	if resp.Skip {
+		ctx.Result.Skipped = true
+		ctx.Result.SkipReason = resp.SkipReason
+		if ctx.Result.SkipReason == "" {
+			ctx.Result.SkipReason = fmt.Sprintf("skipped by %s", name)
+		}
+		log.Printf("[%s] Plugin requested pipeline skip: %s", name, ctx.Result.SkipReason)
+		return pipeline.ErrSkipPipeline
+	}
+
+	return nil
+}
+
+// appendCommentSection adds a markdown section that ResponseBuilder renders
+// after the built-in sections of the triage report.
+func appendCommentSection(ctx *pipeline.Context, section string) {
+	sections, _ := ctx.Metadata["comment_sections"].([]string)
+	ctx.Metadata["comment_sections"] = append(sections, section)
+}
@@ -0,0 +1,186 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package steps
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/similigh/simili-bot/internal/core/config"
+	"github.com/similigh/simili-bot/internal/core/pipeline"
+)
+
+// writePluginScript writes an executable shell script into a temp dir.
+func writePluginScript(t *testing.T, body string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin tests use POSIX shell scripts")
+	}
+	path := filepath.Join(t.TempDir(), "plugin.sh")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+body), 0755); err != nil {
+		t.Fatalf("failed to write plugin script: %v", err)
+	}
+	return path
+}
+
+func newPluginTestCtx(timeoutSeconds int) *pipeline.Context {
+	issue := &pipeline.Issue{Org: "org", Repo: "repo", Number: 7, Title: "CLA missing", EventType: "issues"}
+	cfg := &config.Config{Plugins: config.PluginsConfig{TimeoutSeconds: timeoutSeconds}}
+	ctx := pipeline.NewContext(context.Background(), issue, cfg)
+	ctx.Metadata["issue_embedding"] = []float32{0.1, 0.2}
+	return ctx
+}
+
+func TestPluginStep_AppliesPatch(t *testing.T) {
+	script := writePluginScript(t, `cat > /dev/null
+printf '%s' '{"schema_version":1,"add_labels":["needs-cla"],"comment_section":"#### CLA\nPlease sign.","metadata":{"plugin.cla_signed":false,"comment_sections":["replaced"],"label_sources":{}}}'
+`)
+	step, err := NewPluginStep(script)
+	if err != nil {
+		t.Fatalf("NewPluginStep: %v", err)
+	}
+
+	ctx := newPluginTestCtx(5)
+	if err := step.Run(ctx); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if !hasLabel(ctx.Result.SuggestedLabels, "needs-cla") {
+		t.Errorf("expected needs-cla label, got %v", ctx.Result.SuggestedLabels)
+	}
+	sections, _ := ctx.Metadata["comment_sections"].([]string)
+	if len(sections) != 1 || !strings.Contains(sections[0], "Please sign.") {
+		t.Errorf("expected comment section, got %v", sections)
+	}
+	if v, ok := ctx.Metadata["plugin.cla_signed"].(bool); !ok || v {
+		t.Errorf("expected plugin.cla_signed=false in metadata, got %v", ctx.Metadata["plugin.cla_signed"])
+	}
+	if _, ok := ctx.Metadata["label_sources"].(map[string]string); !ok {
+		t.Errorf("plugin should not replace label_sources, got %#v", ctx.Metadata["label_sources"])
+	}
+}
+
+func TestPluginStep_ReceivesContext(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "stdin.json")
+	script := writePluginScript(t, "cat > "+out+"\necho '{}'\n")
+	step, _ := NewPluginStep(script)
+
+	ctx := newPluginTestCtx(5)
+	ctx.SimilarIssues = []pipeline.SimilarIssue{{Number: 3, Title: "Other"}}
+	if err := step.Run(ctx); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("plugin did not receive stdin: %v", err)
+	}
+	var req PluginRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		t.Fatalf("invalid request JSON: %v", err)
+	}
+	if req.SchemaVersion != PluginSchemaVersion {
+		t.Errorf("expected schema_version %d, got %d", PluginSchemaVersion, req.SchemaVersion)
+	}
+	if req.Issue == nil || req.Issue.Number != 7 {
+		t.Errorf("expected issue #7, got %+v", req.Issue)
+	}
+	if len(req.SimilarIssues) != 1 || req.SimilarIssues[0].Number != 3 {
+		t.Errorf("expected similar issue #3, got %+v", req.SimilarIssues)
+	}
+	if _, ok := req.Metadata["issue_embedding"]; ok {
+		t.Error("issue_embedding should not be sent to plugins")
+	}
+}
+
+func TestPluginStep_MinimalEnv(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "secret-token")
+	out := filepath.Join(t.TempDir(), "env.txt")
+	script := writePluginScript(t, "env > "+out+"\necho '{}'\n")
+	step, _ := NewPluginStep(script)
+
+	if err := step.Run(newPluginTestCtx(5)); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("plugin did not run: %v", err)
+	}
+	if strings.Contains(string(data), "secret-token") {
+		t.Errorf("plugin environment should not include the bot's tokens:\n%s", data)
+	}
+	if !strings.Contains(string(data), "PATH=") {
+		t.Errorf("plugin environment should include PATH:\n%s", data)
+	}
+}
+
+func TestPluginStep_Skip(t *testing.T) {
+	script := writePluginScript(t, `echo '{"skip":true,"skip_reason":"SLA exempt"}'`)
+	step, _ := NewPluginStep(script)
+
+	ctx := newPluginTestCtx(5)
+	err := step.Run(ctx)
+	if !errors.Is(err, pipeline.ErrSkipPipeline) {
+		t.Fatalf("expected ErrSkipPipeline, got %v", err)
+	}
+	if ctx.Result.SkipReason != "SLA exempt" {
+		t.Errorf("expected skip reason, got %q", ctx.Result.SkipReason)
+	}
+}
+
+func TestPluginStep_FailuresAreNonBlocking(t *testing.T) {
+	tests := []struct {
+		name    string
+		script  string
+		timeout int
+		wantErr string
+	}{
+		{"non-zero exit", "exit 3", 5, "command failed"},
+		{"invalid json", "echo not-json", 5, "invalid plugin response"},
+		{"schema mismatch", `echo '{"schema_version":99}'`, 5, "unsupported plugin schema_version"},
+		{"timeout", "sleep 5", 1, "timed out"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, _ := NewPluginStep(writePluginScript(t, tt.script))
+			ctx := newPluginTestCtx(tt.timeout)
+			if err := step.Run(ctx); err != nil {
+				t.Fatalf("expected nil error, got %v", err)
+			}
+			if len(ctx.Result.Errors) != 1 || !strings.Contains(ctx.Result.Errors[0], tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, ctx.Result.Errors)
+			}
+		})
+	}
+}
+
+func TestRegistry_BuildsExecSteps(t *testing.T) {
+	r := pipeline.NewRegistry()
+	RegisterAll(r)
+
+	p, err := r.BuildFromNames([]string{"gatekeeper", "exec:./check-cla --strict"}, &pipeline.Dependencies{})
+	if err != nil {
+		t.Fatalf("BuildFromNames: %v", err)
+	}
+	got := p.Steps()[1].Name()
+	if got != "exec:./check-cla --strict" {
+		t.Errorf("expected exec step name, got %q", got)
+	}
+
+	if _, err := r.BuildFromNames([]string{"exec:"}, &pipeline.Dependencies{}); err == nil {
+		t.Error("expected error for exec step without a command")
+	}
+	if _, err := r.BuildFromNames([]string{"unknown:thing"}, &pipeline.Dependencies{}); err == nil {
+		t.Error("expected error for unknown prefix")
+	}
+}
@@ -1,7 +1,7 @@
 // Author: Kaviru Hapuarachchi
 // GitHub: https://github.com/Kavirubc
 // Created: 2026-02-02
-// Last Modified: 2026-02-02
+// Last Modified: 2026-10-18
 
 package steps
 
@@ -23,6 +23,14 @@ func RegisterAll(r *pipeline.Registry) {
 		return NewVectorDBPrep(deps), nil
 	})
 
+	r.Register("edit_filter", func(deps *pipeline.Dependencies) (pipeline.Step, error) {
+		return NewEditFilter(deps), nil
+	})
+
+	r.Register("fingerprint_match", func(deps *pipeline.Dependencies) (pipeline.Step, error) {
+		return NewFingerprintMatch(deps), nil
+	})
+
 	r.Register("similarity_search", func(deps *pipeline.Dependencies) (pipeline.Step, error) {
 		return NewSimilaritySearch(deps), nil
 	})
@@ -43,10 +51,26 @@ func RegisterAll(r *pipeline.Registry) {
 		return NewQualityChecker(deps), nil
 	})
 
+	r.Register("template_checker", func(deps *pipeline.Dependencies) (pipeline.Step, error) {
+		return NewTemplateChecker(deps), nil
+	})
+
 	r.Register("duplicate_detector", func(deps *pipeline.Dependencies) (pipeline.Step, error) {
 		return NewDuplicateDetector(deps), nil
 	})
 
+	r.Register("needs_info", func(deps *pipeline.Dependencies) (pipeline.Step, error) {
+		return NewNeedsInfo(deps), nil
+	})
+
+	r.Register("severity_estimator", func(deps *pipeline.Dependencies) (pipeline.Step, error) {
+		return NewSeverityEstimator(deps), nil
+	})
+
+	r.Register("owner_suggester", func(deps *pipeline.Dependencies) (pipeline.Step, error) {
+		return NewOwnerSuggester(deps), nil
+	})
+
 	r.Register("response_builder", func(deps *pipeline.Dependencies) (pipeline.Step, error) {
 		return NewResponseBuilder(deps), nil
 	})
@@ -62,4 +86,14 @@ func RegisterAll(r *pipeline.Registry) {
 	r.Register("pending_action_scheduler", func(deps *pipeline.Dependencies) (pipeline.Step, error) {
 		return NewPendingActionScheduler(deps), nil
 	})
+
+	// Out-of-process plugins: "exec:./my-step"
+	r.RegisterPrefix("exec", func(arg string, deps *pipeline.Dependencies) (pipeline.Step, error) {
+		return NewPluginStep(arg)
+	})
+
+	// Declarative prompt steps: "llm_classifier:<name>" (see classifiers: in config)
+	r.RegisterPrefix("llm_classifier", func(arg string, deps *pipeline.Dependencies) (pipeline.Step, error) {
+		return NewLLMClassifier(arg, deps)
+	})
 }
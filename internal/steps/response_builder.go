@@ -1,7 +1,7 @@
 // Author: Kaviru Hapuarachchi
 // GitHub: https://github.com/kavirubc
 // Created: 2026-02-02
-// Last Modified: 2026-03-06
+// Last Modified: 2026-10-18
 
 // Package steps provides the response builder step.
 package steps
@@ -42,6 +42,9 @@ func (s *ResponseBuilder) Run(ctx *pipeline.Context) error {
 
 	comment := s.buildTriageSummary(ctx)
 	ctx.Metadata["comment"] = comment
+	if alert := s.buildOnCallAlert(ctx); alert != "" {
+		ctx.Metadata["on_call_alert"] = alert
+	}
 
 	log.Printf("[response_builder] Built triage summary")
 	return nil
@@ -53,13 +56,23 @@ func (s *ResponseBuilder) buildTriageSummary(ctx *pipeline.Context) string {
 
 	// Header — includes a hidden marker used by command_handler to detect bot's own comments.
 	// The marker is an HTML comment so it survives any formatting changes to the visible title.
-	sections = append(sections, "<!-- simili-bot-report -->\n### Simili Triage Report\n")
+	sections = append(sections, reportMarker+"\n### Simili Triage Report\n")
+
+	// Urgent issues first (P0 Alert)
+	if urgentSection := s.buildUrgentSection(ctx); urgentSection != "" {
+		sections = append(sections, urgentSection)
+	}
 
 	// Quality Assessment Section
 	if qualitySection := s.buildQualitySection(ctx); qualitySection != "" {
 		sections = append(sections, qualitySection)
 	}
 
+	// Needs-info questions for the author (Alert)
+	if needsInfoSection := s.buildNeedsInfoSection(ctx); needsInfoSection != "" {
+		sections = append(sections, needsInfoSection)
+	}
+
 	// Classification Header
 	sections = append(sections, "#### Classification")
 	// Start Classification Table
@@ -71,6 +84,16 @@ func (s *ResponseBuilder) buildTriageSummary(ctx *pipeline.Context) string {
 		sections = append(sections, labelsRow)
 	}
 
+	// Required label groups the triage left without a label
+	if missingRow := s.buildMissingGroupsRow(ctx); missingRow != "" {
+		sections = append(sections, missingRow)
+	}
+
+	// Priority Row
+	if priorityRow := s.buildPriorityRow(ctx); priorityRow != "" {
+		sections = append(sections, priorityRow)
+	}
+
 	// Transfer Row
 	if transferRow := s.buildTransferRow(ctx); transferRow != "" {
 		sections = append(sections, transferRow)
@@ -88,11 +111,23 @@ func (s *ResponseBuilder) buildTriageSummary(ctx *pipeline.Context) string {
 		sections = append(sections, similarSection)
 	}
 
+	// Suggested Owners (Collapsible)
+	if ownersSection := s.buildOwnersSection(ctx); ownersSection != "" {
+		sections = append(sections, ownersSection)
+	}
+
 	// Possible Duplicate (Alert)
 	if duplicateSection := s.buildDuplicateSection(ctx); duplicateSection != "" {
 		sections = append(sections, duplicateSection)
 	}
 
+	// Extra sections contributed by plugin and custom steps
+	if extra, ok := ctx.Metadata["comment_sections"].([]string); ok {
+		for _, section := range extra {
+			sections = append(sections, section, "")
+		}
+	}
+
 	// Footer
 	sections = append(sections, "---\n<sub>Generated by [Simili Bot](https://github.com/similigh/simili-bot)</sub>")
 
@@ -129,6 +164,28 @@ func (s *ResponseBuilder) buildQualitySection(ctx *pipeline.Context) string {
 	return strings.Join(parts, "\n")
 }
 
+// buildNeedsInfoSection asks the issue author for the details the quality
+// check found missing, in place of the collapsed improvements list.
+func (s *ResponseBuilder) buildNeedsInfoSection(ctx *pipeline.Context) string {
+	questions, ok := ctx.Metadata["needs_info_questions"].([]string)
+	if !ok || len(questions) == 0 {
+		return ""
+	}
+
+	var parts []string
+	parts = append(parts, "> [!IMPORTANT]")
+	parts = append(parts, "> **More information needed**")
+	parts = append(parts, fmt.Sprintf("> @%s, could you edit the issue or reply with the following?", ctx.Issue.Author))
+	for _, q := range questions {
+		parts = append(parts, fmt.Sprintf("> - [ ] %s", q))
+	}
+	parts = append(parts, ">")
+	parts = append(parts, fmt.Sprintf("> Issues without a reply are closed after %d days.", ctx.Config.NeedsInfo.StaleDays))
+
+	parts = append(parts, "")
+	return strings.Join(parts, "\n")
+}
+
 // buildLabelsRow creates the labels row for the classification table.
 func (s *ResponseBuilder) buildLabelsRow(ctx *pipeline.Context) string {
 	if len(ctx.Result.SuggestedLabels) == 0 {
@@ -153,6 +210,66 @@ func (s *ResponseBuilder) buildLabelsRow(ctx *pipeline.Context) string {
 	return fmt.Sprintf("| **Labels** | %s |", strings.Join(badges, " "))
 }
 
+// buildMissingGroupsRow lists the required label groups triage could not
+// pick a label for, so a maintainer can fill them in by hand.
+func (s *ResponseBuilder) buildMissingGroupsRow(ctx *pipeline.Context) string {
+	missing, ok := ctx.Metadata["missing_label_groups"].([]string)
+	if !ok || len(missing) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("| **Needs a label** | %s |", strings.Join(missing, ", "))
+}
+
+// buildPriorityRow creates the priority row for the classification table.
+func (s *ResponseBuilder) buildPriorityRow(ctx *pipeline.Context) string {
+	if ctx.Result.Priority == "" {
+		return ""
+	}
+
+	value := fmt.Sprintf("**%s**", ctx.Result.Priority)
+	if ctx.Result.SeverityCategory != "" {
+		value += fmt.Sprintf(" (%s)", ctx.Result.SeverityCategory)
+	}
+	if ctx.Result.SeverityReasoning != "" {
+		value += fmt.Sprintf(": %s", strings.ReplaceAll(ctx.Result.SeverityReasoning, "|", "\\|"))
+	}
+	return fmt.Sprintf("| **Priority** | %s |", value)
+}
+
+// buildUrgentSection flags P0 issues at the top of the report.
+func (s *ResponseBuilder) buildUrgentSection(ctx *pipeline.Context) string {
+	severity, ok := ctx.Metadata["severity_result"].(*ai.SeverityResult)
+	if !ok || severity == nil || severity.Priority != "P0" {
+		return ""
+	}
+
+	var parts []string
+	parts = append(parts, "> [!CAUTION]")
+	parts = append(parts, fmt.Sprintf("> **Estimated P0** (%s, confidence: %d%%)", severity.Category, int(severity.Confidence*100)))
+	for _, signal := range severity.Signals {
+		parts = append(parts, fmt.Sprintf("> - %s", signal))
+	}
+
+	parts = append(parts, "")
+	return strings.Join(parts, "\n")
+}
+
+// buildOnCallAlert mentions the configured on-call handle for confident P0
+// estimates. It is posted as its own comment rather than inside the report,
+// since the report is edited in place and edits never send notifications.
+func (s *ResponseBuilder) buildOnCallAlert(ctx *pipeline.Context) string {
+	severity, ok := ctx.Metadata["severity_result"].(*ai.SeverityResult)
+	if !ok || severity == nil || severity.Priority != "P0" {
+		return ""
+	}
+	onCall := ctx.Config.Severity.OnCall
+	if onCall == "" || severity.Confidence < ctx.Config.Severity.MinConfidence {
+		return ""
+	}
+	return fmt.Sprintf("%s\n> [!CAUTION]\n> %s: this issue is estimated **P0** (%s, confidence: %d%%). See the triage report for details.",
+		onCallMarker, onCall, severity.Category, int(severity.Confidence*100))
+}
+
 // buildTransferRow creates the transfer row for the classification table.
 func (s *ResponseBuilder) buildTransferRow(ctx *pipeline.Context) string {
 	routerResult, ok := ctx.Metadata["router_result"].(*ai.RouterResult)
@@ -197,6 +314,10 @@ func (s *ResponseBuilder) buildQualityImprovements(ctx *pipeline.Context) string
 	if !ok || qualityResult == nil {
 		return ""
 	}
+	// Already listed as questions for the author
+	if _, asked := ctx.Metadata["needs_info_questions"]; asked {
+		return ""
+	}
 
 	// Combine issues and suggestions
 	var items []string
@@ -252,8 +373,36 @@ func (s *ResponseBuilder) buildSimilarSection(ctx *pipeline.Context) string {
 			title = string(runes[:47]) + "..."
 		}
 
-		parts = append(parts, fmt.Sprintf("| %.0f%% | %s | [#%d %s](%s) | %s |",
-			similar.Similarity*100, similarThreadTypeIcon(similar.Type), similar.Number, title, similar.URL, status))
+		similarity := fmt.Sprintf("%.0f%%", similar.Similarity*100)
+		if similar.SameStackTrace {
+			similarity = "Same stack trace"
+		}
+
+		parts = append(parts, fmt.Sprintf("| %s | %s | [#%d %s](%s) | %s |",
+			similarity, similarThreadTypeIcon(similar.Type), similar.Number, title, similar.URL, status))
+	}
+
+	parts = append(parts, "</details>")
+	parts = append(parts, "")
+	return strings.Join(parts, "\n")
+}
+
+// buildOwnersSection lists the suggested owners and why they were picked.
+// Owners are shown in code spans so the report does not notify them.
+func (s *ResponseBuilder) buildOwnersSection(ctx *pipeline.Context) string {
+	if len(ctx.Result.SuggestedOwners) == 0 {
+		return ""
+	}
+
+	var parts []string
+	parts = append(parts, "<details>")
+	parts = append(parts, "<summary>Suggested Owners</summary>")
+	parts = append(parts, "")
+	parts = append(parts, "| Owner | Why |")
+	parts = append(parts, "| :--- | :--- |")
+
+	for _, owner := range ctx.Result.SuggestedOwners {
+		parts = append(parts, fmt.Sprintf("| `%s` | %s |", owner.Owner, strings.Join(owner.Reasons, "; ")))
 	}
 
 	parts = append(parts, "</details>")
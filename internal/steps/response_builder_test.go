@@ -119,6 +119,7 @@ func TestResponseBuilder_buildSimilarSection_TypeIcons(t *testing.T) {
 			{Title: "Issue Thread", Number: 10, Similarity: 0.95, State: "open", Type: "issue", URL: "https://example.com/issues/10"},
 			{Title: "PR Thread", Number: 11, Similarity: 0.82, State: "closed", Type: "pr", URL: "https://example.com/pull/11"},
 			{Title: "Missing Type", Number: 12, Similarity: 0.70, State: "open", URL: "https://example.com/issues/12"},
+			{Title: "Same Crash", Number: 13, Similarity: 1, State: "open", Type: "issue", URL: "https://example.com/issues/13", SameStackTrace: true},
 		},
 	}
 
@@ -130,6 +131,7 @@ func TestResponseBuilder_buildSimilarSection_TypeIcons(t *testing.T) {
 		"| 95% | 📝 | [#10 Issue Thread](https://example.com/issues/10) | Open |",
 		"| 82% | 🔀 | [#11 PR Thread](https://example.com/pull/11) | Closed |",
 		"| 70% | 📝 | [#12 Missing Type](https://example.com/issues/12) | Open |",
+		"| Same stack trace | 📝 | [#13 Same Crash](https://example.com/issues/13) | Open |",
 	}
 
 	for _, elem := range expectedElements {
@@ -138,3 +140,103 @@ func TestResponseBuilder_buildSimilarSection_TypeIcons(t *testing.T) {
 		}
 	}
 }
+
+func TestResponseBuilder_buildOwnersSection(t *testing.T) {
+	builder := NewResponseBuilder(&pipeline.Dependencies{})
+	ctx := &pipeline.Context{Result: &pipeline.Result{}}
+
+	if section := builder.buildOwnersSection(ctx); section != "" {
+		t.Errorf("Expected no section without suggestions, got %q", section)
+	}
+
+	ctx.Result.SuggestedOwners = []pipeline.OwnerSuggestion{
+		{Owner: "@alice", Score: 2, Reasons: []string{"owns `api/server.go`", "assigned to similar #12"}},
+		{Owner: "@acme/api", Score: 1, Reasons: []string{"owns the api area"}},
+	}
+	section := builder.buildOwnersSection(ctx)
+	for _, elem := range []string{
+		"<summary>Suggested Owners</summary>",
+		"| `@alice` | owns `api/server.go`; assigned to similar #12 |",
+		"| `@acme/api` | owns the api area |",
+	} {
+		if !strings.Contains(section, elem) {
+			t.Errorf("Expected owners section to contain %q, got:\n%s", elem, section)
+		}
+	}
+}
+
+func TestResponseBuilder_SeveritySections(t *testing.T) {
+	builder := NewResponseBuilder(&pipeline.Dependencies{})
+	cfg := &config.Config{Severity: config.SeverityConfig{OnCall: "@acme/on-call"}}
+	cfg.ApplyDefaults()
+	ctx := &pipeline.Context{
+		Config:   cfg,
+		Metadata: map[string]interface{}{},
+		Result:   &pipeline.Result{Priority: "P0", SeverityCategory: "data-loss", SeverityReasoning: "Saves overwrite | truncate files"},
+	}
+
+	if row := builder.buildPriorityRow(ctx); row != `| **Priority** | **P0** (data-loss): Saves overwrite \| truncate files |` {
+		t.Errorf("Unexpected priority row %q", row)
+	}
+
+	ctx.Metadata["severity_result"] = &ai.SeverityResult{Priority: "P0", Category: "data-loss", Confidence: 0.9, Signals: []string{"Files are truncated on save"}}
+	section := builder.buildUrgentSection(ctx)
+	for _, elem := range []string{"> [!CAUTION]", "**Estimated P0** (data-loss, confidence: 90%)", "> - Files are truncated on save"} {
+		if !strings.Contains(section, elem) {
+			t.Errorf("Expected urgent section to contain %q, got:\n%s", elem, section)
+		}
+	}
+	// The report is edited in place, so it never carries the page itself.
+	if strings.Contains(section, "@acme/on-call") {
+		t.Errorf("Expected no mention in the report, got:\n%s", section)
+	}
+	alert := builder.buildOnCallAlert(ctx)
+	if !strings.HasPrefix(alert, onCallMarker) || !strings.Contains(alert, "@acme/on-call: this issue is estimated **P0** (data-loss, confidence: 90%)") {
+		t.Errorf("Unexpected on-call alert:\n%s", alert)
+	}
+
+	// Low-confidence estimates do not page the on-call handle.
+	ctx.Metadata["severity_result"] = &ai.SeverityResult{Priority: "P0", Category: "data-loss", Confidence: 0.4}
+	if alert := builder.buildOnCallAlert(ctx); alert != "" {
+		t.Errorf("Expected no alert for a low-confidence estimate, got:\n%s", alert)
+	}
+}
+
+func TestResponseBuilder_NeedsInfoSection(t *testing.T) {
+	builder := NewResponseBuilder(&pipeline.Dependencies{})
+	cfg := &config.Config{}
+	cfg.ApplyDefaults()
+	ctx := &pipeline.Context{
+		Issue:  &pipeline.Issue{Author: "alice"},
+		Config: cfg,
+		Metadata: map[string]interface{}{
+			"quality_result":       &ai.QualityResult{Score: 0.3, Issues: []string{"Steps to reproduce"}},
+			"needs_info_questions": []string{"Steps to reproduce"},
+		},
+		Result: &pipeline.Result{},
+	}
+
+	section := builder.buildNeedsInfoSection(ctx)
+	for _, elem := range []string{"> [!IMPORTANT]", "> @alice, could you", "> - [ ] Steps to reproduce", "closed after 14 days"} {
+		if !strings.Contains(section, elem) {
+			t.Errorf("Expected needs-info section to contain %q, got:\n%s", elem, section)
+		}
+	}
+	if improvements := builder.buildQualityImprovements(ctx); improvements != "" {
+		t.Errorf("Expected no separate improvements list, got:\n%s", improvements)
+	}
+}
+
+func TestResponseBuilder_buildMissingGroupsRow(t *testing.T) {
+	builder := NewResponseBuilder(&pipeline.Dependencies{})
+	ctx := &pipeline.Context{Metadata: map[string]interface{}{}}
+
+	if row := builder.buildMissingGroupsRow(ctx); row != "" {
+		t.Errorf("Expected no row without missing groups, got %q", row)
+	}
+
+	ctx.Metadata["missing_label_groups"] = []string{"priority", "area"}
+	if row := builder.buildMissingGroupsRow(ctx); row != "| **Needs a label** | priority, area |" {
+		t.Errorf("Unexpected missing groups row %q", row)
+	}
+}
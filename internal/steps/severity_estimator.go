@@ -0,0 +1,128 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package steps
+
+import (
+	"log"
+	"strings"
+
+	"github.com/similigh/simili-bot/internal/core/pipeline"
+	"github.com/similigh/simili-bot/internal/integrations/ai"
+	"github.com/similigh/simili-bot/internal/utils/text"
+)
+
+// maxStackTraces bounds the traces sent to the LLM.
+const maxStackTraces = 3
+
+// SeverityEstimator estimates how urgent an issue is (crash, data loss,
+// security, regression, ...) and suggests the matching priority label.
+type SeverityEstimator struct {
+	llm *ai.LLMClient
+}
+
+// NewSeverityEstimator creates a new severity estimator step.
+func NewSeverityEstimator(deps *pipeline.Dependencies) *SeverityEstimator {
+	return &SeverityEstimator{
+		llm: deps.LLMClient,
+	}
+}
+
+// Name returns the step name.
+func (s *SeverityEstimator) Name() string {
+	return "severity_estimator"
+}
+
+// Run estimates the issue's priority. A priority label already on the issue
+// is kept, since it may have been set by a maintainer.
+func (s *SeverityEstimator) Run(ctx *pipeline.Context) error {
+	cfg := ctx.Config.Severity
+	if cfg.Enabled == nil || !*cfg.Enabled {
+		return nil
+	}
+	if ctx.Issue.EventType == "issue_comment" || ctx.Issue.EventType == "pr_comment" {
+		return nil
+	}
+	if s.llm == nil {
+		log.Printf("[severity_estimator] No LLM client, skipping severity estimation")
+		return nil
+	}
+
+	input := &ai.SeverityInput{
+		Issue: &ai.IssueInput{
+			Title:  ctx.Issue.Title,
+			Body:   ctx.Issue.Body,
+			Author: ctx.Issue.Author,
+			Labels: ctx.Issue.Labels,
+		},
+		AuthorAssociation: ctx.Issue.AuthorAssociation,
+		StackTraces:       issueStackTraces(ctx.Issue.Body),
+	}
+
+	result, err := s.llm.EstimateSeverity(ctx.Ctx, input)
+	if err != nil {
+		log.Printf("[severity_estimator] Failed to estimate severity: %v (non-blocking)", err)
+		return nil // Graceful degradation
+	}
+
+	s.apply(ctx, result)
+	return nil
+}
+
+// apply records the estimate and suggests its priority label.
+func (s *SeverityEstimator) apply(ctx *pipeline.Context, result *ai.SeverityResult) {
+	cfg := ctx.Config.Severity
+
+	ctx.Result.Priority = result.Priority
+	ctx.Result.SeverityCategory = result.Category
+	ctx.Result.SeverityReasoning = result.Reasoning
+	ctx.Metadata["severity_result"] = result
+
+	log.Printf("[severity_estimator] Priority: %s (%s, confidence %.2f)", result.Priority, result.Category, result.Confidence)
+
+	label := cfg.Labels[result.Priority]
+	if label == "" || result.Confidence < cfg.MinConfidence {
+		return
+	}
+	for _, l := range ctx.Issue.Labels {
+		if isPriorityLabel(l, cfg.Labels) {
+			log.Printf("[severity_estimator] Issue already has priority label %q, not suggesting %q", l, label)
+			return
+		}
+	}
+
+	// The estimate replaces any other priority label suggested by triage.
+	var labels []string
+	for _, l := range ctx.Result.SuggestedLabels {
+		if !isPriorityLabel(l, cfg.Labels) {
+			labels = append(labels, l)
+		}
+	}
+	ctx.Result.SuggestedLabels = append(labels, label)
+	setLabelSource(ctx, s.Name(), label)
+}
+
+// isPriorityLabel reports whether label is one of the configured priority labels.
+func isPriorityLabel(label string, priorities map[string]string) bool {
+	for _, l := range priorities {
+		if strings.EqualFold(l, label) {
+			return true
+		}
+	}
+	return false
+}
+
+// issueStackTraces renders up to maxStackTraces of the stack traces in
+// body, as found by the same parser fingerprint_match uses.
+func issueStackTraces(body string) []string {
+	var traces []string
+	for _, t := range text.ExtractStackTraces(body) {
+		if len(traces) == maxStackTraces {
+			break
+		}
+		traces = append(traces, strings.TrimSpace(t.Normalize(true)))
+	}
+	return traces
+}
@@ -0,0 +1,75 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package steps
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/similigh/simili-bot/internal/core/config"
+	"github.com/similigh/simili-bot/internal/core/pipeline"
+	"github.com/similigh/simili-bot/internal/integrations/ai"
+	"github.com/similigh/simili-bot/internal/utils/text"
+)
+
+func TestIssueStackTraces(t *testing.T) {
+	body := "The app crashes on start.\n\n```\npanic: runtime error: invalid memory address\n\ngoroutine 1 [running]:\nmain.main()\n\t/app/main.go:12 +0x1d\n```\n\n" +
+		"And in the worker:\nTraceback (most recent call last):\n  File \"worker.py\", line 3, in <module>\nKeyError: 'id'\n\nNot a trace: error handling is wrong.\nSegmentation fault"
+
+	traces := issueStackTraces(body)
+	if len(traces) != 2 {
+		t.Fatalf("Expected 2 traces, got %d: %q", len(traces), traces)
+	}
+	if !strings.HasPrefix(traces[0], "go panic: runtime error") || !strings.HasSuffix(traces[0], "main.main main.go:12") {
+		t.Errorf("traces[0] = %q", traces[0])
+	}
+	if !strings.HasPrefix(traces[1], "python KeyError") || !strings.Contains(traces[1], "worker.py:3") {
+		t.Errorf("traces[1] = %q", traces[1])
+	}
+
+	// Traces are the ones fingerprint_match sees.
+	if fps := text.Fingerprints(body, false); len(fps) != len(traces) {
+		t.Errorf("Expected one trace per fingerprint, got %d fingerprints", len(fps))
+	}
+	if traces := issueStackTraces("Clicking save does nothing."); traces != nil {
+		t.Errorf("Expected no traces, got %q", traces)
+	}
+}
+
+func TestSeverityEstimatorApply(t *testing.T) {
+	tests := []struct {
+		name       string
+		issue      []string
+		suggested  []string
+		result     ai.SeverityResult
+		wantLabels []string
+	}{
+		{"applies label", nil, []string{"bug"}, ai.SeverityResult{Priority: "P1", Category: "regression", Confidence: 0.8}, []string{"bug", "priority/P1"}},
+		{"replaces triage priority", nil, []string{"priority/p3", "bug"}, ai.SeverityResult{Priority: "P0", Category: "security", Confidence: 0.9}, []string{"bug", "priority/P0"}},
+		{"low confidence", nil, []string{"bug"}, ai.SeverityResult{Priority: "P1", Confidence: 0.3}, []string{"bug"}},
+		{"keeps existing priority", []string{"priority/P2"}, []string{"bug"}, ai.SeverityResult{Priority: "P1", Confidence: 0.9}, []string{"bug"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{}
+			cfg.ApplyDefaults()
+			ctx := pipeline.NewContext(context.Background(), &pipeline.Issue{Labels: tt.issue}, cfg)
+			ctx.Result.SuggestedLabels = tt.suggested
+
+			result := tt.result
+			NewSeverityEstimator(&pipeline.Dependencies{}).apply(ctx, &result)
+
+			if !reflect.DeepEqual(ctx.Result.SuggestedLabels, tt.wantLabels) {
+				t.Errorf("SuggestedLabels = %v, want %v", ctx.Result.SuggestedLabels, tt.wantLabels)
+			}
+			if ctx.Result.Priority != tt.result.Priority {
+				t.Errorf("Priority = %q, want %q", ctx.Result.Priority, tt.result.Priority)
+			}
+		})
+	}
+}
@@ -1,7 +1,7 @@
 // Author: Kaviru Hapuarachchi
 // GitHub: https://github.com/Kavirubc
 // Created: 2026-02-02
-// Last Modified: 2026-02-02
+// Last Modified: 2026-10-18
 
 // Package steps provides the similarity search step.
 package steps
@@ -14,6 +14,7 @@ import (
 	"github.com/similigh/simili-bot/internal/core/pipeline"
 	"github.com/similigh/simili-bot/internal/integrations/ai"
 	"github.com/similigh/simili-bot/internal/integrations/qdrant"
+	"github.com/similigh/simili-bot/internal/utils/text"
 )
 
 // SimilaritySearch finds similar issues using the vector database.
@@ -92,78 +93,143 @@ func (s *SimilaritySearch) Run(ctx *pipeline.Context) error {
 
 	foundIssues := make([]pipeline.SimilarIssue, 0, len(results))
 	for _, res := range results {
-		// Safely extract payload fields with type checking
-		// Match the indexer which uses "issue_number", and handle multiple numeric types
-		var number int
-		numFound := false
-
-		for _, key := range []string{"number", "issue_number"} {
-			if val, ok := res.Payload[key]; ok {
-				switch v := val.(type) {
-				case float64:
-					number = int(v)
-					numFound = true
-				case int64:
-					number = int(v)
-					numFound = true
-				case int:
-					number = v
-					numFound = true
-				}
-			}
-			if numFound {
-				break
-			}
-		}
-
-		if !numFound {
-			log.Printf("[similarity_search] WARNING: No valid issue number in payload, skipping result")
+		issue, ok := similarFromResult(ctx, res)
+		if !ok {
 			continue
 		}
+		foundIssues = append(foundIssues, issue)
+	}
 
-		// Filter out the current issue itself
-		resRepo, _ := res.Payload["repo"].(string)
-		if number == ctx.Issue.Number && resRepo == ctx.Issue.Repo {
-			continue
-		}
+	// Exact stack trace matches found by fingerprint_match come first.
+	foundIssues = mergeSimilar(ctx.SimilarIssues, foundIssues, limit)
+
+	ctx.SimilarIssues = foundIssues
+	ctx.Result.SimilarFound = foundIssues
 
-		// Safely extract other fields, with fallbacks
-		title, _ := res.Payload["title"].(string)
-		fullText, _ := res.Payload["text"].(string)
-
-		if title == "" {
-			// Try to extract from text if title is missing (indexers often put it there)
-			if strings.HasPrefix(fullText, "Title: ") {
-				lines := strings.SplitN(fullText, "\n", 2)
-				title = strings.TrimPrefix(lines[0], "Title: ")
-			} else {
-				title = "Similar Issue"
+	log.Printf("[similarity_search] Found %d similar issues for #%d", len(foundIssues), ctx.Issue.Number)
+
+	return nil
+}
+
+// similarFromResult converts a search result's payload into a similar
+// issue. It returns false for results without an issue number and for the
+// issue being processed.
+func similarFromResult(ctx *pipeline.Context, res *qdrant.SearchResult) (pipeline.SimilarIssue, bool) {
+	// Safely extract payload fields with type checking
+	// Match the indexer which uses "issue_number", and handle multiple numeric types
+	var number int
+	numFound := false
+
+	for _, key := range []string{"number", "issue_number"} {
+		if val, ok := res.Payload[key]; ok {
+			switch v := val.(type) {
+			case float64:
+				number = int(v)
+				numFound = true
+			case int64:
+				number = int(v)
+				numFound = true
+			case int:
+				number = v
+				numFound = true
 			}
 		}
-
-		url, _ := res.Payload["url"].(string)
-		state, _ := res.Payload["state"].(string)
-		if state == "" {
-			state = "unknown"
+		if numFound {
+			break
 		}
-		threadType, _ := res.Payload["type"].(string)
-
-		issue := pipeline.SimilarIssue{
-			Number:     number,
-			Title:      title,
-			Body:       fullText,
-			URL:        url,
-			State:      state,
-			Type:       normalizeSimilarThreadType(threadType),
-			Similarity: float64(res.Score),
+	}
+
+	if !numFound {
+		log.Printf("[similarity_search] WARNING: No valid issue number in payload, skipping result")
+		return pipeline.SimilarIssue{}, false
+	}
+
+	// Filter out the current issue itself
+	resRepo, _ := res.Payload["repo"].(string)
+	if number == ctx.Issue.Number && resRepo == ctx.Issue.Repo {
+		return pipeline.SimilarIssue{}, false
+	}
+
+	// Safely extract other fields, with fallbacks
+	title, _ := res.Payload["title"].(string)
+	fullText, _ := res.Payload["text"].(string)
+
+	if title == "" {
+		// Try to extract from text if title is missing (indexers often put it there)
+		if strings.HasPrefix(fullText, "Title: ") {
+			lines := strings.SplitN(fullText, "\n", 2)
+			title = strings.TrimPrefix(lines[0], "Title: ")
+		} else {
+			title = "Similar Issue"
 		}
-		foundIssues = append(foundIssues, issue)
 	}
 
-	ctx.SimilarIssues = foundIssues
-	ctx.Result.SimilarFound = foundIssues
+	url, _ := res.Payload["url"].(string)
+	state, _ := res.Payload["state"].(string)
+	if state == "" {
+		state = "unknown"
+	}
+	threadType, _ := res.Payload["type"].(string)
+	assignees, _ := res.Payload["assignees"].(string)
+	closedBy, _ := res.Payload["closed_by"].(string)
+	simhash, _ := res.Payload["simhash"].(string)
+
+	return pipeline.SimilarIssue{
+		Number:      number,
+		Title:       title,
+		Body:        fullText,
+		URL:         url,
+		State:       state,
+		Type:        normalizeSimilarThreadType(threadType),
+		Similarity:  float64(res.Score),
+		Assignees:   splitLogins(assignees),
+		ClosedBy:    closedBy,
+		BodySimHash: text.ParseSimHash(simhash),
+	}, true
+}
 
-	log.Printf("[similarity_search] Found %d similar issues for #%d", len(foundIssues), ctx.Issue.Number)
+// mergeSimilar appends the issues in more that are not already in first,
+// keeping at most limit issues in total. Issues are the same if they share
+// a URL (or a number, when the URL is unknown). Issues in first without a
+// similarity score, such as stack trace matches, take the score of the same
+// issue in more.
+func mergeSimilar(first, more []pipeline.SimilarIssue, limit int) []pipeline.SimilarIssue {
+	if len(first) == 0 {
+		return more
+	}
+	key := func(s pipeline.SimilarIssue) string {
+		if s.URL != "" {
+			return s.URL
+		}
+		return fmt.Sprintf("#%d", s.Number)
+	}
 
-	return nil
+	seen := make(map[string]int, len(first))
+	merged := append([]pipeline.SimilarIssue{}, first...)
+	for i, s := range merged {
+		seen[key(s)] = i
+	}
+	for _, s := range more {
+		i, ok := seen[key(s)]
+		if !ok {
+			merged = append(merged, s)
+		} else if merged[i].Similarity == 0 {
+			merged[i].Similarity = s.Similarity
+		}
+	}
+	if limit > 0 && len(merged) > limit {
+		merged = merged[:limit]
+	}
+	return merged
+}
+
+// splitLogins parses the comma-separated logins stored in a payload.
+func splitLogins(s string) []string {
+	var logins []string
+	for _, login := range strings.Split(s, ",") {
+		if login = strings.TrimSpace(login); login != "" {
+			logins = append(logins, login)
+		}
+	}
+	return logins
 }
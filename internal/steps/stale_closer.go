@@ -0,0 +1,247 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package steps
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	githubapi "github.com/google/go-github/v60/github"
+
+	"github.com/similigh/simili-bot/internal/core/config"
+	"github.com/similigh/simili-bot/internal/core/state"
+	"github.com/similigh/simili-bot/internal/integrations/github"
+)
+
+// staleCloseMarker identifies the comment posted when closing a stale issue.
+const staleCloseMarker = "<!-- simili-bot-stale-close -->"
+
+// StaleCloseResult holds the summary of a close-stale run.
+type StaleCloseResult struct {
+	Processed      int                `json:"processed"`
+	Closed         int                `json:"closed"`
+	SkippedWaiting int                `json:"skipped_waiting"`
+	SkippedReplied int                `json:"skipped_replied"`
+	Errors         []string           `json:"errors,omitempty"`
+	Details        []StaleCloseDetail `json:"details,omitempty"`
+}
+
+// StaleCloseDetail records the outcome for a single issue.
+type StaleCloseDetail struct {
+	Number int    `json:"number"`
+	Action string `json:"action"` // "closed", "skipped_waiting", "skipped_replied", "error"
+	Reason string `json:"reason,omitempty"`
+}
+
+// StaleCloser scans issues with the needs-info label and closes those whose
+// author has not replied within the configured number of days.
+type StaleCloser struct {
+	github   *github.Client
+	cfg      *config.Config
+	feedback state.FeedbackStore
+	dryRun   bool
+	verbose  bool
+}
+
+// NewStaleCloser creates a new StaleCloser.
+func NewStaleCloser(gh *github.Client, cfg *config.Config, dryRun, verbose bool) *StaleCloser {
+	return &StaleCloser{
+		github:  gh,
+		cfg:     cfg,
+		dryRun:  dryRun,
+		verbose: verbose,
+	}
+}
+
+// WithFeedback records each closure in store so reopens can be tracked.
+func (sc *StaleCloser) WithFeedback(store state.FeedbackStore) *StaleCloser {
+	sc.feedback = store
+	return sc
+}
+
+// Run processes all open issues with the needs-info label.
+func (sc *StaleCloser) Run(ctx context.Context, org, repo string) (*StaleCloseResult, error) {
+	if sc.github == nil {
+		return nil, fmt.Errorf("GitHub client is required for close-stale")
+	}
+
+	cfg := sc.cfg.NeedsInfo
+	label := cfg.Label
+	if label == "" {
+		label = "needs-info"
+	}
+	staleDays := cfg.StaleDays
+	if staleDays <= 0 {
+		staleDays = 14
+	}
+	staleAfter := time.Duration(staleDays) * 24 * time.Hour
+
+	issues, err := sc.fetchLabeled(ctx, org, repo, label)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %q issues: %w", label, err)
+	}
+	if sc.verbose {
+		log.Printf("[stale-closer] Found %d open issues with %q label (stale after %d days)", len(issues), label, staleDays)
+	}
+
+	result := &StaleCloseResult{}
+	for _, issue := range issues {
+		number := issue.GetNumber()
+		result.Processed++
+		detail := StaleCloseDetail{Number: number}
+
+		fail := func(reason string) {
+			detail.Action = "error"
+			detail.Reason = reason
+			result.Errors = append(result.Errors, fmt.Sprintf("#%d: %s", number, reason))
+			result.Details = append(result.Details, detail)
+		}
+
+		// 1. Check how long the issue has been waiting
+		labeledAt, err := findLabeledTime(ctx, sc.github, org, repo, number, label)
+		if err != nil {
+			fail(fmt.Sprintf("failed to check label time: %v", err))
+			continue
+		}
+		if labeledAt.IsZero() {
+			fail(fmt.Sprintf("could not find when %q label was applied", label))
+			continue
+		}
+		if waited := time.Since(labeledAt); waited < staleAfter {
+			detail.Action = "skipped_waiting"
+			detail.Reason = fmt.Sprintf("%s left to reply", (staleAfter - waited).Round(time.Hour))
+			result.SkippedWaiting++
+			result.Details = append(result.Details, detail)
+			if sc.verbose {
+				log.Printf("[stale-closer] #%d: %s", number, detail.Reason)
+			}
+			continue
+		}
+
+		// 2. Each reply or edit by the author is re-assessed, and the label
+		// stays only if the issue still lacks detail. The author then has
+		// another full period to answer, counted from their last activity.
+		lastActivity, err := sc.authorActivitySince(ctx, org, repo, issue, labeledAt)
+		if err != nil {
+			fail(err.Error())
+			continue
+		}
+		if waited := time.Since(lastActivity); !lastActivity.IsZero() && waited < staleAfter {
+			detail.Action = "skipped_replied"
+			detail.Reason = fmt.Sprintf("author replied after the label was applied, %s left to reply again", (staleAfter - waited).Round(time.Hour))
+			result.SkippedReplied++
+			result.Details = append(result.Details, detail)
+			if sc.verbose {
+				log.Printf("[stale-closer] #%d: %s", number, detail.Reason)
+			}
+			continue
+		}
+
+		// 3. Close the issue
+		if sc.dryRun {
+			detail.Action = "closed"
+			detail.Reason = "DRY RUN: would comment and close"
+			result.Closed++
+			result.Details = append(result.Details, detail)
+			log.Printf("[stale-closer] DRY RUN: would close #%d", number)
+			continue
+		}
+
+		// Closed as "not planned": nothing was fixed, the report was abandoned.
+		if err := sc.github.CloseIssueWithReason(ctx, org, repo, number, "not_planned"); err != nil {
+			fail(fmt.Sprintf("failed to close: %v", err))
+			continue
+		}
+		comment := staleCloseMarker + "\n" + cfg.CloseMessage + "\n\n---\n" +
+			"<sub>Generated by [Simili Bot](https://github.com/similigh/simili-bot)</sub>"
+		if err := sc.github.CreateComment(ctx, org, repo, number, comment); err != nil {
+			// Issue is already closed — log the failure but don't surface it as an error.
+			log.Printf("[stale-closer] Warning: failed to post closing comment on #%d: %v", number, err)
+		}
+
+		detail.Action = "closed"
+		detail.Reason = fmt.Sprintf("no reply within %d days", staleDays)
+		result.Closed++
+		result.Details = append(result.Details, detail)
+		log.Printf("[stale-closer] Closed #%d: %s", number, detail.Reason)
+
+		closeDecision := state.Decision{Step: "stale_closer", Action: "close", Labels: []string{label}, DecidedAt: time.Now()}
+		if err := state.RecordDecisions(ctx, sc.feedback, org, repo, number, []state.Decision{closeDecision}); err != nil {
+			log.Printf("[stale-closer] Warning: failed to record close decision for #%d: %v", number, err)
+		}
+	}
+
+	return result, nil
+}
+
+// fetchLabeled retrieves all open issues (not pull requests) with label.
+func (sc *StaleCloser) fetchLabeled(ctx context.Context, org, repo, label string) ([]*githubapi.Issue, error) {
+	var all []*githubapi.Issue
+	opts := &githubapi.IssueListByRepoOptions{
+		State:       "open",
+		Labels:      []string{label},
+		ListOptions: githubapi.ListOptions{PerPage: 100},
+	}
+	for {
+		issues, resp, err := sc.github.ListIssues(ctx, org, repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, issue := range issues {
+			if issue.PullRequestLinks == nil {
+				all = append(all, issue)
+			}
+		}
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return all, nil
+}
+
+// authorActivitySince returns when the issue author last commented on or
+// edited the issue after since, or the zero time if they did neither.
+func (sc *StaleCloser) authorActivitySince(ctx context.Context, org, repo string, issue *githubapi.Issue, since time.Time) (time.Time, error) {
+	comments, err := fetchComments(ctx, sc.github, org, repo, issue.GetNumber(), &since)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to list comments: %w", err)
+	}
+
+	// Edits leave no trace in the REST API beyond updated_at, which every
+	// label and comment also bumps, so only look them up when it has moved.
+	var edits []github.IssueEdit
+	if issue.GetUpdatedAt().Time.After(since) {
+		if edits, err = sc.github.ListIssueEdits(ctx, org, repo, issue.GetNumber()); err != nil {
+			return time.Time{}, fmt.Errorf("failed to list edits: %w", err)
+		}
+	}
+	return latestAuthorActivity(comments, edits, issue.GetUser().GetLogin(), since), nil
+}
+
+// latestAuthorActivity returns the time of the author's last comment or
+// edit after since, or the zero time if there is none.
+func latestAuthorActivity(comments []*githubapi.IssueComment, edits []github.IssueEdit, author string, since time.Time) time.Time {
+	var latest time.Time
+	if author == "" {
+		return latest
+	}
+	seen := func(user string, at time.Time) {
+		if strings.EqualFold(user, author) && at.After(since) && at.After(latest) {
+			latest = at
+		}
+	}
+	for _, c := range comments {
+		seen(c.GetUser().GetLogin(), c.GetCreatedAt().Time)
+	}
+	for _, e := range edits {
+		seen(e.Editor, e.EditedAt)
+	}
+	return latest
+}
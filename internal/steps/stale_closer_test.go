@@ -0,0 +1,167 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package steps
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	githubapi "github.com/google/go-github/v60/github"
+	"golang.org/x/oauth2"
+
+	"github.com/similigh/simili-bot/internal/core/config"
+	"github.com/similigh/simili-bot/internal/core/state"
+	"github.com/similigh/simili-bot/internal/integrations/github"
+)
+
+func TestLatestAuthorActivity(t *testing.T) {
+	labeledAt := time.Now().Add(-48 * time.Hour)
+	comment := func(user string, at time.Time) *githubapi.IssueComment {
+		return &githubapi.IssueComment{
+			User:      &githubapi.User{Login: githubapi.String(user)},
+			CreatedAt: &githubapi.Timestamp{Time: at},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		comments []*githubapi.IssueComment
+		edits    []github.IssueEdit
+		want     time.Time
+	}{
+		{"no activity", nil, nil, time.Time{}},
+		{"author replied", []*githubapi.IssueComment{comment("gh-simili-bot", labeledAt.Add(time.Minute)), comment("Alice", labeledAt.Add(time.Hour))}, nil, labeledAt.Add(time.Hour)},
+		{"only others replied", []*githubapi.IssueComment{comment("bob", labeledAt.Add(time.Hour))}, []github.IssueEdit{{Editor: "bob", EditedAt: labeledAt.Add(time.Hour)}}, time.Time{}},
+		{"author comment predates label", []*githubapi.IssueComment{comment("alice", labeledAt.Add(-time.Hour))}, nil, time.Time{}},
+		{"author edited after replying", []*githubapi.IssueComment{comment("alice", labeledAt.Add(time.Hour))}, []github.IssueEdit{{Editor: "alice", EditedAt: labeledAt.Add(-time.Hour)}, {Editor: "alice", EditedAt: labeledAt.Add(2 * time.Hour)}}, labeledAt.Add(2 * time.Hour)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := latestAuthorActivity(tt.comments, tt.edits, "alice", labeledAt); !got.Equal(tt.want) {
+				t.Errorf("latestAuthorActivity() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// redirectTransport sends every request to a test server.
+type redirectTransport struct{ target *url.URL }
+
+func (rt redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.URL.Scheme = rt.target.Scheme
+	req.URL.Host = rt.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// newFakeGitHub returns a client whose requests are served by handler.
+func newFakeGitHub(t *testing.T, handler http.Handler) *github.Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	target, _ := url.Parse(server.URL)
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{Transport: redirectTransport{target: target}})
+	return github.NewClient(ctx, "test-token")
+}
+
+func TestStaleCloserRun(t *testing.T) {
+	now := time.Now()
+	stale, fresh := now.Add(-20*24*time.Hour), now.Add(-2*24*time.Hour)
+	labeledAt := map[string]time.Time{"1": stale, "2": fresh, "3": stale, "5": stale, "6": stale}
+	// #3 got a reply recently and #6 an edit; #5 got a reply that still
+	// left the issue short of detail, long enough ago to be stale again.
+	replies := map[string]time.Time{"3": now.Add(-time.Hour), "5": now.Add(-16 * 24 * time.Hour)}
+	edited := now.Add(-time.Hour)
+
+	var mu sync.Mutex
+	var closes []map[string]interface{}
+	var posted []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/acme/app/issues", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[
+			{"number": 1, "user": {"login": "alice"}},
+			{"number": 2, "user": {"login": "alice"}},
+			{"number": 3, "user": {"login": "alice"}},
+			{"number": 4, "user": {"login": "alice"}, "pull_request": {"url": "x"}},
+			{"number": 5, "user": {"login": "alice"}},
+			{"number": 6, "user": {"login": "alice"}, "updated_at": "`+edited.Format(time.RFC3339)+`"}
+		]`)
+	})
+	var editLookups int
+	mux.HandleFunc("/graphql", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		editLookups++
+		mu.Unlock()
+		fmt.Fprintf(w, `{"data": {"repository": {"issue": {"userContentEdits": {"nodes": [
+			{"editedAt": %q, "editor": {"login": "alice"}}
+		]}}}}}`, edited.Format(time.RFC3339))
+	})
+	mux.HandleFunc("/repos/acme/app/issues/", func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/repos/acme/app/issues/"), "/")
+		number, rest := parts[0], strings.Join(parts[1:], "/")
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case rest == "events":
+			_ = json.NewEncoder(w).Encode([]map[string]interface{}{
+				{"event": "labeled", "label": map[string]string{"name": "Needs-Info"}, "created_at": labeledAt[number].Format(time.RFC3339)},
+			})
+		case rest == "comments" && r.Method == http.MethodPost:
+			var body map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			posted = append(posted, number+": "+body["body"])
+			fmt.Fprint(w, `{"id": 1}`)
+		case rest == "comments":
+			var comments []map[string]interface{}
+			if at, ok := replies[number]; ok {
+				comments = append(comments, map[string]interface{}{"user": map[string]string{"login": "alice"}, "created_at": at.Format(time.RFC3339)})
+			}
+			_ = json.NewEncoder(w).Encode(comments)
+		case rest == "" && r.Method == http.MethodPatch:
+			var req map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			req["number"] = number
+			closes = append(closes, req)
+			fmt.Fprint(w, `{}`)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	enabled := true
+	cfg := &config.Config{NeedsInfo: config.NeedsInfoConfig{Enabled: &enabled}}
+	cfg.ApplyDefaults()
+	store := state.NewLocalFeedbackStore(t.TempDir())
+
+	result, err := NewStaleCloser(newFakeGitHub(t, mux), cfg, false, false).WithFeedback(store).Run(context.Background(), "acme", "app")
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if result.Processed != 5 || result.Closed != 2 || result.SkippedWaiting != 1 || result.SkippedReplied != 2 || len(result.Errors) != 0 {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+	if editLookups != 1 {
+		t.Errorf("Expected edits to be looked up for #6 only, got %d lookups", editLookups)
+	}
+
+	if len(closes) != 2 || closes[0]["number"] != "1" || closes[1]["number"] != "5" || closes[0]["state"] != "closed" || closes[0]["state_reason"] != "not_planned" {
+		t.Errorf("Expected #1 and #5 to be closed as not planned, got %v", closes)
+	}
+	if len(posted) != 2 || !strings.HasPrefix(posted[0], "1: "+staleCloseMarker) {
+		t.Errorf("Expected closing comments on #1 and #5, got %v", posted)
+	}
+	record, err := store.GetFeedback(context.Background(), "acme", "app", 1)
+	if err != nil || record == nil || len(record.Decisions) != 1 || record.Decisions[0].Action != "close" {
+		t.Errorf("Expected the close decision to be recorded, got %+v, %v", record, err)
+	}
+}
@@ -0,0 +1,108 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package steps
+
+import (
+	"log"
+
+	"github.com/similigh/simili-bot/internal/core/pipeline"
+	"github.com/similigh/simili-bot/internal/integrations/ai"
+	"github.com/similigh/simili-bot/internal/integrations/github"
+	"github.com/similigh/simili-bot/internal/utils/issueforms"
+)
+
+// TemplateChecker checks issues created from one of the repository's issue
+// forms: required fields must be present, filled in and not left at the
+// form's placeholder text. Its findings are added to the quality issues
+// found by quality_checker.
+type TemplateChecker struct {
+	github *github.Client
+}
+
+// NewTemplateChecker creates a new template checker step.
+func NewTemplateChecker(deps *pipeline.Dependencies) *TemplateChecker {
+	return &TemplateChecker{
+		github: deps.GitHub,
+	}
+}
+
+// Name returns the step name.
+func (s *TemplateChecker) Name() string {
+	return "template_checker"
+}
+
+// Run detects the form the issue was created from and checks its fields.
+func (s *TemplateChecker) Run(ctx *pipeline.Context) error {
+	cfg := ctx.Config.Templates
+	if cfg.Enabled == nil || !*cfg.Enabled {
+		return nil
+	}
+	if ctx.Issue.EventType != "issues" {
+		return nil
+	}
+	if s.github == nil {
+		log.Printf("[template_checker] No GitHub client, skipping template check")
+		return nil
+	}
+
+	forms := s.loadForms(ctx)
+	if len(forms) == 0 {
+		return nil
+	}
+	s.apply(ctx, forms)
+	return nil
+}
+
+// apply checks the issue against the form it was created from, if any.
+func (s *TemplateChecker) apply(ctx *pipeline.Context, forms []*issueforms.Form) {
+	sections := issueforms.Sections(ctx.Issue.Body)
+	form := issueforms.Detect(forms, sections, ctx.Issue.Labels)
+	if form == nil {
+		log.Printf("[template_checker] Issue #%d does not match any issue form", ctx.Issue.Number)
+		return
+	}
+	ctx.Metadata["issue_template"] = form.Name
+
+	findings := form.Check(sections)
+	log.Printf("[template_checker] Issue #%d uses the %q form, %d finding(s)", ctx.Issue.Number, form.Name, len(findings))
+	if len(findings) == 0 {
+		return
+	}
+
+	ctx.Result.QualityIssues = append(ctx.Result.QualityIssues, findings...)
+	if quality, ok := ctx.Metadata["quality_result"].(*ai.QualityResult); ok && quality != nil {
+		quality.Issues = append(quality.Issues, findings...)
+	}
+}
+
+// loadForms fetches and parses the repository's issue forms. Forms that
+// fail to parse are skipped.
+func (s *TemplateChecker) loadForms(ctx *pipeline.Context) []*issueforms.Form {
+	paths, err := s.github.ListDirectory(ctx.Ctx, ctx.Issue.Org, ctx.Issue.Repo, issueforms.Directory, "")
+	if err != nil {
+		log.Printf("[template_checker] No issue forms found: %v (non-blocking)", err)
+		return nil
+	}
+
+	var forms []*issueforms.Form
+	for _, path := range paths {
+		if !issueforms.IsForm(path) {
+			continue
+		}
+		data, err := s.github.GetFileContent(ctx.Ctx, ctx.Issue.Org, ctx.Issue.Repo, path, "")
+		if err != nil {
+			log.Printf("[template_checker] Failed to fetch %s: %v (non-blocking)", path, err)
+			continue
+		}
+		form, err := issueforms.Parse(path, data)
+		if err != nil {
+			log.Printf("[template_checker] %v (non-blocking)", err)
+			continue
+		}
+		forms = append(forms, form)
+	}
+	return forms
+}
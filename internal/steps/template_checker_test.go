@@ -0,0 +1,66 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package steps
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/similigh/simili-bot/internal/core/config"
+	"github.com/similigh/simili-bot/internal/core/pipeline"
+	"github.com/similigh/simili-bot/internal/integrations/ai"
+	"github.com/similigh/simili-bot/internal/utils/issueforms"
+)
+
+func TestTemplateCheckerApply(t *testing.T) {
+	form, err := issueforms.Parse("bug.yml", []byte(`
+name: Bug report
+labels: [bug]
+body:
+  - type: textarea
+    attributes:
+      label: What happened?
+    validations:
+      required: true
+  - type: input
+    attributes:
+      label: Version
+    validations:
+      required: true
+`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	cfg := &config.Config{}
+	cfg.ApplyDefaults()
+	issue := &pipeline.Issue{Body: "### What happened?\n\nIt crashes.\n\n### Version\n\n_No response_", Labels: []string{"bug"}}
+	ctx := pipeline.NewContext(context.Background(), issue, cfg)
+	quality := &ai.QualityResult{Score: 0.7, Issues: []string{"No logs"}}
+	ctx.Metadata["quality_result"] = quality
+	ctx.Result.QualityIssues = []string{"No logs"}
+
+	NewTemplateChecker(&pipeline.Dependencies{}).apply(ctx, []*issueforms.Form{form})
+
+	want := []string{"No logs", `Required field "Version" is empty`}
+	if !reflect.DeepEqual(ctx.Result.QualityIssues, want) {
+		t.Errorf("QualityIssues = %q, want %q", ctx.Result.QualityIssues, want)
+	}
+	if !reflect.DeepEqual(quality.Issues, want) {
+		t.Errorf("quality_result issues = %q, want %q", quality.Issues, want)
+	}
+	if ctx.Metadata["issue_template"] != "Bug report" {
+		t.Errorf("issue_template = %v", ctx.Metadata["issue_template"])
+	}
+
+	// Issues not written from a form are left alone.
+	other := pipeline.NewContext(context.Background(), &pipeline.Issue{Body: "It crashes.", Labels: []string{"bug"}}, cfg)
+	NewTemplateChecker(&pipeline.Dependencies{}).apply(other, []*issueforms.Form{form})
+	if len(other.Result.QualityIssues) != 0 || other.Metadata["issue_template"] != nil {
+		t.Errorf("Expected no findings, got %q", other.Result.QualityIssues)
+	}
+}
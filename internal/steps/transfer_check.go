@@ -1,7 +1,7 @@
 // Author: Kaviru Hapuarachchi
 // GitHub: https://github.com/Kavirubc
 // Created: 2026-02-02
-// Last Modified: 2026-02-27
+// Last Modified: 2026-10-18
 
 // Package steps provides the transfer check step.
 package steps
@@ -167,6 +167,7 @@ func (s *TransferCheck) Run(ctx *pipeline.Context) error {
 		vdbResult.Target, vdbResult.Confidence)
 
 	setTransferTarget(ctx, vdbResult.Target, "vdb", vdbResult.Confidence, "", reasoning)
+	ctx.Metadata["transfer_threshold"] = vdbCfg.ConfidenceThreshold
 	return nil
 }
 
@@ -17,16 +17,31 @@ import (
 
 type tcMockStore struct {
 	results []*qdrant.SearchResult
+	points  map[string]*qdrant.Point
 }
 
 func (m *tcMockStore) CreateCollection(_ context.Context, _ string, _ int) error { return nil }
 func (m *tcMockStore) CollectionExists(_ context.Context, _ string) (bool, error) {
 	return true, nil
 }
-func (m *tcMockStore) Upsert(_ context.Context, _ string, _ []*qdrant.Point) error { return nil }
+func (m *tcMockStore) Upsert(_ context.Context, _ string, points []*qdrant.Point) error {
+	if m.points == nil {
+		m.points = make(map[string]*qdrant.Point)
+	}
+	for _, p := range points {
+		m.points[p.ID] = p
+	}
+	return nil
+}
 func (m *tcMockStore) Search(_ context.Context, _ string, _ []float32, _ int, _ float64) ([]*qdrant.SearchResult, error) {
 	return m.results, nil
 }
+func (m *tcMockStore) FindByPayload(_ context.Context, _ string, _ string, _ []string, _ int) ([]*qdrant.SearchResult, error) {
+	return nil, nil
+}
+func (m *tcMockStore) Get(_ context.Context, _ string, id string) (*qdrant.Point, error) {
+	return m.points[id], nil
+}
 func (m *tcMockStore) Delete(_ context.Context, _ string, _ string) error { return nil }
 func (m *tcMockStore) SetPayload(_ context.Context, _ string, _ string, _ map[string]interface{}) error {
 	return nil
@@ -1,7 +1,7 @@
 // Author: Kaviru Hapuarachchi
 // GitHub: https://github.com/kavirubc
 // Created: 2026-02-02
-// Last Modified: 2026-02-04
+// Last Modified: 2026-10-18
 
 // Package steps provides the triage step.
 package steps
@@ -11,17 +11,22 @@ import (
 
 	"github.com/similigh/simili-bot/internal/core/pipeline"
 	"github.com/similigh/simili-bot/internal/integrations/ai"
+	"github.com/similigh/simili-bot/internal/integrations/github"
 )
 
 // Triage uses LLM to suggest labels for the issue.
+// Suggestions are limited to labels that exist in the repository, so the
+// executor never creates new labels.
 type Triage struct {
-	llm *ai.LLMClient
+	llm    *ai.LLMClient
+	github *github.Client
 }
 
 // NewTriage creates a new triage step.
 func NewTriage(deps *pipeline.Dependencies) *Triage {
 	return &Triage{
-		llm: deps.LLMClient,
+		llm:    deps.LLMClient,
+		github: deps.GitHub,
 	}
 }
 
@@ -42,11 +47,14 @@ func (s *Triage) Run(ctx *pipeline.Context) error {
 
 	// Convert pipeline.Issue to ai.IssueInput
 	input := &ai.IssueInput{
-		Title:  ctx.Issue.Title,
-		Body:   ctx.Issue.Body,
-		Author: ctx.Issue.Author,
-		Labels: ctx.Issue.Labels,
+		Title:         ctx.Issue.Title,
+		Body:          ctx.Issue.Body,
+		Author:        ctx.Issue.Author,
+		Labels:        ctx.Issue.Labels,
+		AllowedLabels: repoLabelOptions(ctx, s.github),
 	}
+	groups := ctx.Config.Labels.Groups
+	input.LabelGroups = labelGroupOptions(groups, input.AllowedLabels)
 
 	result, err := s.llm.AnalyzeIssue(ctx.Ctx, input)
 	if err != nil {
@@ -54,8 +62,25 @@ func (s *Triage) Run(ctx *pipeline.Context) error {
 		return nil // Graceful degradation
 	}
 
-	ctx.Result.SuggestedLabels = result.SuggestedLabels
-	log.Printf("[triage] Suggested labels: %v", result.SuggestedLabels)
+	labels, dropped := constrainLabels(result.SuggestedLabels, input.AllowedLabels, ctx.Config.Labels)
+	if len(dropped) > 0 {
+		log.Printf("[triage] Dropped labels not in the repository's allowed set: %v", dropped)
+	}
+
+	if len(groups) > 0 {
+		var missing []string
+		labels, missing = applyLabelGroups(labels, result.LabelGroups, groups, input.LabelGroups)
+		if len(missing) > 0 {
+			log.Printf("[triage] No label picked for required groups: %v", missing)
+			ctx.Metadata["missing_label_groups"] = missing
+		}
+	}
+
+	ctx.Result.SuggestedLabels = labels
+	for _, label := range labels {
+		setLabelSource(ctx, s.Name(), label)
+	}
+	log.Printf("[triage] Suggested labels: %v", labels)
 
 	return nil
 }
@@ -0,0 +1,190 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package steps
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/similigh/simili-bot/internal/core/pipeline"
+)
+
+// reportMarker opens every triage report so later runs can find and update it.
+const reportMarker = "<!-- simili-bot-report -->"
+
+// onCallMarker opens the comment paging the on-call handle for a P0 issue,
+// so it is posted only once per issue.
+const onCallMarker = "<!-- simili-bot-on-call -->"
+
+// Hidden state embedded at the end of the report, used to work out what
+// changed since the previous run.
+const (
+	reportStatePrefix = "<!-- simili-bot-state "
+	reportStateSuffix = " -->"
+)
+
+// reportFooter starts the footer of the report; the changelog goes above it.
+const reportFooter = "---\n<sub>Generated by [Simili Bot]"
+
+// reportState is the part of a triage report that is tracked between runs.
+type reportState struct {
+	DuplicateOf int      `json:"duplicate_of,omitempty"`
+	Similar     []int    `json:"similar,omitempty"`
+	Labels      []string `json:"labels,omitempty"`
+	Transfer    string   `json:"transfer,omitempty"`
+	Changelog   []string `json:"changelog,omitempty"`
+}
+
+// currentReportState captures the decisions of this run. labels are the
+// labels the executor is about to apply.
+func currentReportState(ctx *pipeline.Context, labels []string) reportState {
+	st := reportState{Transfer: ctx.TransferTarget}
+	if ctx.Result.IsDuplicate {
+		st.DuplicateOf = ctx.Result.DuplicateOf
+	}
+	for _, similar := range ctx.SimilarIssues {
+		st.Similar = append(st.Similar, similar.Number)
+	}
+	sort.Ints(st.Similar)
+	st.Labels = append(st.Labels, labels...)
+	sort.Strings(st.Labels)
+	return st
+}
+
+// parseReportState extracts the state embedded in a previous report.
+func parseReportState(body string) (reportState, bool) {
+	var st reportState
+	start := strings.LastIndex(body, reportStatePrefix)
+	if start < 0 {
+		return st, false
+	}
+	rest := body[start+len(reportStatePrefix):]
+	end := strings.Index(rest, reportStateSuffix)
+	if end < 0 {
+		return st, false
+	}
+	if err := json.Unmarshal([]byte(rest[:end]), &st); err != nil {
+		return st, false
+	}
+	return st, true
+}
+
+// reportChanges describes what changed between two runs, one phrase per change.
+func reportChanges(prev, cur reportState) []string {
+	var changes []string
+
+	switch {
+	case prev.DuplicateOf == cur.DuplicateOf:
+	case prev.DuplicateOf == 0:
+		changes = append(changes, fmt.Sprintf("flagged as a possible duplicate of #%d", cur.DuplicateOf))
+	case cur.DuplicateOf == 0:
+		changes = append(changes, fmt.Sprintf("no longer flagged as a duplicate of #%d", prev.DuplicateOf))
+	default:
+		changes = append(changes, fmt.Sprintf("possible duplicate changed from #%d to #%d", prev.DuplicateOf, cur.DuplicateOf))
+	}
+
+	if added := missingInts(cur.Similar, prev.Similar); len(added) > 0 {
+		changes = append(changes, "new similar issues: "+joinNumbers(added))
+	}
+
+	if added := missingStrings(cur.Labels, prev.Labels); len(added) > 0 {
+		changes = append(changes, "labels added: "+joinLabels(added))
+	}
+	if removed := missingStrings(prev.Labels, cur.Labels); len(removed) > 0 {
+		changes = append(changes, "labels no longer suggested: "+joinLabels(removed))
+	}
+
+	if prev.Transfer != cur.Transfer {
+		if cur.Transfer == "" {
+			changes = append(changes, fmt.Sprintf("transfer to %s no longer suggested", prev.Transfer))
+		} else {
+			changes = append(changes, fmt.Sprintf("transfer to %s suggested", cur.Transfer))
+		}
+	}
+
+	return changes
+}
+
+// renderReport finalizes a triage report given the body of the previous one
+// ("" on the first run). A dated changelog entry is added when the tracked
+// decisions changed; at most limit entries are kept.
+func renderReport(comment, previous string, cur reportState, now time.Time, limit int) string {
+	if prev, ok := parseReportState(previous); ok {
+		cur.Changelog = prev.Changelog
+		if changes := reportChanges(prev, cur); len(changes) > 0 {
+			entry := fmt.Sprintf("**%s**: %s", now.UTC().Format("2006-01-02 15:04 UTC"), strings.Join(changes, "; "))
+			cur.Changelog = append([]string{entry}, cur.Changelog...)
+		}
+	}
+	if limit > 0 && len(cur.Changelog) > limit {
+		cur.Changelog = cur.Changelog[:limit]
+	}
+
+	body := comment
+	if len(cur.Changelog) > 0 {
+		var b strings.Builder
+		b.WriteString("<details>\n<summary>Changelog</summary>\n\n")
+		for _, entry := range cur.Changelog {
+			b.WriteString("- " + entry + "\n")
+		}
+		b.WriteString("\n</details>\n\n")
+
+		if i := strings.LastIndex(body, reportFooter); i >= 0 {
+			body = body[:i] + b.String() + body[i:]
+		} else {
+			body = strings.TrimRight(body, "\n") + "\n\n" + b.String()
+		}
+	}
+
+	// json.Marshal escapes '>' so the state cannot close the HTML comment early.
+	data, err := json.Marshal(cur)
+	if err != nil {
+		return body
+	}
+	return strings.TrimRight(body, "\n") + "\n" + reportStatePrefix + string(data) + reportStateSuffix
+}
+
+// missingInts returns the values of a that are not in b.
+func missingInts(a, b []int) []int {
+	var out []int
+	for _, v := range a {
+		if !slices.Contains(b, v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// missingStrings returns the values of a that are not in b, ignoring case.
+func missingStrings(a, b []string) []string {
+	var out []string
+	for _, v := range a {
+		if !hasLabel(b, v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func joinNumbers(numbers []int) string {
+	parts := make([]string, len(numbers))
+	for i, n := range numbers {
+		parts[i] = fmt.Sprintf("#%d", n)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func joinLabels(labels []string) string {
+	parts := make([]string, len(labels))
+	for i, l := range labels {
+		parts[i] = "`" + l + "`"
+	}
+	return strings.Join(parts, ", ")
+}
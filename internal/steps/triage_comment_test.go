@@ -0,0 +1,72 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package steps
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/similigh/simili-bot/internal/core/pipeline"
+)
+
+const testReport = reportMarker + "\n### Simili Triage Report\n\nbody\n---\n<sub>Generated by [Simili Bot](https://github.com/similigh/simili-bot)</sub>"
+
+func TestRenderReportFirstRun(t *testing.T) {
+	ctx := &pipeline.Context{
+		Result:        &pipeline.Result{IsDuplicate: true, DuplicateOf: 12},
+		SimilarIssues: []pipeline.SimilarIssue{{Number: 30}, {Number: 12}},
+	}
+	cur := currentReportState(ctx, []string{"potential-duplicate"})
+
+	body := renderReport(testReport, "", cur, time.Now(), 10)
+	if strings.Contains(body, "Changelog") {
+		t.Error("First report should not have a changelog")
+	}
+	st, ok := parseReportState(body)
+	if !ok || st.DuplicateOf != 12 || len(st.Similar) != 2 || st.Similar[0] != 12 || st.Labels[0] != "potential-duplicate" {
+		t.Errorf("Unexpected embedded state %+v (ok=%v)", st, ok)
+	}
+}
+
+func TestRenderReportChangelog(t *testing.T) {
+	now := time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC)
+	first := renderReport(testReport, "", reportState{Similar: []int{4}, Labels: []string{"bug"}}, now, 10)
+
+	// Nothing tracked changed: the report is identical, so no edit is needed.
+	if again := renderReport(testReport, first, reportState{Similar: []int{4}, Labels: []string{"bug"}}, now, 10); again != first {
+		t.Errorf("Unchanged run altered the report:\n%s", again)
+	}
+
+	second := renderReport(testReport, first, reportState{
+		DuplicateOf: 4,
+		Similar:     []int{4, 9},
+		Labels:      []string{"potential-duplicate"},
+		Transfer:    "acme/docs",
+	}, now, 10)
+	want := "- **2026-10-18 09:30 UTC**: flagged as a possible duplicate of #4; new similar issues: #9; " +
+		"labels added: `potential-duplicate`; labels no longer suggested: `bug`; transfer to acme/docs suggested\n"
+	if !strings.Contains(second, "<details>\n<summary>Changelog</summary>\n\n"+want) {
+		t.Errorf("Missing changelog entry in:\n%s", second)
+	}
+	if strings.Index(second, "Changelog") > strings.Index(second, reportFooter) {
+		t.Error("Changelog should be placed above the footer")
+	}
+
+	third := renderReport(testReport, second, reportState{Similar: []int{4, 9}}, now.Add(time.Hour), 1)
+	st, _ := parseReportState(third)
+	if len(st.Changelog) != 1 || !strings.Contains(st.Changelog[0], "10:30 UTC**: no longer flagged as a duplicate of #4") {
+		t.Errorf("Changelog = %v, want only the latest entry", st.Changelog)
+	}
+}
+
+func TestParseReportStateIgnoresGarbage(t *testing.T) {
+	for _, body := range []string{"", testReport, reportStatePrefix + "{not json" + reportStateSuffix} {
+		if _, ok := parseReportState(body); ok {
+			t.Errorf("parseReportState(%q) should fail", body)
+		}
+	}
+}
@@ -37,6 +37,12 @@ func (m *mockVectorStore) Upsert(_ context.Context, _ string, _ []*qdrant.Point)
 func (m *mockVectorStore) Search(_ context.Context, _ string, _ []float32, _ int, _ float64) ([]*qdrant.SearchResult, error) {
 	return m.results, m.err
 }
+func (m *mockVectorStore) FindByPayload(_ context.Context, _ string, _ string, _ []string, _ int) ([]*qdrant.SearchResult, error) {
+	return nil, nil
+}
+func (m *mockVectorStore) Get(_ context.Context, _ string, _ string) (*qdrant.Point, error) {
+	return nil, nil
+}
 func (m *mockVectorStore) Delete(_ context.Context, _ string, _ string) error { return nil }
 func (m *mockVectorStore) SetPayload(_ context.Context, _ string, _ string, _ map[string]interface{}) error {
 	return nil
@@ -0,0 +1,104 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+// Package codeowners parses GitHub CODEOWNERS files and resolves the owners
+// of repository paths.
+package codeowners
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Locations are the paths GitHub reads a CODEOWNERS file from, in order.
+var Locations = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// Rule is a single CODEOWNERS line.
+type Rule struct {
+	Pattern string
+	Owners  []string // "@user", "@org/team" or an email address
+	re      *regexp.Regexp
+}
+
+// File is a parsed CODEOWNERS file.
+type File struct {
+	Rules []Rule
+}
+
+// Parse parses a CODEOWNERS file. Comments, blank lines and lines with an
+// invalid pattern are skipped.
+func Parse(data []byte) *File {
+	f := &File{}
+	for _, line := range strings.Split(string(data), "\n") {
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		re, err := compile(fields[0])
+		if err != nil {
+			continue
+		}
+		f.Rules = append(f.Rules, Rule{Pattern: fields[0], Owners: fields[1:], re: re})
+	}
+	return f
+}
+
+// Owners returns the owners of a path. As on GitHub, the last matching rule
+// wins, and a matching rule without owners leaves the path unowned.
+func (f *File) Owners(path string) []string {
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "./"), "/")
+	for i := len(f.Rules) - 1; i >= 0; i-- {
+		if f.Rules[i].re.MatchString(path) {
+			if len(f.Rules[i].Owners) == 0 {
+				return nil
+			}
+			return f.Rules[i].Owners
+		}
+	}
+	return nil
+}
+
+// compile turns a gitignore-style CODEOWNERS pattern into a regexp. Patterns
+// with a leading or inner "/" are anchored at the repository root; others
+// match at any depth. A pattern also matches everything under a directory it
+// names.
+func compile(pattern string) (*regexp.Regexp, error) {
+	dirOnly := strings.HasSuffix(pattern, "/")
+	p := strings.TrimSuffix(pattern, "/")
+	anchored := strings.Contains(p, "/")
+	p = strings.TrimPrefix(p, "/")
+
+	var b strings.Builder
+	if anchored {
+		b.WriteString("^")
+	} else {
+		b.WriteString("^(?:.*/)?")
+	}
+	for i := 0; i < len(p); i++ {
+		switch {
+		case strings.HasPrefix(p[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(p[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case p[i] == '*':
+			b.WriteString("[^/]*")
+		case p[i] == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(p[i : i+1]))
+		}
+	}
+	if dirOnly {
+		b.WriteString("/.*$")
+	} else {
+		b.WriteString("(?:/.*)?$")
+	}
+	return regexp.Compile(b.String())
+}
@@ -0,0 +1,51 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package codeowners
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOwners(t *testing.T) {
+	f := Parse([]byte(`
+# Default owners
+*                 @acme/maintainers
+
+*.md              @docs-team   # trailing comment
+/internal/api/    @alice @acme/api
+docs/**/*.png     @designer
+build/            @ci
+/internal/api/generated/
+`))
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"main.go", []string{"@acme/maintainers"}},
+		{"README.md", []string{"@docs-team"}},
+		{"internal/api/handler.go", []string{"@alice", "@acme/api"}},
+		{"./internal/api/v2/routes.go", []string{"@alice", "@acme/api"}},
+		{"internal/api/README.md", []string{"@alice", "@acme/api"}},
+		{"internal/api/generated/types.go", nil},
+		{"docs/img/logo.png", []string{"@designer"}},
+		{"docs/logo.png", []string{"@designer"}},
+		{"tools/build/run.sh", []string{"@ci"}},
+		{"src/internal/api/x.go", []string{"@acme/maintainers"}},
+	}
+	for _, tt := range tests {
+		if got := f.Owners(tt.path); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Owners(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestOwnersEmptyFile(t *testing.T) {
+	if got := Parse(nil).Owners("main.go"); got != nil {
+		t.Errorf("Owners() = %v, want nil", got)
+	}
+}
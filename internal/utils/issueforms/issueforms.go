@@ -0,0 +1,272 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+// Package issueforms parses GitHub issue forms (.github/ISSUE_TEMPLATE/*.yml)
+// and checks issue bodies created from them.
+package issueforms
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/similigh/simili-bot/internal/utils/text"
+)
+
+// Directory is where GitHub reads issue forms from.
+const Directory = ".github/ISSUE_TEMPLATE"
+
+// noResponse is what GitHub renders for an optional field left empty.
+const noResponse = "_No response_"
+
+// headingPattern matches the "### Label" headings GitHub renders for each field.
+var headingPattern = regexp.MustCompile(`(?m)^###[ \t]+(.+?)[ \t]*$`)
+
+// checkedPattern matches a ticked checkbox option, e.g. "- [X] I searched".
+var checkedPattern = regexp.MustCompile(`(?m)^\s*[-*]\s+\[[xX]\]\s+(.+?)\s*$`)
+
+// checkboxPattern matches a checkbox option, ticked or not.
+var checkboxPattern = regexp.MustCompile(`^\s*[-*]\s+\[[ xX]\]\s`)
+
+// Form is a parsed issue form.
+type Form struct {
+	Path   string
+	Name   string
+	Labels []string
+	Fields []Field
+}
+
+// Field is an input element of a form. Markdown elements are not fields.
+type Field struct {
+	Type        string // "input", "textarea", "dropdown" or "checkboxes"
+	Label       string
+	Placeholder string
+	Value       string // Pre-filled value
+	Required    bool
+	Options     []Option // Checkbox options
+}
+
+// Option is a checkbox option.
+type Option struct {
+	Label    string
+	Required bool
+}
+
+// IsForm reports whether a template path is an issue form, as opposed to a
+// Markdown template or the template chooser config.
+func IsForm(p string) bool {
+	base := strings.ToLower(path.Base(p))
+	ext := path.Ext(base)
+	return (ext == ".yml" || ext == ".yaml") && strings.TrimSuffix(base, ext) != "config"
+}
+
+// stringList accepts a YAML sequence or a comma-separated string, as GitHub
+// does for a form's labels.
+type stringList []string
+
+func (l *stringList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		for _, s := range strings.Split(node.Value, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				*l = append(*l, s)
+			}
+		}
+		return nil
+	}
+	var items []string
+	if err := node.Decode(&items); err != nil {
+		return err
+	}
+	*l = items
+	return nil
+}
+
+type rawForm struct {
+	Name   string     `yaml:"name"`
+	Labels stringList `yaml:"labels"`
+	Body   []struct {
+		Type       string `yaml:"type"`
+		Attributes struct {
+			Label       string `yaml:"label"`
+			Placeholder string `yaml:"placeholder"`
+			Value       string `yaml:"value"`
+			Options     []struct {
+				Label    string `yaml:"label"`
+				Required bool   `yaml:"required"`
+			} `yaml:"options"`
+		} `yaml:"attributes"`
+		Validations struct {
+			Required bool `yaml:"required"`
+		} `yaml:"validations"`
+	} `yaml:"body"`
+}
+
+// Parse parses an issue form.
+func Parse(p string, data []byte) (*Form, error) {
+	var raw rawForm
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse issue form %s: %w", p, err)
+	}
+
+	form := &Form{Path: p, Name: raw.Name, Labels: raw.Labels}
+	for _, el := range raw.Body {
+		if el.Type == "markdown" || el.Attributes.Label == "" {
+			continue
+		}
+		field := Field{
+			Type:        el.Type,
+			Label:       strings.TrimSpace(el.Attributes.Label),
+			Placeholder: strings.TrimSpace(el.Attributes.Placeholder),
+			Value:       strings.TrimSpace(el.Attributes.Value),
+			Required:    el.Validations.Required,
+		}
+		if el.Type == "checkboxes" {
+			for _, o := range el.Attributes.Options {
+				field.Options = append(field.Options, Option{Label: strings.TrimSpace(o.Label), Required: o.Required})
+			}
+		}
+		form.Fields = append(form.Fields, field)
+	}
+	if form.Name == "" {
+		form.Name = strings.TrimSuffix(path.Base(p), path.Ext(p))
+	}
+	return form, nil
+}
+
+// Sections splits an issue body into the values under its "### " headings,
+// keyed by lower-cased heading.
+func Sections(body string) map[string]string {
+	sections := make(map[string]string)
+	matches := headingPattern.FindAllStringSubmatchIndex(body, -1)
+	for i, m := range matches {
+		end := len(body)
+		if i+1 < len(matches) {
+			end = matches[i+1][0]
+		}
+		heading := strings.ToLower(body[m[2]:m[3]])
+		sections[heading] = strings.TrimSpace(body[m[1]:end])
+	}
+	return sections
+}
+
+// Values returns what the author wrote in an issue body, without the parts
+// an issue form adds to every issue: the "### " field headings, the
+// "_No response_" of empty fields and checkbox options. Bodies not created
+// from a form only lose lines of those kinds.
+func Values(body string) string {
+	var kept []string
+	for _, line := range strings.Split(body, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == noResponse || headingPattern.MatchString(trimmed) || checkboxPattern.MatchString(line) {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	return strings.Join(kept, "\n")
+}
+
+// SimHash signs what the author wrote in an issue body. Form headings and
+// checkboxes are the same in every report from a form, so two different
+// short reports would otherwise sign within a few bits. Every signature
+// stored in or compared against the index must come from here.
+func SimHash(body string) uint64 {
+	return text.SimHash(Values(body))
+}
+
+// Match scores how likely it is that an issue was created from the form:
+// two points per field heading found in the body and one per form label on
+// the issue.
+func (f *Form) Match(sections map[string]string, labels []string) int {
+	score := 0
+	for _, field := range f.Fields {
+		if _, ok := sections[strings.ToLower(field.Label)]; ok {
+			score += 2
+		}
+	}
+	for _, fl := range f.Labels {
+		for _, l := range labels {
+			if strings.EqualFold(fl, l) {
+				score++
+			}
+		}
+	}
+	return score
+}
+
+// Detect returns the form an issue was most likely created from, or nil
+// when none of the forms' field headings appear in it. Form labels on the
+// issue break ties between forms with shared headings.
+func Detect(forms []*Form, sections map[string]string, labels []string) *Form {
+	var best *Form
+	bestScore := 0
+	for _, f := range forms {
+		if f.Match(sections, nil) == 0 {
+			continue
+		}
+		if score := f.Match(sections, labels); score > bestScore {
+			best, bestScore = f, score
+		}
+	}
+	return best
+}
+
+// Check returns a finding for each required field that is missing, empty or
+// still holds the form's placeholder or pre-filled text, and for each
+// required checkbox left unticked.
+func (f *Form) Check(sections map[string]string) []string {
+	var findings []string
+	for _, field := range f.Fields {
+		value, present := sections[strings.ToLower(field.Label)]
+
+		if field.Type == "checkboxes" {
+			checked := make(map[string]bool)
+			for _, m := range checkedPattern.FindAllStringSubmatch(value, -1) {
+				checked[strings.ToLower(m[1])] = true
+			}
+			for _, o := range field.Options {
+				if o.Required && !checked[strings.ToLower(o.Label)] {
+					findings = append(findings, fmt.Sprintf("Required checkbox %q under %q is not checked", o.Label, field.Label))
+				}
+			}
+			continue
+		}
+
+		if !field.Required {
+			continue
+		}
+		switch {
+		case !present:
+			findings = append(findings, fmt.Sprintf("Required field %q is missing", field.Label))
+		case value == "" || value == noResponse:
+			findings = append(findings, fmt.Sprintf("Required field %q is empty", field.Label))
+		case isPlaceholder(value, field):
+			findings = append(findings, fmt.Sprintf("Required field %q still contains the template text", field.Label))
+		}
+	}
+	return findings
+}
+
+// isPlaceholder reports whether value is just the field's placeholder or
+// pre-filled text, ignoring whitespace and code fences (textareas with a
+// render type wrap their value in one).
+func isPlaceholder(value string, field Field) bool {
+	v := normalize(value)
+	return v == "" || (field.Placeholder != "" && v == normalize(field.Placeholder)) ||
+		(field.Value != "" && v == normalize(field.Value))
+}
+
+func normalize(s string) string {
+	var lines []string
+	for _, line := range strings.Split(s, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			continue
+		}
+		lines = append(lines, strings.Fields(line)...)
+	}
+	return strings.ToLower(strings.Join(lines, " "))
+}
@@ -0,0 +1,162 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package issueforms
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const bugForm = `
+name: Bug report
+labels: bug, triage
+body:
+  - type: markdown
+    attributes:
+      value: Thanks for reporting!
+  - type: textarea
+    attributes:
+      label: What happened?
+      placeholder: Tell us what you see
+    validations:
+      required: true
+  - type: textarea
+    attributes:
+      label: Steps to reproduce
+      value: |
+        1.
+        2.
+    validations:
+      required: true
+  - type: input
+    attributes:
+      label: Version
+    validations:
+      required: true
+  - type: textarea
+    attributes:
+      label: Logs
+      render: shell
+  - type: checkboxes
+    attributes:
+      label: Checks
+      options:
+        - label: I searched existing issues
+          required: true
+        - label: I want to fix this myself
+`
+
+const featureForm = `
+name: Feature request
+labels: [enhancement]
+body:
+  - type: textarea
+    attributes:
+      label: What happened?
+    validations:
+      required: true
+  - type: textarea
+    attributes:
+      label: Proposal
+`
+
+func TestParse(t *testing.T) {
+	form, err := Parse(".github/ISSUE_TEMPLATE/bug.yml", []byte(bugForm))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if form.Name != "Bug report" || !reflect.DeepEqual(form.Labels, []string{"bug", "triage"}) {
+		t.Errorf("Unexpected form %q with labels %v", form.Name, form.Labels)
+	}
+	if len(form.Fields) != 5 {
+		t.Fatalf("Expected 5 fields (markdown skipped), got %d", len(form.Fields))
+	}
+	if checks := form.Fields[4]; len(checks.Options) != 2 || !checks.Options[0].Required || checks.Options[1].Required {
+		t.Errorf("Unexpected checkbox options %+v", checks.Options)
+	}
+
+	feature, err := Parse("feature.yaml", []byte(featureForm))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if !reflect.DeepEqual(feature.Labels, []string{"enhancement"}) {
+		t.Errorf("Expected list labels to parse, got %v", feature.Labels)
+	}
+
+	if _, err := Parse("broken.yml", []byte("body: [")); err == nil {
+		t.Error("Expected an error for invalid YAML")
+	}
+}
+
+func TestValues(t *testing.T) {
+	body := "### What happened?\n\nExport does nothing.\n\n### Logs\n\n_No response_\n\n" +
+		"### Checks\n\n- [X] I searched the issues\n- [ ] I can send a fix\n* [x] Latest version\n\n" +
+		"Steps:\n- open the page\n- click export"
+	want := "Export does nothing. Steps: - open the page - click export"
+	if got := strings.Join(strings.Fields(Values(body)), " "); got != want {
+		t.Errorf("Values() = %q, want %q", got, want)
+	}
+	if got := Values("Plain report without a form."); got != "Plain report without a form." {
+		t.Errorf("Values() changed a plain body: %q", got)
+	}
+}
+
+func TestSimHash(t *testing.T) {
+	form := "### What happened?\n\nExport does nothing.\n\n### Logs\n\n_No response_\n\n### Checks\n\n- [X] I searched the issues"
+	if got, want := SimHash(form), SimHash("Export does nothing."); got != want {
+		t.Errorf("SimHash() = %x, want the signature of the plain text %x", got, want)
+	}
+}
+
+func TestIsForm(t *testing.T) {
+	for p, want := range map[string]bool{
+		".github/ISSUE_TEMPLATE/bug.yml":      true,
+		".github/ISSUE_TEMPLATE/feature.YAML": true,
+		".github/ISSUE_TEMPLATE/config.yml":   false,
+		".github/ISSUE_TEMPLATE/bug.md":       false,
+	} {
+		if got := IsForm(p); got != want {
+			t.Errorf("IsForm(%q) = %v, want %v", p, got, want)
+		}
+	}
+}
+
+func TestDetectAndCheck(t *testing.T) {
+	bug, _ := Parse("bug.yml", []byte(bugForm))
+	feature, _ := Parse("feature.yml", []byte(featureForm))
+	forms := []*Form{feature, bug}
+
+	body := "### What happened?\n\nThe app crashes.\n\n### Steps to reproduce\n\n1.\n2.\n\n" +
+		"### Version\n\n_No response_\n\n### Logs\n\n```shell\n```\n\n### Checks\n\n- [ ] I searched existing issues\n- [x] I want to fix this myself\n"
+	sections := Sections(body)
+
+	if got := Detect(forms, sections, nil); got != bug {
+		t.Fatalf("Detect() = %v, want the bug form", got)
+	}
+	want := []string{
+		`Required field "Steps to reproduce" still contains the template text`,
+		`Required field "Version" is empty`,
+		`Required checkbox "I searched existing issues" under "Checks" is not checked`,
+	}
+	if got := bug.Check(sections); !reflect.DeepEqual(got, want) {
+		t.Errorf("Check() = %q, want %q", got, want)
+	}
+
+	// A shared heading alone is a tie broken by the issue's labels.
+	shared := Sections("### What happened?\n\nIt would be nice to have dark mode.")
+	if got := Detect(forms, shared, []string{"enhancement"}); got != feature {
+		t.Errorf("Detect() = %v, want the feature form", got)
+	}
+	if got := feature.Check(shared); got != nil {
+		t.Errorf("Expected no findings, got %q", got)
+	}
+
+	// Labels alone do not make an issue a form issue.
+	if got := Detect(forms, Sections("Crashes on start."), []string{"bug"}); got != nil {
+		t.Errorf("Detect() = %v, want nil", got)
+	}
+}
@@ -0,0 +1,241 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+// Package ratelimit provides client-side token-bucket rate limiting shared
+// by all workers that call the same provider.
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Limit caps the request and token rate of one provider. Zero means unlimited.
+type Limit struct {
+	RequestsPerMinute int
+	TokensPerMinute   int
+}
+
+// Limiter is a token-bucket limiter for requests and tokens per minute.
+// A nil *Limiter never waits, so callers do not need to check for one.
+type Limiter struct {
+	mu          sync.Mutex
+	requests    *bucket
+	tokens      *bucket
+	pausedUntil time.Time
+	now         func() time.Time
+}
+
+// New returns a limiter for the given limit, or nil when it is unlimited.
+func New(limit Limit) *Limiter {
+	if limit.RequestsPerMinute <= 0 && limit.TokensPerMinute <= 0 {
+		return nil
+	}
+	return newLimiter(limit, time.Now)
+}
+
+// Unlimited returns a limiter that never throttles on its own but still
+// honours PauseFor, so Retry-After is respected without a configured limit.
+func Unlimited() *Limiter {
+	return newLimiter(Limit{}, time.Now)
+}
+
+func newLimiter(limit Limit, now func() time.Time) *Limiter {
+	start := now()
+	return &Limiter{
+		requests: newBucket(limit.RequestsPerMinute, start),
+		tokens:   newBucket(limit.TokensPerMinute, start),
+		now:      now,
+	}
+}
+
+// Wait blocks until one request carrying the given number of tokens may be
+// sent, or until ctx is done.
+func (l *Limiter) Wait(ctx context.Context, tokens int) error {
+	if l == nil {
+		return nil
+	}
+	wait := l.reserve(tokens)
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Charge adjusts the token bucket once the real token count of a request is
+// known. A negative n refunds an over-estimate.
+func (l *Limiter) Charge(n int) {
+	if l == nil || l.tokens == nil || n == 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tokens.refill(l.now())
+	l.tokens.level -= float64(n)
+	if l.tokens.level > l.tokens.capacity {
+		l.tokens.level = l.tokens.capacity
+	}
+}
+
+// PauseFor holds back every request for d, e.g. after a Retry-After header.
+func (l *Limiter) PauseFor(d time.Duration) {
+	if l == nil || d <= 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if until := l.now().Add(d); until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+}
+
+// reserve takes capacity for one request and returns how long the caller
+// has to wait before sending it.
+func (l *Limiter) reserve(tokens int) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	wait := l.requests.take(now, 1)
+	if w := l.tokens.take(now, float64(tokens)); w > wait {
+		wait = w
+	}
+	if p := l.pausedUntil.Sub(now); p > wait {
+		wait = p
+	}
+	return wait
+}
+
+// bucket refills continuously at capacity per minute. Its level may go
+// negative: callers that overdraw it wait until it is back to zero, which
+// queues concurrent callers fairly.
+type bucket struct {
+	capacity float64
+	rate     float64 // per second
+	level    float64
+	last     time.Time
+}
+
+func newBucket(perMinute int, now time.Time) *bucket {
+	if perMinute <= 0 {
+		return nil
+	}
+	capacity := float64(perMinute)
+	return &bucket{capacity: capacity, rate: capacity / 60, level: capacity, last: now}
+}
+
+func (b *bucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.level += elapsed * b.rate
+		if b.level > b.capacity {
+			b.level = b.capacity
+		}
+		b.last = now
+	}
+}
+
+// take removes n units and returns how long until the level is non-negative.
+func (b *bucket) take(now time.Time, n float64) time.Duration {
+	if b == nil {
+		return 0
+	}
+	b.refill(now)
+	// A single request larger than the bucket could never be satisfied.
+	if n > b.capacity {
+		n = b.capacity
+	}
+	b.level -= n
+	if b.level >= 0 {
+		return 0
+	}
+	return time.Duration(-b.level / b.rate * float64(time.Second))
+}
+
+// RetryAfter returns how long the server asked clients to back off. It reads
+// Retry-After (seconds or an HTTP date) and, once the quota is exhausted,
+// X-RateLimit-Reset (Unix seconds). It returns 0 when no wait is requested.
+func RetryAfter(h http.Header, now time.Time) time.Duration {
+	if h == nil {
+		return 0
+	}
+	if v := h.Get("Retry-After"); v != "" {
+		if secs, err := strconv.Atoi(v); err == nil && secs > 0 {
+			return time.Duration(secs) * time.Second
+		}
+		if t, err := http.ParseTime(v); err == nil && t.After(now) {
+			return t.Sub(now)
+		}
+	}
+	if h.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			if t := time.Unix(reset, 0); t.After(now) {
+				return t.Sub(now)
+			}
+		}
+	}
+	return 0
+}
+
+// Transport is an http.RoundTripper that waits for the limiter before each
+// request and pauses it when a response asks clients to back off.
+type Transport struct {
+	Base    http.RoundTripper // nil = http.DefaultTransport
+	Limiter *Limiter
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.Limiter.Wait(req.Context(), 0); err != nil {
+		return nil, err
+	}
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	resp, err := base.RoundTrip(req)
+	if err == nil {
+		t.Limiter.PauseFor(RetryAfter(resp.Header, time.Now()))
+	}
+	return resp, err
+}
+
+// Registry hands out one shared limiter per provider name, so every client
+// and worker in the process draws from the same buckets.
+type Registry struct {
+	mu       sync.Mutex
+	limits   map[string]Limit
+	limiters map[string]*Limiter
+}
+
+// NewRegistry creates a registry from limits keyed by provider name.
+func NewRegistry(limits map[string]Limit) *Registry {
+	return &Registry{limits: limits, limiters: make(map[string]*Limiter)}
+}
+
+// Get returns the limiter for name, or nil when it has no configured limit.
+func (r *Registry) Get(name string) *Limiter {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if l, ok := r.limiters[name]; ok {
+		return l
+	}
+	l := New(r.limits[name])
+	r.limiters[name] = l
+	return l
+}
@@ -0,0 +1,167 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time { return c.t }
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{t: time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)}
+}
+
+func TestLimiter_RequestsPerMinute(t *testing.T) {
+	clock := newFakeClock()
+	l := newLimiter(Limit{RequestsPerMinute: 2}, clock.now)
+
+	if w := l.reserve(0); w != 0 {
+		t.Fatalf("first request waited %v", w)
+	}
+	if w := l.reserve(0); w != 0 {
+		t.Fatalf("second request waited %v", w)
+	}
+	if w := l.reserve(0); w != 30*time.Second {
+		t.Fatalf("third request wait = %v, want 30s", w)
+	}
+	// The third request overdrew the bucket, so the fourth queues behind it.
+	if w := l.reserve(0); w != time.Minute {
+		t.Fatalf("fourth request wait = %v, want 1m", w)
+	}
+
+	clock.t = clock.t.Add(2 * time.Minute)
+	if w := l.reserve(0); w != 0 {
+		t.Fatalf("request after refill waited %v", w)
+	}
+}
+
+func TestLimiter_TokensPerMinute(t *testing.T) {
+	clock := newFakeClock()
+	l := newLimiter(Limit{TokensPerMinute: 600}, clock.now)
+
+	if w := l.reserve(500); w != 0 {
+		t.Fatalf("first request waited %v", w)
+	}
+	if w := l.reserve(200); w != 10*time.Second {
+		t.Fatalf("wait = %v, want 10s", w)
+	}
+
+	// A refund of over-estimated tokens shortens the queue.
+	l.Charge(-100)
+	if w := l.reserve(0); w != 0 {
+		t.Fatalf("wait after refund = %v, want 0", w)
+	}
+}
+
+func TestLimiter_PauseFor(t *testing.T) {
+	clock := newFakeClock()
+	l := newLimiter(Limit{RequestsPerMinute: 1000}, clock.now)
+
+	l.PauseFor(5 * time.Second)
+	l.PauseFor(time.Second) // a shorter pause must not cut the longer one
+	if w := l.reserve(0); w != 5*time.Second {
+		t.Fatalf("wait = %v, want 5s", w)
+	}
+}
+
+func TestUnlimited_HonoursPause(t *testing.T) {
+	l := Unlimited()
+	if w := l.reserve(1000); w != 0 {
+		t.Fatalf("unlimited limiter should not wait, wait = %v", w)
+	}
+	l.PauseFor(time.Minute)
+	if w := l.reserve(0); w < 59*time.Second {
+		t.Fatalf("limiter should be paused ~1m, wait = %v", w)
+	}
+}
+
+func TestLimiter_NilAndCancelled(t *testing.T) {
+	var nilLimiter *Limiter
+	if err := nilLimiter.Wait(context.Background(), 100); err != nil {
+		t.Fatalf("nil limiter returned %v", err)
+	}
+	if New(Limit{}) != nil {
+		t.Fatal("New with no limits should return nil")
+	}
+
+	l := New(Limit{RequestsPerMinute: 1})
+	l.PauseFor(time.Hour)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := l.Wait(ctx, 0); err != context.Canceled {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		header http.Header
+		want   time.Duration
+	}{
+		{"none", http.Header{}, 0},
+		{"seconds", http.Header{"Retry-After": {"7"}}, 7 * time.Second},
+		{"http date", http.Header{"Retry-After": {now.Add(time.Minute).Format(http.TimeFormat)}}, time.Minute},
+		{"reset when exhausted", http.Header{
+			"X-Ratelimit-Remaining": {"0"},
+			"X-Ratelimit-Reset":     {strconv.FormatInt(now.Add(90*time.Second).Unix(), 10)},
+		}, 90 * time.Second},
+		{"reset with quota left", http.Header{
+			"X-Ratelimit-Remaining": {"10"},
+			"X-Ratelimit-Reset":     {strconv.FormatInt(now.Add(90*time.Second).Unix(), 10)},
+		}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RetryAfter(tt.header, now); got != tt.want {
+				t.Errorf("RetryAfter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransport_PausesOnRetryAfter(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	l := New(Limit{RequestsPerMinute: 1000})
+	client := &http.Client{Transport: &Transport{Limiter: l}}
+	resp, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if w := l.reserve(0); w < 29*time.Second {
+		t.Fatalf("limiter should be paused ~30s, wait = %v", w)
+	}
+}
+
+func TestRegistry_SharesLimiters(t *testing.T) {
+	r := NewRegistry(map[string]Limit{"openai": {RequestsPerMinute: 60}})
+	if r.Get("openai") == nil || r.Get("openai") != r.Get("openai") {
+		t.Fatal("expected one shared limiter per provider")
+	}
+	if r.Get("github") != nil {
+		t.Fatal("unconfigured provider should be unlimited")
+	}
+	var nilRegistry *Registry
+	if nilRegistry.Get("openai") != nil {
+		t.Fatal("nil registry should return nil")
+	}
+}
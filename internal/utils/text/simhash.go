@@ -0,0 +1,80 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package text
+
+import (
+	"hash/fnv"
+	"math/bits"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// simHashShingle is the number of consecutive words hashed together.
+const simHashShingle = 3
+
+// simHashMinWords is the shortest text that gets a signature. Short bodies
+// ("Same here", "+1") match each other without being re-posts.
+const simHashMinWords = 12
+
+// SimHash returns a 64-bit SimHash signature of s, computed over its
+// lower-cased words in overlapping runs of three. Punctuation, Markdown and
+// whitespace are ignored, so re-posts that only differ in formatting get the
+// same signature and small edits change only a few bits. It returns 0 for
+// texts with fewer than simHashMinWords words.
+func SimHash(s string) uint64 {
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) < simHashMinWords {
+		return 0
+	}
+
+	var weights [64]int
+	for i := 0; i+simHashShingle <= len(words); i++ {
+		h := fnv.New64a()
+		h.Write([]byte(strings.Join(words[i:i+simHashShingle], " ")))
+		sum := h.Sum64()
+		for bit := 0; bit < 64; bit++ {
+			if sum&(1<<bit) != 0 {
+				weights[bit]++
+			} else {
+				weights[bit]--
+			}
+		}
+	}
+
+	var hash uint64
+	for bit, w := range weights {
+		if w > 0 {
+			hash |= 1 << bit
+		}
+	}
+	return hash
+}
+
+// SimHashDistance returns the number of bits that differ between two
+// signatures: 0 for identical texts, a few for near-identical ones.
+func SimHashDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// FormatSimHash renders a signature as the 16-digit hex string stored in
+// point payloads (payload integers are signed).
+func FormatSimHash(h uint64) string {
+	s := strconv.FormatUint(h, 16)
+	return strings.Repeat("0", 16-len(s)) + s
+}
+
+// ParseSimHash parses a signature stored by FormatSimHash. It returns 0 for
+// an empty or malformed value.
+func ParseSimHash(s string) uint64 {
+	h, err := strconv.ParseUint(s, 16, 64)
+	if err != nil {
+		return 0
+	}
+	return h
+}
@@ -0,0 +1,56 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package text
+
+import "testing"
+
+const simHashBody = "When I click the export button on the reports page nothing happens. " +
+	"The browser console shows a network error for the export endpoint and no file is downloaded. " +
+	"This started after upgrading to version 2.3 and happens in both Chrome and Firefox."
+
+func TestSimHash(t *testing.T) {
+	base := SimHash(simHashBody)
+	if base == 0 {
+		t.Fatal("SimHash() = 0 for a full body")
+	}
+
+	reformatted := "**When I click the export button** on the reports page, nothing happens!\n\n" +
+		"The browser console shows a network error for the `export` endpoint and no file is downloaded.\n" +
+		"This started after upgrading to version 2.3 and happens in both Chrome and Firefox."
+	if d := SimHashDistance(base, SimHash(reformatted)); d != 0 {
+		t.Errorf("reformatted body distance = %d, want 0", d)
+	}
+
+	edited := simHashBody + " Any ideas?"
+	if d := SimHashDistance(base, SimHash(edited)); d > 10 {
+		t.Errorf("lightly edited body distance = %d, want a small distance", d)
+	}
+
+	different := "The dark theme makes the sidebar text unreadable because the contrast between the " +
+		"grey labels and the background is too low, especially on laptop screens in daylight."
+	if d := SimHashDistance(base, SimHash(different)); d < 16 {
+		t.Errorf("unrelated body distance = %d, want a large distance", d)
+	}
+
+	if got := SimHash("Same here, any update?"); got != 0 {
+		t.Errorf("SimHash() of a short text = %x, want 0", got)
+	}
+}
+
+func TestFormatSimHash(t *testing.T) {
+	for _, h := range []uint64{0, 1, 0xdeadbeef, ^uint64(0)} {
+		s := FormatSimHash(h)
+		if len(s) != 16 {
+			t.Errorf("FormatSimHash(%x) = %q, want 16 digits", h, s)
+		}
+		if got := ParseSimHash(s); got != h {
+			t.Errorf("ParseSimHash(%q) = %x, want %x", s, got, h)
+		}
+	}
+	if got := ParseSimHash("not hex"); got != 0 {
+		t.Errorf("ParseSimHash() of a malformed value = %x, want 0", got)
+	}
+}
@@ -0,0 +1,240 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package text
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// maxFingerprintFrames is how many of the innermost frames a fingerprint
+// covers. Deeper frames depend on how the code was called and vary between
+// otherwise identical crashes.
+const maxFingerprintFrames = 5
+
+var (
+	goPanic     = regexp.MustCompile(`^(panic|fatal error): (.+?)(?: \[recovered\])?$`)
+	goGoroutine = regexp.MustCompile(`^goroutine \d+ \[.*\]:$`)
+	goFunc      = regexp.MustCompile(`^((?:[\w./\[\]-]|\(\*?[\w\[\].]+\))+)\(.*\)$`)
+	goFile      = regexp.MustCompile(`^(\S+\.go):(\d+)(?: \+0x[0-9a-fA-F]+)?$`)
+
+	javaHeader = regexp.MustCompile(`^(?:Exception in thread "[^"]*" )?(?:Caused by: )?([\w$]+(?:\.[\w$]+)+(?:Exception|Error|Throwable))(?::.*)?$`)
+	javaFrame  = regexp.MustCompile(`^at ([\w$.<>/-]+)\((?:([\w$.-]+\.(?:java|kt|scala|groovy)):(\d+)|Native Method|Unknown Source)\)$`)
+
+	pyStart = regexp.MustCompile(`^Traceback \(most recent call last\):$`)
+	pyFrame = regexp.MustCompile(`^File "([^"]+)", line (\d+), in (\S+)$`)
+	pyError = regexp.MustCompile(`^([A-Za-z_][\w.]*)(?::.*)?$`)
+
+	jsHeader = regexp.MustCompile(`^(?:Uncaught )?((?:[A-Z]\w*)?(?:Error|Exception))(?:: .*)?$`)
+	jsFrame  = regexp.MustCompile(`^at (?:(?:async )?(.+?) \()?(.+?):(\d+):\d+\)?$`)
+
+	hexAddress = regexp.MustCompile(`0x[0-9a-fA-F]+`)
+	digits     = regexp.MustCompile(`\d+`)
+)
+
+// Frame is a single stack frame.
+type Frame struct {
+	Function string
+	File     string // Base name of the source file
+	Line     int
+}
+
+// StackTrace is a stack trace found in free text, with its frames
+// innermost first.
+type StackTrace struct {
+	Language string // "go", "java", "python" or "js"
+	Error    string // The error type, or the panic message for Go
+	Frames   []Frame
+}
+
+// ExtractStackTraces finds the Go, Java, Python and JavaScript stack traces
+// in text. Traces without any frame are ignored.
+func ExtractStackTraces(s string) []StackTrace {
+	lines := strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+
+	var traces []StackTrace
+	for i := 0; i < len(lines); i++ {
+		var trace StackTrace
+		var end int
+		switch line := strings.TrimSpace(lines[i]); {
+		case goPanic.MatchString(line):
+			trace, end = parseGo(lines, i)
+		case pyStart.MatchString(line):
+			trace, end = parsePython(lines, i)
+		case javaHeader.MatchString(line) && i+1 < len(lines) && javaFrame.MatchString(strings.TrimSpace(lines[i+1])):
+			trace, end = parseJava(lines, i)
+		case jsHeader.MatchString(line):
+			trace, end = parseJS(lines, i)
+		default:
+			continue
+		}
+		if len(trace.Frames) > 0 {
+			traces = append(traces, trace)
+			i = end - 1
+		}
+	}
+	return traces
+}
+
+// parseGo parses a Go panic starting at lines[start]: the panic message,
+// then the first goroutine's function/file line pairs. Frames inside the
+// runtime are skipped.
+func parseGo(lines []string, start int) (StackTrace, int) {
+	m := goPanic.FindStringSubmatch(strings.TrimSpace(lines[start]))
+	message := digits.ReplaceAllString(hexAddress.ReplaceAllString(m[2], "0x?"), "N")
+	trace := StackTrace{Language: "go", Error: m[1] + ": " + message}
+
+	i := start + 1
+	for i < len(lines) {
+		line := strings.TrimSpace(lines[i])
+		if line != "" && !strings.HasPrefix(line, "[signal ") && !goGoroutine.MatchString(line) {
+			break
+		}
+		i++
+	}
+	for i+1 < len(lines) {
+		fn := goFunc.FindStringSubmatch(strings.TrimSpace(lines[i]))
+		file := goFile.FindStringSubmatch(strings.TrimSpace(lines[i+1]))
+		if fn == nil || file == nil {
+			break
+		}
+		if name := fn[1]; !strings.HasPrefix(name, "runtime.") && name != "panic" {
+			line, _ := strconv.Atoi(file[2])
+			trace.Frames = append(trace.Frames, Frame{Function: name, File: path.Base(file[1]), Line: line})
+		}
+		i += 2
+	}
+	return trace, i
+}
+
+// parseJava parses a Java (or Kotlin/Scala) exception starting at
+// lines[start], up to the first line that is not a frame.
+func parseJava(lines []string, start int) (StackTrace, int) {
+	trace := StackTrace{Language: "java", Error: javaHeader.FindStringSubmatch(strings.TrimSpace(lines[start]))[1]}
+	i := start + 1
+	for ; i < len(lines); i++ {
+		m := javaFrame.FindStringSubmatch(strings.TrimSpace(lines[i]))
+		if m == nil {
+			break
+		}
+		line, _ := strconv.Atoi(m[3])
+		trace.Frames = append(trace.Frames, Frame{Function: m[1], File: m[2], Line: line})
+	}
+	return trace, i
+}
+
+// parsePython parses a Python traceback starting at lines[start]. Each
+// frame line is followed by an indented source line, and the traceback ends
+// with the unindented error line. Python lists the innermost call last, so
+// the frames are reversed.
+func parsePython(lines []string, start int) (StackTrace, int) {
+	trace := StackTrace{Language: "python"}
+	i := start + 1
+	for ; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if m := pyFrame.FindStringSubmatch(line); m != nil {
+			n, _ := strconv.Atoi(m[2])
+			trace.Frames = append(trace.Frames, Frame{Function: m[3], File: path.Base(m[1]), Line: n})
+			continue
+		}
+		if line == "" || lines[i] != strings.TrimLeft(lines[i], " \t") {
+			continue // Source line or error marker under a frame
+		}
+		if m := pyError.FindStringSubmatch(line); m != nil {
+			trace.Error = m[1]
+			i++
+		}
+		break
+	}
+	slices.Reverse(trace.Frames)
+	return trace, i
+}
+
+// parseJS parses a JavaScript error starting at lines[start].
+func parseJS(lines []string, start int) (StackTrace, int) {
+	trace := StackTrace{Language: "js", Error: jsHeader.FindStringSubmatch(strings.TrimSpace(lines[start]))[1]}
+	i := start + 1
+	for ; i < len(lines); i++ {
+		m := jsFrame.FindStringSubmatch(strings.TrimSpace(lines[i]))
+		if m == nil {
+			break
+		}
+		fn := m[1]
+		if fn == "" {
+			fn = "<anonymous>"
+		}
+		file := m[2]
+		if j := strings.IndexAny(file, "?#"); j >= 0 {
+			file = file[:j]
+		}
+		line, _ := strconv.Atoi(m[3])
+		trace.Frames = append(trace.Frames, Frame{Function: fn, File: path.Base(file), Line: line})
+	}
+	return trace, i
+}
+
+// Normalize renders the trace without anything that differs between two
+// occurrences of the same crash: addresses, argument values, directories
+// and, unless lineNumbers is set, line numbers. Only the innermost
+// maxFingerprintFrames frames are kept.
+func (t StackTrace) Normalize(lineNumbers bool) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s\n", t.Language, t.Error)
+	for i, f := range t.Frames {
+		if i == maxFingerprintFrames {
+			break
+		}
+		b.WriteString(hexAddress.ReplaceAllString(f.Function, "0x?"))
+		if f.File != "" {
+			b.WriteString(" " + f.File)
+			if lineNumbers && f.Line > 0 {
+				fmt.Fprintf(&b, ":%d", f.Line)
+			}
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// Fingerprint returns a short hash of the normalized trace. Two reports of
+// the same crash get the same fingerprint.
+func (t StackTrace) Fingerprint(lineNumbers bool) string {
+	sum := sha256.Sum256([]byte(t.Normalize(lineNumbers)))
+	return hex.EncodeToString(sum[:8])
+}
+
+// Fingerprints returns the distinct fingerprints of the stack traces in s.
+func Fingerprints(s string, lineNumbers bool) []string {
+	var fps []string
+	for _, t := range ExtractStackTraces(s) {
+		if fp := t.Fingerprint(lineNumbers); !slices.Contains(fps, fp) {
+			fps = append(fps, fp)
+		}
+	}
+	return fps
+}
+
+// IssueFingerprints returns the distinct fingerprints of the stack traces in
+// an issue. The title and body, and each comment, are scanned as raw text so
+// a trace pasted at the start of any of them is found. Indexing and lookup
+// must both use it for their fingerprints to match.
+func IssueFingerprints(title, body string, comments []Comment, lineNumbers bool) []string {
+	fps := Fingerprints(title+"\n\n"+body, lineNumbers)
+	for _, c := range comments {
+		for _, fp := range Fingerprints(c.Body, lineNumbers) {
+			if !slices.Contains(fps, fp) {
+				fps = append(fps, fp)
+			}
+		}
+	}
+	return fps
+}
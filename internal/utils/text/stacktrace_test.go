@@ -0,0 +1,114 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package text
+
+import (
+	"reflect"
+	"testing"
+)
+
+const goTrace = "panic: runtime error: index out of range [5] with length 3\n\n" +
+	"goroutine 1 [running]:\n" +
+	"panic({0x4a3b20?, 0xc000012345?})\n\t/usr/local/go/src/runtime/panic.go:770 +0x132\n" +
+	"github.com/acme/app/server.(*Server).handle(0xc0000a2000, {0x0, 0x0})\n\t/home/alice/app/server/server.go:42 +0x1d\n" +
+	"main.main()\n\t/home/alice/app/main.go:12 +0x25\n" +
+	"exit status 2"
+
+const javaTrace = "Exception in thread \"main\" java.lang.IllegalStateException: user 42 not found\n" +
+	"\tat com.acme.UserService.find(UserService.java:88)\n" +
+	"\tat com.acme.Main.main(Main.java:10)\n" +
+	"Caused by: java.io.IOException: closed\n" +
+	"\tat com.acme.Db.query(Db.java:5)\n" +
+	"\t... 2 more"
+
+const pythonTrace = "Traceback (most recent call last):\n" +
+	"  File \"/srv/app/worker.py\", line 30, in <module>\n" +
+	"    run()\n" +
+	"  File \"/srv/app/worker.py\", line 12, in run\n" +
+	"    job = jobs[\"id\"]\n" +
+	"          ~~~~^^^^^^\n" +
+	"KeyError: 'id'"
+
+const jsTrace = "TypeError: Cannot read properties of undefined (reading 'map')\n" +
+	"    at renderList (/app/src/list.js:14:20)\n" +
+	"    at async handler (webpack:///./src/api.js?abc:3:7)\n" +
+	"    at /app/src/index.js:99:1"
+
+func TestExtractStackTraces(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want StackTrace
+	}{
+		{"go", goTrace, StackTrace{Language: "go", Error: "panic: runtime error: index out of range [N] with length N", Frames: []Frame{
+			{"github.com/acme/app/server.(*Server).handle", "server.go", 42},
+			{"main.main", "main.go", 12},
+		}}},
+		{"java", javaTrace, StackTrace{Language: "java", Error: "java.lang.IllegalStateException", Frames: []Frame{
+			{"com.acme.UserService.find", "UserService.java", 88},
+			{"com.acme.Main.main", "Main.java", 10},
+		}}},
+		{"python", pythonTrace, StackTrace{Language: "python", Error: "KeyError", Frames: []Frame{
+			{"run", "worker.py", 12},
+			{"<module>", "worker.py", 30},
+		}}},
+		{"js", jsTrace, StackTrace{Language: "js", Error: "TypeError", Frames: []Frame{
+			{"renderList", "list.js", 14},
+			{"handler", "api.js", 3},
+			{"<anonymous>", "index.js", 99},
+		}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			traces := ExtractStackTraces("It crashed:\n\n```\n" + tt.text + "\n```\n")
+			if len(traces) == 0 {
+				t.Fatal("Expected a stack trace")
+			}
+			if !reflect.DeepEqual(traces[0], tt.want) {
+				t.Errorf("ExtractStackTraces() = %+v, want %+v", traces[0], tt.want)
+			}
+		})
+	}
+
+	if traces := ExtractStackTraces(javaTrace); len(traces) != 2 || traces[1].Error != "java.io.IOException" {
+		t.Errorf("Expected the cause as a second trace, got %+v", traces)
+	}
+	if traces := ExtractStackTraces("TypeError: x is undefined, but no stack.\nRuntimeError: nope"); traces != nil {
+		t.Errorf("Expected no traces without frames, got %+v", traces)
+	}
+}
+
+func TestFingerprints(t *testing.T) {
+	// The same crash reported from another machine, build and wording.
+	other := "Seeing this after upgrading:\n" +
+		"panic: runtime error: index out of range [7] with length 2\n\n" +
+		"goroutine 19 [running]:\n" +
+		"github.com/acme/app/server.(*Server).handle(0xc000140000, {0x1, 0x2})\n\t/build/server/server.go:45 +0x3f\n" +
+		"main.main()\n\t/build/main.go:12 +0x25\n"
+
+	a, b := Fingerprints(goTrace, false), Fingerprints(other, false)
+	if len(a) != 1 || !reflect.DeepEqual(a, b) {
+		t.Errorf("Expected matching fingerprints, got %v and %v", a, b)
+	}
+	if reflect.DeepEqual(Fingerprints(goTrace, true), Fingerprints(other, true)) {
+		t.Error("Expected fingerprints with line numbers to differ")
+	}
+	if reflect.DeepEqual(a, Fingerprints(pythonTrace, false)) {
+		t.Error("Expected different crashes to get different fingerprints")
+	}
+	if fps := Fingerprints("Clicking save does nothing.", false); fps != nil {
+		t.Errorf("Expected no fingerprints, got %v", fps)
+	}
+}
+
+func TestIssueFingerprints(t *testing.T) {
+	comments := []Comment{{Author: "bob", Body: pythonTrace}, {Author: "carol", Body: goTrace}}
+	got := IssueFingerprints("Crash", goTrace, comments, false)
+	want := append(Fingerprints(goTrace, false), Fingerprints(pythonTrace, false)...)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("IssueFingerprints() = %v, want %v", got, want)
+	}
+}
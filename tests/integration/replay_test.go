@@ -0,0 +1,179 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package integration
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/similigh/simili-bot/internal/core/config"
+	"github.com/similigh/simili-bot/internal/core/pipeline"
+	"github.com/similigh/simili-bot/internal/integrations/ai"
+	"github.com/similigh/simili-bot/internal/integrations/qdrant"
+	"github.com/similigh/simili-bot/internal/steps"
+)
+
+// issueTriageCassettes holds the LLM and embedding responses for
+// TestIssueTriageReplay. They are synthetic: written by hand (the embeddings
+// have 8 dimensions) and marked "synthetic" in place of a provider and model.
+// To replace them with recordings from real providers:
+//
+//	SIMILI_AI_RECORD=testdata/cassettes/issue-triage GEMINI_API_KEY=... go test ./tests/integration -run TestIssueTriageReplay
+const issueTriageCassettes = "testdata/cassettes/issue-triage"
+
+// memoryStore is a VectorStore returning a fixed set of search results.
+type memoryStore struct {
+	results  []*qdrant.SearchResult
+	upserted int
+}
+
+func (m *memoryStore) CreateCollection(ctx context.Context, name string, dimension int) error {
+	return nil
+}
+
+func (m *memoryStore) CollectionExists(ctx context.Context, name string) (bool, error) {
+	return true, nil
+}
+
+func (m *memoryStore) Upsert(ctx context.Context, collectionName string, points []*qdrant.Point) error {
+	m.upserted += len(points)
+	return nil
+}
+
+func (m *memoryStore) Search(ctx context.Context, collectionName string, vector []float32, limit int, threshold float64) ([]*qdrant.SearchResult, error) {
+	return m.results, nil
+}
+
+func (m *memoryStore) FindByPayload(ctx context.Context, collectionName string, key string, values []string, limit int) ([]*qdrant.SearchResult, error) {
+	return nil, nil
+}
+
+func (m *memoryStore) Get(ctx context.Context, collectionName string, id string) (*qdrant.Point, error) {
+	return nil, nil
+}
+
+func (m *memoryStore) Delete(ctx context.Context, collectionName string, id string) error {
+	return nil
+}
+
+func (m *memoryStore) SetPayload(ctx context.Context, collectionName string, id string, payload map[string]interface{}) error {
+	return nil
+}
+
+func (m *memoryStore) Close() error {
+	return nil
+}
+
+// replayClients returns clients serving the recorded responses, or recording
+// clients when SIMILI_AI_RECORD is set.
+func replayClients(t *testing.T) (*ai.Embedder, *ai.LLMClient) {
+	t.Helper()
+	if os.Getenv(ai.EnvRecordDir) != "" {
+		embedder, err := ai.NewEmbedder("", "")
+		if err != nil {
+			t.Fatalf("Failed to create recording embedder: %v", err)
+		}
+		llm, err := ai.NewLLMClient("")
+		if err != nil {
+			t.Fatalf("Failed to create recording LLM client: %v", err)
+		}
+		return embedder, llm
+	}
+
+	cassette, err := ai.OpenCassette(issueTriageCassettes, ai.CassetteReplay)
+	if err != nil {
+		t.Fatalf("Failed to open cassettes: %v", err)
+	}
+	return ai.NewReplayEmbedder(cassette, ""), ai.NewReplayLLMClient(cassette, "")
+}
+
+func TestIssueTriageReplay(t *testing.T) {
+	embedder, llm := replayClients(t)
+	runIssueTriage(t, embedder, llm)
+}
+
+// runIssueTriage runs the issue-triage preset on a crash report with one
+// similar issue (#42) in the vector store and checks the step outcomes.
+func runIssueTriage(t *testing.T, embedder *ai.Embedder, llm *ai.LLMClient) {
+	cfg := &config.Config{
+		Qdrant: config.QdrantConfig{Collection: "issues"},
+		Defaults: config.DefaultsConfig{
+			SimilarityThreshold: 0.65,
+			MaxSimilarToShow:    5,
+			DuplicateCandidates: 5,
+		},
+	}
+	issue := &pipeline.Issue{
+		Org:       "test-org",
+		Repo:      "test-repo",
+		Number:    1337,
+		Title:     "App crashes when saving settings",
+		Body:      "Steps to reproduce:\n1. Open Settings\n2. Change the theme\n3. Click Save\n\nExpected: settings are saved.\nActual: the app crashes with a null pointer exception.\n\nVersion: 2.3.1 on macOS 14.",
+		State:     "open",
+		Author:    "octocat",
+		EventType: "issues",
+	}
+
+	store := &memoryStore{results: []*qdrant.SearchResult{{
+		ID:    "issue-42",
+		Score: 0.93,
+		Payload: map[string]interface{}{
+			"number": 42,
+			"title":  "Crash on saving settings after changing theme",
+			"text":   "Saving settings after switching the theme crashes the app with a NullPointerException.",
+			"url":    "https://github.com/test-org/test-repo/issues/42",
+			"state":  "open",
+		},
+	}}}
+	deps := &pipeline.Dependencies{
+		Embedder:    embedder,
+		LLMClient:   llm,
+		VectorStore: store,
+		DryRun:      true,
+	}
+
+	registry := pipeline.NewRegistry()
+	steps.RegisterAll(registry)
+	p, err := registry.BuildFromNames(pipeline.ResolveSteps(nil, "issue-triage"), deps)
+	if err != nil {
+		t.Fatalf("Failed to build pipeline: %v", err)
+	}
+
+	pCtx := pipeline.NewContext(context.Background(), issue, cfg)
+	if err := p.Run(pCtx); err != nil {
+		t.Fatalf("Pipeline execution failed: %v", err)
+	}
+
+	result := pCtx.Result
+	if len(result.Errors) > 0 {
+		t.Fatalf("Pipeline reported errors: %v", result.Errors)
+	}
+	if len(result.SimilarFound) != 1 || result.SimilarFound[0].Number != 42 {
+		t.Fatalf("Expected similar issue #42, got %+v", result.SimilarFound)
+	}
+	if !result.IsDuplicate || result.DuplicateOf != 42 {
+		t.Errorf("Expected duplicate of #42, got is_duplicate=%v duplicate_of=%d", result.IsDuplicate, result.DuplicateOf)
+	}
+	if !containsString(result.SuggestedLabels, "bug") {
+		t.Errorf("Expected suggested label 'bug', got %v", result.SuggestedLabels)
+	}
+	if result.QualityScore < 0.7 {
+		t.Errorf("Expected a good quality score, got %.2f", result.QualityScore)
+	}
+	if result.Usage.LLMCalls == 0 || result.Usage.EmbeddingCalls == 0 {
+		t.Errorf("Expected replayed calls to be counted, got %+v", result.Usage)
+	}
+}
+
+func containsString(list []string, want string) bool {
+	for _, s := range list {
+		if s == want {
+			return true
+		}
+	}
+	return false
+}